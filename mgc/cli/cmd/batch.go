@@ -0,0 +1,669 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/invopop/yaml"
+	"github.com/spf13/cobra"
+	"magalu.cloud/cli/ui"
+	"magalu.cloud/core"
+	mgcConfigPkg "magalu.cloud/core/config"
+	"magalu.cloud/core/progress_report"
+	"magalu.cloud/core/utils"
+	mgcSdk "magalu.cloud/sdk"
+)
+
+const (
+	batchManifestVersion    = "1.0.0"
+	batchDefaultConcurrency = 4
+
+	batchFileFlag        = "file"
+	batchConcurrencyFlag = "concurrency"
+
+	batchStatusSucceeded = "succeeded"
+	batchStatusFailed    = "failed"
+	batchStatusSkipped   = "skipped"
+
+	// strings starting with this prefix are JSONPath expressions, use "$$" for a literal "$"
+	batchExpressionPrefix = "$"
+
+	batchSummaryOutput = "table=ID:$[*].id,COMMAND:$[*].command,STATUS:$[*].status,DURATION:$[*].duration,ERROR:$[*].error"
+)
+
+var (
+	batchStepIdRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// references to other steps in expressions, such as "$.steps.vpc.result.id" or '$.steps["vpc"].result'
+	batchStepRefRe = regexp.MustCompile(`\bsteps(?:\.([A-Za-z_][A-Za-z0-9_]*)|\[["']([^"']+)["']\])`)
+)
+
+// batchManifest is the file given to "batch run", listing the commands to
+// execute. Steps run concurrently, unless they depend on each other, either
+// explicitly with "dependsOn" or by referencing the results of other steps.
+type batchManifest struct {
+	Version     string       `json:"version"`
+	Concurrency int          `json:"concurrency,omitempty"`
+	Steps       []*batchStep `json:"steps"`
+}
+
+type batchStep struct {
+	Id              string         `json:"id"`
+	Command         string         `json:"command"`
+	DependsOn       []string       `json:"dependsOn,omitempty"`
+	WaitTermination bool           `json:"waitTermination,omitempty"`
+	Parameters      map[string]any `json:"parameters,omitempty"`
+	Configs         map[string]any `json:"configs,omitempty"`
+
+	// Materialized values, populated in validate():
+
+	executor     core.Executor
+	dependencies []string
+}
+
+// batchStepResult is reported in the summary and, while the batch is running,
+// as "$.steps.<id>" in the JSONPath document, same as in blueprints.
+type batchStepResult struct {
+	Id         string
+	Command    string
+	Status     string
+	Duration   time.Duration
+	Parameters core.Parameters
+	Configs    core.Configs
+	Result     core.Value
+	Err        error
+}
+
+func (r *batchStepResult) jsonPathDocument() map[string]any {
+	var errMsg any
+	if r.Err != nil {
+		errMsg = r.Err.Error()
+	}
+	return map[string]any{
+		"id":         r.Id,
+		"command":    r.Command,
+		"status":     r.Status,
+		"parameters": r.Parameters,
+		"configs":    r.Configs,
+		"result":     r.Result,
+		"error":      errMsg,
+		"skipped":    r.Status == batchStatusSkipped,
+	}
+}
+
+func (r *batchStepResult) summary() map[string]any {
+	summary := map[string]any{
+		"id":       r.Id,
+		"command":  r.Command,
+		"status":   r.Status,
+		"duration": r.Duration.Round(time.Millisecond).String(),
+	}
+	if r.Err != nil {
+		summary["error"] = r.Err.Error()
+	}
+	if r.Result != nil {
+		summary["result"] = r.Result
+	}
+	return summary
+}
+
+func parseBatchManifest(data []byte) (*batchManifest, error) {
+	manifest := &batchManifest{}
+	if err := yaml.Unmarshal(data, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func isBatchExpression(value string) bool {
+	return strings.HasPrefix(value, batchExpressionPrefix) && !strings.HasPrefix(value, batchExpressionPrefix+batchExpressionPrefix)
+}
+
+// visitBatchExpressions calls cb for every JSONPath expression in value, recursively
+func visitBatchExpressions(value any, cb func(expression string) error) error {
+	switch v := value.(type) {
+	case string:
+		if isBatchExpression(v) {
+			return cb(v)
+		}
+	case map[string]any:
+		for k, item := range v {
+			if err := visitBatchExpressions(item, cb); err != nil {
+				return &core.ChainedError{Name: k, Err: err}
+			}
+		}
+	case []any:
+		for i, item := range v {
+			if err := visitBatchExpressions(item, cb); err != nil {
+				return &core.ChainedError{Name: fmt.Sprint(i), Err: err}
+			}
+		}
+	}
+	return nil
+}
+
+// resolveBatchValue returns a copy of value with the JSONPath expressions
+// replaced by their results in the document
+func resolveBatchValue(value any, document map[string]any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		if isBatchExpression(v) {
+			return utils.GetJsonPath(v, document)
+		}
+		if strings.HasPrefix(v, batchExpressionPrefix) {
+			return v[len(batchExpressionPrefix):], nil
+		}
+		return v, nil
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for k, item := range v {
+			resolved, err := resolveBatchValue(item, document)
+			if err != nil {
+				return nil, &core.ChainedError{Name: k, Err: err}
+			}
+			result[k] = resolved
+		}
+		return result, nil
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			resolved, err := resolveBatchValue(item, document)
+			if err != nil {
+				return nil, &core.ChainedError{Name: fmt.Sprint(i), Err: err}
+			}
+			result[i] = resolved
+		}
+		return result, nil
+	default:
+		return value, nil
+	}
+}
+
+func resolveBatchMap(m map[string]any, document map[string]any) (map[string]any, error) {
+	if m == nil {
+		return map[string]any{}, nil
+	}
+	resolved, err := resolveBatchValue(m, document)
+	if err != nil {
+		return nil, err
+	}
+	return resolved.(map[string]any), nil
+}
+
+func (s *batchStep) validate(ids []string, resolveCommand func(command string) (core.Executor, error)) (err error) {
+	if s.Command == "" {
+		return errors.New("missing command")
+	}
+	if s.executor, err = resolveCommand(s.Command); err != nil {
+		return &core.ChainedError{Name: "command", Err: err}
+	}
+
+	addDependency := func(id string) error {
+		if id == s.Id {
+			return errors.New("step can't depend on itself")
+		}
+		if !slices.Contains(ids, id) {
+			return fmt.Errorf("unknown step %q", id)
+		}
+		if !slices.Contains(s.dependencies, id) {
+			s.dependencies = append(s.dependencies, id)
+		}
+		return nil
+	}
+
+	s.dependencies = nil
+	for _, id := range s.DependsOn {
+		if err = addDependency(id); err != nil {
+			return &core.ChainedError{Name: "dependsOn", Err: err}
+		}
+	}
+
+	checkExpression := func(expression string) error {
+		if _, err := utils.NewJsonPath(expression); err != nil {
+			return err
+		}
+		for _, match := range batchStepRefRe.FindAllStringSubmatch(expression, -1) {
+			id := match[1]
+			if id == "" {
+				id = match[2]
+			}
+			if err := addDependency(id); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err = visitBatchExpressions(s.Parameters, checkExpression); err != nil {
+		return &core.ChainedError{Name: "parameters", Err: err}
+	}
+	if err = visitBatchExpressions(s.Configs, checkExpression); err != nil {
+		return &core.ChainedError{Name: "configs", Err: err}
+	}
+
+	return nil
+}
+
+func (m *batchManifest) validate(resolveCommand func(command string) (core.Executor, error)) error {
+	if m.Version != batchManifestVersion {
+		return fmt.Errorf("expected batch version %q, got %q", batchManifestVersion, m.Version)
+	}
+	if m.Concurrency < 0 {
+		return fmt.Errorf("invalid concurrency %d", m.Concurrency)
+	}
+	if len(m.Steps) == 0 {
+		return errors.New("missing steps")
+	}
+
+	ids := make([]string, 0, len(m.Steps))
+	for i, step := range m.Steps {
+		if !batchStepIdRe.MatchString(step.Id) {
+			return &core.ChainedError{Name: fmt.Sprintf("steps[%d]", i), Err: fmt.Errorf("invalid id %q, use letters, digits and '_'", step.Id)}
+		}
+		if slices.Contains(ids, step.Id) {
+			return &core.ChainedError{Name: step.Id, Err: errors.New("duplicated step id")}
+		}
+		ids = append(ids, step.Id)
+	}
+
+	for _, step := range m.Steps {
+		if err := step.validate(ids, resolveCommand); err != nil {
+			return &core.ChainedError{Name: step.Id, Err: err}
+		}
+	}
+
+	return m.checkCycles()
+}
+
+func (m *batchManifest) checkCycles() error {
+	byId := make(map[string]*batchStep, len(m.Steps))
+	for _, step := range m.Steps {
+		byId[step.Id] = step
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	var visit func(step *batchStep, path []string) error
+	visit = func(step *batchStep, path []string) error {
+		path = append(path, step.Id)
+		switch state[step.Id] {
+		case visiting:
+			return fmt.Errorf("dependency cycle: %s", strings.Join(path, " -> "))
+		case visited:
+			return nil
+		}
+		state[step.Id] = visiting
+		for _, id := range step.dependencies {
+			if err := visit(byId[id], path); err != nil {
+				return err
+			}
+		}
+		state[step.Id] = visited
+		return nil
+	}
+
+	for _, step := range m.Steps {
+		if err := visit(step, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type batchRunFunc func(ctx context.Context, step *batchStep, parameters core.Parameters, configs core.Configs) (core.Value, error)
+
+// runBatch executes the steps once their dependencies succeed, at most
+// concurrency at a time. Steps whose dependencies failed are skipped.
+// Results are returned in the manifest order.
+func runBatch(ctx context.Context, manifest *batchManifest, concurrency int, run batchRunFunc) []*batchStepResult {
+	if concurrency <= 0 {
+		concurrency = batchDefaultConcurrency
+	}
+
+	var mu sync.Mutex
+	steps := map[string]any{}
+	document := map[string]any{"steps": steps}
+	results := make(map[string]*batchStepResult, len(manifest.Steps))
+
+	done := make(map[string]chan struct{}, len(manifest.Steps))
+	for _, step := range manifest.Steps {
+		done[step.Id] = make(chan struct{})
+	}
+
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	runStep := func(step *batchStep) *batchStepResult {
+		result := &batchStepResult{Id: step.Id, Command: step.Command}
+
+		for _, id := range step.dependencies {
+			<-done[id]
+			mu.Lock()
+			status := results[id].Status
+			mu.Unlock()
+			if status != batchStatusSucceeded {
+				result.Status = batchStatusSkipped
+				result.Err = fmt.Errorf("dependency %q %s", id, status)
+				return result
+			}
+		}
+
+		semaphore <- struct{}{}
+		defer func() { <-semaphore }()
+
+		mu.Lock()
+		parameters, err := resolveBatchMap(step.Parameters, document)
+		if err == nil {
+			result.Parameters = parameters
+			result.Configs, err = resolveBatchMap(step.Configs, document)
+		}
+		mu.Unlock()
+
+		if err == nil {
+			start := time.Now()
+			result.Result, err = run(ctx, step, result.Parameters, result.Configs)
+			result.Duration = time.Since(start)
+		}
+
+		if err != nil {
+			result.Status = batchStatusFailed
+			result.Err = err
+		} else {
+			result.Status = batchStatusSucceeded
+		}
+		return result
+	}
+
+	for _, step := range manifest.Steps {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done[step.Id])
+
+			result := runStep(step)
+			logger().Debugw("finished batch step", "id", step.Id, "status", result.Status, "error", result.Err)
+
+			mu.Lock()
+			results[step.Id] = result
+			steps[step.Id] = result.jsonPathDocument()
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	ordered := make([]*batchStepResult, len(manifest.Steps))
+	for i, step := range manifest.Steps {
+		ordered[i] = results[step.Id]
+	}
+	return ordered
+}
+
+// resolveBatchCommand finds the executor given its command path, such as
+// "virtual-machine instances create", accepting the same aliases as the CLI
+func resolveBatchCommand(root core.Grouper, command string) (core.Executor, error) {
+	names := strings.Fields(command)
+	if len(names) == 0 {
+		return nil, errors.New("empty command")
+	}
+
+	grouper := root
+	for i, name := range names {
+		child, err := findChildByNameOrAliases(grouper, name)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", command, err)
+		}
+
+		if i == len(names)-1 {
+			exec, ok := child.(core.Executor)
+			if !ok {
+				return nil, fmt.Errorf("%q is not an executable command", command)
+			}
+			return exec, nil
+		}
+
+		next, ok := child.(core.Grouper)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a group of commands", strings.Join(names[:i+1], " "))
+		}
+		grouper = next
+	}
+	return nil, fmt.Errorf("%q is not an executable command", command)
+}
+
+// fillBatchDefaults sets the properties that were not given to their
+// configured or default values, like the CLI flags
+func fillBatchDefaults(values map[string]any, schema *core.Schema, config *mgcConfigPkg.Config) {
+	for name, propRef := range schema.Properties {
+		if _, ok := values[name]; ok {
+			continue
+		}
+
+		if config != nil {
+			var value any
+			if err := config.Get(name, &value); err == nil && value != nil {
+				values[name] = value
+				continue
+			}
+		}
+
+		if propRef.Value != nil && propRef.Value.Default != nil {
+			values[name] = propRef.Value.Default
+		}
+	}
+}
+
+func newBatchStepRunner(sdk *mgcSdk.Sdk) batchRunFunc {
+	return func(ctx context.Context, step *batchStep, parameters core.Parameters, configs core.Configs) (core.Value, error) {
+		exec := step.executor
+		fillBatchDefaults(parameters, exec.ParametersSchema(), nil)
+		fillBatchDefaults(configs, exec.ConfigsSchema(), sdk.Config())
+
+		if err := checkDryRun(ctx, exec); err != nil {
+			return nil, err
+		}
+		if err := checkScopes(sdk, exec); err != nil {
+			return nil, err
+		}
+		if err := exec.ParametersSchema().VisitJSON(parameters); err != nil {
+			return nil, core.UsageError{Err: err}
+		}
+		if err := exec.ConfigsSchema().VisitJSON(configs); err != nil {
+			return nil, core.UsageError{Err: err}
+		}
+
+		var result core.Result
+		var err error
+		if tExec, ok := core.ExecutorAs[core.TerminatorExecutor](exec); ok && step.WaitTermination {
+			result, err = tExec.ExecuteUntilTermination(ctx, parameters, configs)
+		} else {
+			result, err = exec.Execute(ctx, parameters, configs)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if resultWithValue, ok := core.ResultAs[core.ResultWithValue](result); ok {
+			return resultWithValue.Value(), nil
+		}
+		return nil, nil
+	}
+}
+
+// confirmBatch asks once for all the steps that would ask for confirmation
+// when executed alone, since prompts can't be answered while steps run concurrently
+func confirmBatch(cmd *cobra.Command, manifest *batchManifest) error {
+	if getBypassConfirmationFlag(cmd) || getDryRunFlag(cmd) {
+		return nil
+	}
+
+	var commands []string
+	for _, step := range manifest.Steps {
+		_, confirmable := core.ExecutorAs[core.ConfirmableExecutor](step.executor)
+		_, promptInput := core.ExecutorAs[core.PromptInputExecutor](step.executor)
+		if confirmable || promptInput {
+			commands = append(commands, fmt.Sprintf("  %s: %s", step.Id, step.Command))
+		}
+	}
+	if len(commands) == 0 {
+		return nil
+	}
+
+	msg := fmt.Sprintf("The following steps require confirmation:\n%s\nRun them?", strings.Join(commands, "\n"))
+	run, err := ui.Confirm(msg)
+	if err != nil {
+		return err
+	}
+	if !run {
+		return core.UserDeniedConfirmationError{Prompt: msg}
+	}
+	return nil
+}
+
+func readBatchFile(fileName string) ([]byte, error) {
+	if fileName == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(fileName)
+}
+
+func newBatchRunCmd(sdk *mgcSdk.Sdk) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "run",
+		Aliases: []string{"apply"},
+		Short:   "Execute the commands listed in a YAML or JSON manifest",
+		Long: `Execute the commands listed in a YAML or JSON manifest and report the status of each step.
+
+Steps address commands by their path, as typed in the command line, and give
+their parameters and configs as values. Strings starting with "$" are JSONPath
+expressions evaluated against a document with the previous results, such as
+"$.steps.<id>.result.id". Use "$$" for strings starting with a literal "$".
+
+Steps run concurrently, unless they depend on each other, by referencing other
+steps or listing them in "dependsOn". Steps whose dependencies failed are
+skipped.`,
+		Example: `  mgc batch run -f batch.yaml
+
+  # batch.yaml
+  version: 1.0.0
+  concurrency: 2
+  steps:
+    - id: vpc
+      command: network vpcs create
+      parameters:
+        name: my-vpc
+    - id: vm
+      command: virtual-machine instances create
+      waitTermination: true
+      parameters:
+        name: my-vm
+        network:
+          vpc:
+            id: $.steps.vpc.result.id`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fileName, _ := cmd.Flags().GetString(batchFileFlag)
+			data, err := readBatchFile(fileName)
+			if err != nil {
+				return err
+			}
+
+			manifest, err := parseBatchManifest(data)
+			if err != nil {
+				return core.UsageError{Err: fmt.Errorf("invalid batch file %q: %w", fileName, err)}
+			}
+
+			root := sdk.Group()
+			err = manifest.validate(func(command string) (core.Executor, error) {
+				return resolveBatchCommand(root, command)
+			})
+			if err != nil {
+				return core.UsageError{Err: fmt.Errorf("invalid batch file %q: %w", fileName, err)}
+			}
+
+			if getDryRunFlag(cmd) {
+				for _, step := range manifest.Steps {
+					if !core.ExecutorSupportsDryRun(step.executor) {
+						return core.UsageError{Err: fmt.Errorf("step %q: %q does not support --%s", step.Id, step.executor.Name(), dryRunFlag)}
+					}
+				}
+			}
+
+			if err = confirmBatch(cmd, manifest); err != nil {
+				return err
+			}
+
+			concurrency, _ := cmd.Flags().GetInt(batchConcurrencyFlag)
+			if concurrency == 0 {
+				concurrency = manifest.Concurrency
+			}
+
+			ctx := core.NewDryRunContext(sdk.NewContext(), getDryRunFlag(cmd))
+			if pb != nil {
+				ctx = progress_report.NewContext(ctx, pb.ReportProgress)
+			}
+			if t := getTimeoutFlag(cmd); t > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, t)
+				defer cancel()
+			}
+
+			results := runBatch(ctx, manifest, concurrency, newBatchStepRunner(sdk))
+			if pb != nil {
+				pb.Flush()
+			}
+
+			summary := make([]any, len(results))
+			var failed, skipped int
+			for i, result := range results {
+				summary[i] = result.summary()
+				switch result.Status {
+				case batchStatusFailed:
+					failed++
+				case batchStatusSkipped:
+					skipped++
+				}
+			}
+
+			output := getOutputFlag(cmd)
+			if output == "" {
+				output = batchSummaryOutput
+			}
+			if err = handleSimpleResultValue(summary, output); err != nil {
+				return err
+			}
+
+			if failed > 0 || skipped > 0 {
+				return fmt.Errorf("%d of %d steps failed, %d skipped", failed, len(results), skipped)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP(batchFileFlag, "f", "", `Manifest file, in YAML or JSON. Use "-" to read from stdin`)
+	cmd.Flags().Int(batchConcurrencyFlag, 0, fmt.Sprintf("Maximum number of steps running at the same time. Overrides the manifest, which defaults to %d", batchDefaultConcurrency))
+	_ = cmd.MarkFlagRequired(batchFileFlag)
+	return cmd
+}
+
+func newBatchCmd(sdk *mgcSdk.Sdk) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "batch",
+		Short:   "Execute multiple commands from a manifest",
+		GroupID: "other",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newBatchRunCmd(sdk))
+	return cmd
+}
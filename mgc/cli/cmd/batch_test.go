@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"magalu.cloud/core"
+)
+
+func newTestBatchRoot() core.Grouper {
+	return newTestGroup(
+		"root",
+		newTestGroup(
+			"network",
+			newTestGroup("vpcs", newTestListExecutor("list", "id"), newTestListExecutor("create", "id")),
+		),
+	)
+}
+
+func Test_resolveBatchCommand(t *testing.T) {
+	root := newTestBatchRoot()
+
+	tests := []struct {
+		command string
+		err     error
+	}{
+		{command: "network vpcs create"},
+		{command: "  network  vpcs   list "},
+		{command: "network vpcs", err: errors.New(`"network vpcs" is not an executable command`)},
+		{command: "network vpcs create extra", err: errors.New(`"network vpcs create" is not a group of commands`)},
+		{command: "network subnets list", err: errors.New(`"network subnets list": no command with name "subnets"`)},
+		{command: "", err: errors.New("empty command")},
+	}
+	for _, tc := range tests {
+		_, err := resolveBatchCommand(root, tc.command)
+		checkError(t, tc.command, tc.err, err)
+	}
+}
+
+func Test_batchManifest_validate(t *testing.T) {
+	root := newTestBatchRoot()
+	resolve := func(command string) (core.Executor, error) {
+		return resolveBatchCommand(root, command)
+	}
+
+	tests := []struct {
+		name         string
+		manifest     string
+		dependencies map[string][]string
+		err          error
+	}{
+		{
+			name: "dependencies",
+			manifest: `
+version: 1.0.0
+steps:
+  - id: vpc
+    command: network vpcs create
+  - id: other
+    command: network vpcs list
+  - id: vm
+    command: network vpcs create
+    dependsOn: [other]
+    parameters:
+      name: $$literal
+      network:
+        vpc: {id: $.steps.vpc.result.id}
+        ids: ['$.steps["other"].result.items[*].id']
+`,
+			dependencies: map[string][]string{"vpc": nil, "other": nil, "vm": {"other", "vpc"}},
+		},
+		{
+			name:     "version",
+			manifest: `{"version": "2.0.0", "steps": []}`,
+			err:      errors.New(`expected batch version "1.0.0", got "2.0.0"`),
+		},
+		{
+			name:     "no steps",
+			manifest: `{"version": "1.0.0", "steps": []}`,
+			err:      errors.New("missing steps"),
+		},
+		{
+			name: "invalid id",
+			manifest: `
+version: 1.0.0
+steps:
+  - id: my-vpc
+    command: network vpcs create
+`,
+			err: errors.New(`invalid "/steps[0]": invalid id "my-vpc", use letters, digits and '_'`),
+		},
+		{
+			name: "duplicated id",
+			manifest: `
+version: 1.0.0
+steps:
+  - id: vpc
+    command: network vpcs create
+  - id: vpc
+    command: network vpcs create
+`,
+			err: errors.New(`invalid "/vpc": duplicated step id`),
+		},
+		{
+			name: "unknown command",
+			manifest: `
+version: 1.0.0
+steps:
+  - id: vpc
+    command: network vpc create
+`,
+			err: errors.New(`invalid "/vpc/command": "network vpc create": no command with name "vpc"`),
+		},
+		{
+			name: "unknown reference",
+			manifest: `
+version: 1.0.0
+steps:
+  - id: vm
+    command: network vpcs create
+    parameters:
+      vpc: $.steps.vpc.result.id
+`,
+			err: errors.New(`invalid "/vm/parameters/vpc": unknown step "vpc"`),
+		},
+		{
+			name: "cycle",
+			manifest: `
+version: 1.0.0
+steps:
+  - id: a
+    command: network vpcs create
+    dependsOn: [c]
+  - id: b
+    command: network vpcs create
+    configs:
+      region: $.steps.a.configs.region
+  - id: c
+    command: network vpcs create
+    dependsOn: [b]
+`,
+			err: errors.New("dependency cycle: a -> c -> b -> a"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			manifest, err := parseBatchManifest([]byte(tc.manifest))
+			checkError(t, "parseBatchManifest", nil, err)
+
+			err = manifest.validate(resolve)
+			checkError(t, "validate", tc.err, err)
+			for _, step := range manifest.Steps {
+				if expected, ok := tc.dependencies[step.Id]; ok {
+					checkExpectedArray(t, step.Id, expected, step.dependencies)
+				}
+			}
+		})
+	}
+}
+
+func Test_runBatch(t *testing.T) {
+	manifest, err := parseBatchManifest([]byte(`
+version: 1.0.0
+steps:
+  - id: vpc
+    command: network vpcs create
+    parameters:
+      name: $$vpc
+  - id: broken
+    command: network vpcs create
+  - id: vm
+    command: network vpcs create
+    parameters:
+      vpc_id: $.steps.vpc.result.id
+      name: vm
+  - id: skipped
+    command: network vpcs create
+    dependsOn: [broken]
+  - id: chained
+    command: network vpcs create
+    parameters:
+      previous: $.steps.skipped.status
+`))
+	checkError(t, "parseBatchManifest", nil, err)
+
+	root := newTestBatchRoot()
+	err = manifest.validate(func(command string) (core.Executor, error) {
+		return resolveBatchCommand(root, command)
+	})
+	checkError(t, "validate", nil, err)
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+
+	results := runBatch(context.Background(), manifest, 1, func(ctx context.Context, step *batchStep, parameters core.Parameters, configs core.Configs) (core.Value, error) {
+		mu.Lock()
+		running++
+		maxRunning = max(maxRunning, running)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+
+		if step.Id == "broken" {
+			return nil, errors.New("some error")
+		}
+		return map[string]any{"id": step.Id + "-id", "parameters": parameters}, nil
+	})
+
+	if maxRunning != 1 {
+		t.Errorf("expected at most 1 step running, got %d", maxRunning)
+	}
+
+	var got []string
+	for _, r := range results {
+		got = append(got, fmt.Sprintf("%s=%s %v %v", r.Id, r.Status, r.Err, r.Parameters))
+	}
+	checkExpectedArray(t, "results", []string{
+		"vpc=succeeded <nil> map[name:$vpc]",
+		"broken=failed some error map[]",
+		"vm=succeeded <nil> map[name:vm vpc_id:vpc-id]",
+		`skipped=skipped dependency "broken" failed map[]`,
+		`chained=skipped dependency "skipped" skipped map[]`,
+	}, got)
+}
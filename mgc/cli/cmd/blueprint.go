@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"magalu.cloud/core"
+	"magalu.cloud/core/dataloader"
+	mgcSdk "magalu.cloud/sdk"
+	"magalu.cloud/sdk/blueprint"
+)
+
+const (
+	blueprintDirFlag           = "dir"
+	blueprintTestSummaryOutput = "table=FILE:$[*].file,NAME:$[*].name,STATUS:$[*].status,ERROR:$[*].error"
+)
+
+func addBlueprintDirFlag(cmd *cobra.Command) {
+	cmd.Flags().String(blueprintDirFlag, mgcSdk.BlueprintsDir(), "Directory with the index.blueprint.yaml and the modules it lists")
+}
+
+func getBlueprintLoader(cmd *cobra.Command) dataloader.Loader {
+	dir, _ := cmd.Flags().GetString(blueprintDirFlag)
+	return &dataloader.FileLoader{Dir: dir}
+}
+
+func newBlueprintValidateCmd(sdk *mgcSdk.Sdk) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check the blueprints for mistakes, without executing them",
+		Long: `Load the index.blueprint.yaml and every module it lists, resolve all of their
+references, such as step targets and schema "$ref", and check the step parameters
+and configs against the target schemas, as well as the parameters, configs and
+steps referenced by their JSON Paths.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := blueprint.Validate(getBlueprintLoader(cmd), sdk.RefResolver())
+			if err != nil {
+				return err
+			}
+			fmt.Println("Blueprints are valid")
+			return nil
+		},
+	}
+	addBlueprintDirFlag(cmd)
+	return cmd
+}
+
+func newBlueprintTestCmd(sdk *mgcSdk.Sdk) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "test [file...]",
+		Short: "Execute the blueprints against stubs, as described in YAML or JSON files",
+		Long: `Execute the blueprints against stubs, as described in YAML or JSON files, and
+check the executed steps, the result and the error of each test.
+
+Blueprint steps never execute their targets for real, each call consumes
+the next stub of the target, in the order they are declared. Calls without
+stubs fail the test, as well as stubs that were not called.`,
+		Example: `  mgc blueprint test vm_test.yaml
+
+  # vm_test.yaml
+  version: 1.0.0
+  tests:
+    - name: creates the VM with a volume
+      executor: /vm/create-with-volume
+      parameters:
+        name: my-vm
+      stubs:
+        - target: /virtual-machine/instances/create
+          parameters:
+            name: my-vm
+          result:
+            id: vm-1
+        - target: /block-storage/volumes/attach
+          error: volume not found
+      expect:
+        steps: [createVm, attachVolume]
+        error: volume not found`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			loader := getBlueprintLoader(cmd)
+			ctx := sdk.NewContext()
+
+			var summary []any
+			var failed int
+			for _, fileName := range args {
+				data, err := os.ReadFile(fileName)
+				if err != nil {
+					return err
+				}
+
+				f, err := blueprint.ParseTestFile(data)
+				if err != nil {
+					return core.UsageError{Err: fmt.Errorf("invalid test file %q: %w", fileName, err)}
+				}
+
+				for _, result := range blueprint.RunTests(ctx, loader, sdk.RefResolver(), f) {
+					row := map[string]any{"file": fileName, "name": result.Name, "status": "passed"}
+					if result.Err != nil {
+						row["status"] = "failed"
+						row["error"] = result.Err.Error()
+						failed++
+					}
+					summary = append(summary, row)
+				}
+			}
+
+			output := getOutputFlag(cmd)
+			if output == "" {
+				output = blueprintTestSummaryOutput
+			}
+			if err := handleSimpleResultValue(summary, output); err != nil {
+				return err
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d of %d tests failed", failed, len(summary))
+			}
+			return nil
+		},
+	}
+	addBlueprintDirFlag(cmd)
+	return cmd
+}
+
+func newBlueprintCmd(sdk *mgcSdk.Sdk) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "blueprint",
+		Short:   "Validate and test blueprints",
+		GroupID: "other",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newBlueprintValidateCmd(sdk))
+	cmd.AddCommand(newBlueprintTestCmd(sdk))
+	return cmd
+}
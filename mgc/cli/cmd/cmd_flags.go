@@ -27,6 +27,8 @@ type cmdFlags struct {
 	childFlags     []*flag.Flag
 
 	knownFlags map[flag.NormalizedName]*flag.Flag // all known flags, both existing and schemaFlags
+
+	completer *resourceCompleter // optional, completes values from list executors
 }
 
 const childFlagSeparator = '.'
@@ -300,6 +302,11 @@ func (cf *cmdFlags) completeFlagValues(f *flag.Flag, cmd *cobra.Command, args []
 			directive = cobra.ShellCompDirectiveDefault
 		} else {
 			directive = cobra.ShellCompDirectiveNoFileComp
+			if cf.completer != nil {
+				resources, resourcesDirective := cf.completer.complete(cf, f, cmd, toComplete)
+				completions = append(completions, resources...)
+				directive |= resourcesDirective
+			}
 		}
 	}
 
@@ -66,6 +66,11 @@ func handleResultWithValue(result core.ResultWithValue, output string, cmd *cobr
 		logValidationErr(err)
 	}
 
+	value, err = transformResultValue(cmd, value)
+	if err != nil {
+		return err
+	}
+
 	name, options := parseOutputFormatter(output)
 	if name == "" {
 		if formatter, ok := core.ResultAs[core.ResultWithDefaultFormatter](result); ok {
@@ -0,0 +1,478 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/stoewer/go-strcase"
+	"magalu.cloud/cli/cmd/schema_flags"
+	"magalu.cloud/core"
+	"magalu.cloud/core/profile_manager"
+	mgcSchemaPkg "magalu.cloud/core/schema"
+	mgcSdk "magalu.cloud/sdk"
+)
+
+const (
+	completionCacheFile    = "completion_cache.json"
+	completionCacheTTL     = time.Minute
+	completionTimeout      = 5 * time.Second
+	completionURIPrefix    = "s3://"
+	completionURIFlagType  = "uri"
+	completionIdFieldName  = "id"
+	completionIdNameSuffix = "_id"
+)
+
+var completionObjectStoragePath = []string{"object-storage"}
+
+// resourceCompleter completes flag values with resources fetched from the
+// list executors of the command tree, such as instance IDs and bucket names.
+// It also resolves resource names given to ID parameters, see resolveNames().
+type resourceCompleter struct {
+	sdk *mgcSdk.Sdk
+	now func() time.Time
+}
+
+func newResourceCompleter(sdk *mgcSdk.Sdk) *resourceCompleter {
+	return &resourceCompleter{sdk: sdk, now: time.Now}
+}
+
+type completionEntry struct {
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+func (e completionEntry) String() string {
+	if e.Description == "" {
+		return e.Value
+	}
+	return e.Value + "\t" + e.Description
+}
+
+type completionCacheItem struct {
+	CreatedAt time.Time         `json:"created_at"`
+	Entries   []completionEntry `json:"entries"`
+}
+
+// resourceList is a list executor whose items provide the values of a parameter
+type resourceList struct {
+	exec  core.Executor
+	path  []string // command path of the executor, used as cache key
+	field string   // item property holding the parameter value
+}
+
+func readCompletionCache(profile *profile_manager.Profile, key string, now time.Time) ([]completionEntry, bool) {
+	data, err := profile.Read(completionCacheFile)
+	if err != nil {
+		return nil, false
+	}
+
+	var cache map[string]completionCacheItem
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, false
+	}
+
+	item, ok := cache[key]
+	if !ok || now.Sub(item.CreatedAt) > completionCacheTTL {
+		return nil, false
+	}
+	return item.Entries, true
+}
+
+func writeCompletionCache(profile *profile_manager.Profile, key string, entries []completionEntry, now time.Time) error {
+	cache := map[string]completionCacheItem{}
+	if data, err := profile.Read(completionCacheFile); err == nil {
+		_ = json.Unmarshal(data, &cache)
+	}
+
+	for k, item := range cache {
+		if now.Sub(item.CreatedAt) > completionCacheTTL {
+			delete(cache, k)
+		}
+	}
+	cache[key] = completionCacheItem{CreatedAt: now, Entries: entries}
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	return profile.Write(completionCacheFile, data)
+}
+
+func isListExecutor(exec core.Executor) bool {
+	if !strings.HasPrefix(exec.Name(), listExecNamePrefix) {
+		return false
+	}
+	return len(exec.ParametersSchema().Required) == 0
+}
+
+// hasListItemField reports whether the items of the list executor have a field
+// of the same type as the parameter.
+func hasListItemField(listExec core.Executor, field string, paramSchema *mgcSchemaPkg.Schema) bool {
+	itemSchema, err := findListSchema(listExec.ResultSchema())
+	if err != nil || itemSchema.Type != "object" {
+		return false
+	}
+
+	fieldRef := itemSchema.Properties[field]
+	if fieldRef == nil {
+		return false
+	}
+	return fieldRef.Value.Type == paramSchema.Type
+}
+
+func findGroupListExecutor(group core.Grouper, field string, paramSchema *mgcSchemaPkg.Schema) (listExec core.Executor) {
+	_, _ = group.VisitChildren(func(child core.Descriptor) (bool, error) {
+		exec, ok := child.(core.Executor)
+		if !ok || !isListExecutor(exec) || !hasListItemField(exec, field, paramSchema) {
+			return true, nil
+		}
+		// prefer the plain "list" over "list-something"
+		if listExec == nil || exec.Name() == listExecNamePrefix {
+			listExec = exec
+		}
+		return true, nil
+	})
+	return
+}
+
+func findChildGrouper(group core.Grouper, names []string) (path string, child core.Grouper) {
+	_, _ = group.VisitChildren(func(desc core.Descriptor) (bool, error) {
+		g, ok := desc.(core.Grouper)
+		if ok && slices.Contains(names, strcase.SnakeCase(desc.Name())) {
+			path, child = strcase.KebabCase(desc.Name()), g
+			return false, nil
+		}
+		return true, nil
+	})
+	return
+}
+
+// findResourceList finds the list executor providing the values of a parameter.
+//
+// groups are the command groups leading to the executor, the closest first,
+// and groupPaths their command paths. Parameters named "<resource>_id" are
+// looked up in the "<resource>" or "<resource>s" groups of any ancestor, using
+// the "id" of their items (ie: "dbaas instances backups list --instance-id").
+// Otherwise the closest group is searched for a list whose items have a
+// property named after the parameter (ie: "id" for "vm instances get --id").
+func findResourceList(groups []core.Grouper, groupPaths [][]string, paramName string, paramSchema *mgcSchemaPkg.Schema) (list resourceList, ok bool) {
+	if len(groups) == 0 || paramSchema.Type != "string" || len(paramSchema.Enum) > 0 {
+		return
+	}
+
+	if resource, isRef := strings.CutSuffix(strcase.SnakeCase(paramName), completionIdNameSuffix); isRef && resource != "" {
+		names := []string{resource, resource + "s", resource + "es"}
+		for i, group := range groups {
+			childName, child := findChildGrouper(group, names)
+			if child == nil {
+				continue
+			}
+			if exec := findGroupListExecutor(child, completionIdFieldName, paramSchema); exec != nil {
+				path := append(slices.Clone(groupPaths[i]), childName, strcase.KebabCase(exec.Name()))
+				return resourceList{exec, path, completionIdFieldName}, true
+			}
+		}
+	}
+
+	if exec := findGroupListExecutor(groups[0], paramName, paramSchema); exec != nil {
+		return resourceList{exec, append(slices.Clone(groupPaths[0]), strcase.KebabCase(exec.Name())), paramName}, true
+	}
+
+	return
+}
+
+// commandGroups returns the SDK groups leading to cmd, the closest first,
+// along with their paths.
+func (c *resourceCompleter) commandGroups(cmd *cobra.Command) (groups []core.Grouper, paths [][]string) {
+	var names []string
+	for p := cmd.Parent(); p != nil && p.HasParent(); p = p.Parent() {
+		names = append(names, p.Name())
+	}
+	slices.Reverse(names)
+
+	group := c.sdk.Group()
+	groups = []core.Grouper{group}
+	paths = [][]string{{}}
+	for i, name := range names {
+		child, err := findChildByNameOrAliases(group, name)
+		if err != nil {
+			return nil, nil
+		}
+		var ok bool
+		if group, ok = child.(core.Grouper); !ok {
+			return nil, nil
+		}
+		groups = append([]core.Grouper{group}, groups...)
+		paths = append([][]string{slices.Clone(names[:i+1])}, paths...)
+	}
+	return
+}
+
+func (c *resourceCompleter) findExecutor(path []string) (exec core.Executor, ok bool) {
+	var desc core.Descriptor = c.sdk.Group()
+	for _, name := range path {
+		group, isGroup := desc.(core.Grouper)
+		if !isGroup {
+			return nil, false
+		}
+		child, err := findChildByNameOrAliases(group, name)
+		if err != nil {
+			return nil, false
+		}
+		desc = child
+	}
+	exec, ok = desc.(core.Executor)
+	return
+}
+
+// configs of exec, as the command would use them: taken from the command flags
+// sharing the same name or from the current workspace configuration.
+func (c *resourceCompleter) configs(cf *cmdFlags, exec core.Executor) core.Configs {
+	configs := core.Configs{}
+	for _, f := range cf.schemaFlags {
+		desc := f.Value.(schema_flags.SchemaFlagValue).Desc()
+		if !desc.IsConfig || exec.ConfigsSchema().Properties[desc.PropName] == nil {
+			continue
+		}
+		if value, err := schema_flags.GetFlagValue(f, c.sdk.Config()); err == nil {
+			configs[desc.PropName] = value
+		}
+	}
+
+	for propName, propRef := range exec.ConfigsSchema().Properties {
+		if _, ok := configs[propName]; ok {
+			continue
+		}
+		var value any
+		if err := c.sdk.Config().Get(propName, &value); err == nil && value != nil {
+			configs[propName] = value
+		} else if propRef.Value.Default != nil {
+			configs[propName] = propRef.Value.Default
+		}
+	}
+	return configs
+}
+
+func completionParameters(exec core.Executor, parameters core.Parameters) core.Parameters {
+	for propName, propRef := range exec.ParametersSchema().Properties {
+		if _, ok := parameters[propName]; !ok && propRef.Value.Default != nil {
+			parameters[propName] = propRef.Value.Default
+		}
+	}
+	return parameters
+}
+
+// list runs the executor, or reuses its cached result, converting the result
+// value to completion entries.
+func (c *resourceCompleter) list(
+	cf *cmdFlags,
+	exec core.Executor,
+	path []string,
+	parameters core.Parameters,
+	toEntries func(value any) []completionEntry,
+) []completionEntry {
+	parameters = completionParameters(exec, parameters)
+	configs := c.configs(cf, exec)
+
+	keyData, _ := json.Marshal([]any{parameters, configs})
+	key := strings.Join(path, " ") + " " + string(keyData)
+
+	profile := c.sdk.ProfileManager().Current()
+	if entries, ok := readCompletionCache(profile, key, c.now()); ok {
+		return entries
+	}
+
+	ctx, cancel := context.WithTimeout(c.sdk.NewContext(), completionTimeout)
+	defer cancel()
+
+	result, err := exec.Execute(ctx, parameters, configs)
+	if err != nil {
+		logger().Debugw("completion list failed", "path", path, "error", err)
+		return nil
+	}
+
+	resultWithValue, ok := core.ResultAs[core.ResultWithValue](result)
+	if !ok {
+		return nil
+	}
+
+	entries := toEntries(resultWithValue.Value())
+	if err := writeCompletionCache(profile, key, entries, c.now()); err != nil {
+		logger().Debugw("failed to write completion cache", "error", err)
+	}
+	return entries
+}
+
+// listItems returns the array of a list result, that may be wrapped in an object
+// with a single array property, the same way findListSchema() does.
+func listItems(value any) ([]any, bool) {
+	switch v := value.(type) {
+	case []any:
+		return v, true
+	case map[string]any:
+		if len(v) != 1 {
+			return nil, false
+		}
+		for _, items := range v {
+			array, ok := items.([]any)
+			return array, ok
+		}
+	}
+	return nil, false
+}
+
+func describeItem(item map[string]any, field string, humanFields []string) string {
+	if len(humanFields) == 0 {
+		humanFields = []string{"name"}
+	}
+
+	var parts []string
+	for _, k := range humanFields {
+		if k == field {
+			continue
+		}
+		if v, ok := item[k]; ok && v != nil && v != "" {
+			parts = append(parts, fmt.Sprint(v))
+		}
+	}
+	return strings.Join(parts, " | ")
+}
+
+func (c *resourceCompleter) completeResource(cf *cmdFlags, f *flag.Flag, groups []core.Grouper, paths [][]string) []completionEntry {
+	fv, ok := f.Value.(schema_flags.SchemaFlagValue)
+	if !ok {
+		return nil
+	}
+
+	desc := fv.Desc()
+	if desc.IsConfig {
+		return nil
+	}
+
+	return c.resourceEntries(cf, groups, paths, desc.PropName, desc.Schema)
+}
+
+// resourceEntries lists the resources that may be given to the parameter, see findResourceList()
+func (c *resourceCompleter) resourceEntries(cf *cmdFlags, groups []core.Grouper, paths [][]string, paramName string, schema *mgcSchemaPkg.Schema) []completionEntry {
+	list, ok := findResourceList(groups, paths, paramName, schema)
+	if !ok {
+		return nil
+	}
+
+	var humanFields []string
+	if humanExec, ok := core.ExecutorAs[core.HumanIdentifiableFieldsExecutor](list.exec); ok {
+		humanFields = humanExec.HumanIdentifiableFields()
+	}
+
+	return c.list(cf, list.exec, list.path, core.Parameters{}, func(value any) (entries []completionEntry) {
+		items, _ := listItems(value)
+		for _, item := range items {
+			m, ok := item.(map[string]any)
+			if !ok || m[list.field] == nil {
+				continue
+			}
+			entries = append(entries, completionEntry{
+				Value:       fmt.Sprint(m[list.field]),
+				Description: describeItem(m, list.field, humanFields),
+			})
+		}
+		return
+	})
+}
+
+// completeObjectStorageURI completes "s3://bucket/prefix" values, listing buckets
+// until the first "/" is typed and then the objects and directories of the bucket.
+func (c *resourceCompleter) completeObjectStorageURI(cf *cmdFlags, toComplete string) []completionEntry {
+	uri, isURI := strings.CutPrefix(toComplete, completionURIPrefix)
+	if !isURI {
+		return []completionEntry{{Value: completionURIPrefix}}
+	}
+
+	bucket, key, hasKey := strings.Cut(uri, "/")
+	if !hasKey {
+		path := append(slices.Clone(completionObjectStoragePath), "buckets", "list")
+		exec, ok := c.findExecutor(path)
+		if !ok {
+			return nil
+		}
+		return c.list(cf, exec, path, core.Parameters{}, func(value any) (entries []completionEntry) {
+			for _, item := range objectStorageItems(value, "Buckets") {
+				if name, ok := item["Name"].(string); ok {
+					entries = append(entries, completionEntry{Value: completionURIPrefix + name + "/"})
+				}
+			}
+			return
+		})
+	}
+
+	path := append(slices.Clone(completionObjectStoragePath), "objects", "list")
+	exec, ok := c.findExecutor(path)
+	if !ok {
+		return nil
+	}
+
+	dir := key[:strings.LastIndex(key, "/")+1]
+	bucketURI := completionURIPrefix + bucket + "/"
+	parameters := core.Parameters{"dst": bucketURI + dir}
+
+	return c.list(cf, exec, path, parameters, func(value any) (entries []completionEntry) {
+		for _, item := range objectStorageItems(value, "CommonPrefixes") {
+			if prefix, ok := item["Path"].(string); ok {
+				entries = append(entries, completionEntry{Value: bucketURI + prefix})
+			}
+		}
+		for _, item := range objectStorageItems(value, "Contents") {
+			if key, ok := item["Key"].(string); ok {
+				entries = append(entries, completionEntry{Value: bucketURI + key})
+			}
+		}
+		return
+	})
+}
+
+func objectStorageItems(value any, field string) (items []map[string]any) {
+	m, ok := value.(map[string]any)
+	if !ok {
+		return
+	}
+	array, _ := m[field].([]any)
+	for _, item := range array {
+		if itemMap, ok := item.(map[string]any); ok {
+			items = append(items, itemMap)
+		}
+	}
+	return
+}
+
+func filterCompletionEntries(entries []completionEntry, toComplete string) (completions []string) {
+	for _, e := range entries {
+		if strings.HasPrefix(e.Value, toComplete) {
+			completions = append(completions, e.String())
+		}
+	}
+	return
+}
+
+func (c *resourceCompleter) complete(cf *cmdFlags, f *flag.Flag, cmd *cobra.Command, toComplete string) (completions []string, directive cobra.ShellCompDirective) {
+	groups, paths := c.commandGroups(cmd)
+	if len(groups) < 2 {
+		return
+	}
+
+	// paths[len(paths)-1] is the root, the one before it the product
+	if f.Value.Type() == completionURIFlagType {
+		if !slices.Equal(paths[len(paths)-2], completionObjectStoragePath) {
+			return
+		}
+		entries := c.completeObjectStorageURI(cf, toComplete)
+		return filterCompletionEntries(entries, toComplete), cobra.ShellCompDirectiveNoSpace
+	}
+
+	return filterCompletionEntries(c.completeResource(cf, f, groups, paths), toComplete), 0
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"magalu.cloud/core"
+	"magalu.cloud/core/profile_manager"
+	mgcSchemaPkg "magalu.cloud/core/schema"
+)
+
+func newTestListExecutor(name string, itemProps ...string) core.Executor {
+	props := map[string]*mgcSchemaPkg.Schema{}
+	for _, p := range itemProps {
+		props[p] = mgcSchemaPkg.NewStringSchema()
+	}
+
+	return core.NewSimpleExecutor(core.ExecutorSpec{
+		DescriptorSpec:   core.DescriptorSpec{Name: name, Description: name},
+		ParametersSchema: mgcSchemaPkg.NewObjectSchema(nil, nil),
+		ConfigsSchema:    mgcSchemaPkg.NewObjectSchema(nil, nil),
+		ResultSchema: mgcSchemaPkg.NewObjectSchema(map[string]*mgcSchemaPkg.Schema{
+			"items": mgcSchemaPkg.NewArraySchema(mgcSchemaPkg.NewObjectSchema(props, nil)),
+		}, nil),
+		Execute: func(core.Executor, context.Context, core.Parameters, core.Configs) (core.Result, error) {
+			return nil, nil
+		},
+	})
+}
+
+func newTestGroup(name string, children ...core.Descriptor) core.Grouper {
+	return core.NewSimpleGrouper(core.DescriptorSpec{Name: name, Description: name}, func() ([]core.Descriptor, error) {
+		return children, nil
+	})
+}
+
+func Test_findResourceList(t *testing.T) {
+	instancesList := newTestListExecutor("list", "id", "name")
+	backupsList := newTestListExecutor("list", "id", "instance_id")
+	backups := newTestGroup("backups", backupsList)
+	instances := newTestGroup("instances", instancesList, backups)
+	product := newTestGroup("dbaas", instances)
+
+	groups := []core.Grouper{backups, instances, product}
+	paths := [][]string{{"dbaas", "instances", "backups"}, {"dbaas", "instances"}, {"dbaas"}}
+
+	type testCase struct {
+		name      string
+		paramName string
+		schema    *mgcSchemaPkg.Schema
+		found     bool
+		path      []string
+		field     string
+	}
+
+	testCases := []testCase{
+		{"same group", "id", mgcSchemaPkg.NewStringSchema(), true, []string{"dbaas", "instances", "backups", "list"}, "id"},
+		{"parent resource", "instance_id", mgcSchemaPkg.NewStringSchema(), true, []string{"dbaas", "instances", "list"}, "id"},
+		{"camel case", "instanceId", mgcSchemaPkg.NewStringSchema(), true, []string{"dbaas", "instances", "list"}, "id"},
+		{"unknown resource", "volume_id", mgcSchemaPkg.NewStringSchema(), false, nil, ""},
+		{"not a reference", "name", mgcSchemaPkg.NewStringSchema(), false, nil, ""},
+		{"enum", "id", &mgcSchemaPkg.Schema{Type: "string", Enum: []any{"a"}}, false, nil, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			list, found := findResourceList(groups, paths, tc.paramName, tc.schema)
+			if found != tc.found {
+				t.Fatalf("expected found=%v, got %v", tc.found, found)
+			}
+			if !found {
+				return
+			}
+			checkExpectedArray(t, "path", tc.path, list.path)
+			checkExpectedString(t, "field", tc.field, list.field)
+		})
+	}
+}
+
+func Test_completionCache(t *testing.T) {
+	m, _ := profile_manager.NewInMemoryProfileManager()
+	profile := m.Current()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := []completionEntry{{Value: "abc", Description: "web-01"}}
+
+	if _, ok := readCompletionCache(profile, "key", now); ok {
+		t.Fatal("expected empty cache")
+	}
+
+	err := writeCompletionCache(profile, "key", entries, now)
+	checkError(t, "writeCompletionCache", nil, err)
+
+	got, ok := readCompletionCache(profile, "key", now.Add(completionCacheTTL/2))
+	if !ok || len(got) != 1 || got[0] != entries[0] {
+		t.Errorf("expected cached %v, got %v", entries, got)
+	}
+
+	if _, ok := readCompletionCache(profile, "key", now.Add(2*completionCacheTTL)); ok {
+		t.Error("expected expired cache entry")
+	}
+}
+
+func Test_filterCompletionEntries(t *testing.T) {
+	entries := []completionEntry{
+		{Value: "s3://bucket1/"},
+		{Value: "s3://bucket2/", Description: "other"},
+		{Value: "s3://logs/"},
+	}
+
+	got := filterCompletionEntries(entries, "s3://bu")
+	checkExpectedArray(t, "filter", []string{"s3://bucket1/", "s3://bucket2/\tother"}, got)
+}
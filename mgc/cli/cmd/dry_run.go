@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"magalu.cloud/core"
+)
+
+const dryRunFlag = "dry-run"
+
+func addDryRunFlag(cmd *cobra.Command) {
+	cmd.Root().PersistentFlags().Bool(
+		dryRunFlag,
+		false,
+		`Show what the command would do, without doing it. HTTP requests are printed instead
+of sent and blueprints list which steps would run, with which parameters. Commands that
+can't do it refuse to run`,
+	)
+}
+
+func getDryRunFlag(cmd *cobra.Command) bool {
+	dryRun, err := cmd.Root().PersistentFlags().GetBool(dryRunFlag)
+	if err != nil {
+		return false
+	}
+	return dryRun
+}
+
+// checkDryRun refuses to run executors that would change things in dry-run mode
+func checkDryRun(ctx context.Context, exec core.Executor) error {
+	if !core.IsDryRun(ctx) || core.ExecutorSupportsDryRun(exec) {
+		return nil
+	}
+	return core.UsageError{Err: fmt.Errorf("%q does not support --%s", exec.Name(), dryRunFlag)}
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"magalu.cloud/core"
+	mgcSchemaPkg "magalu.cloud/core/schema"
+	mgcSdk "magalu.cloud/sdk"
+)
+
+type testDryRunExecutor struct {
+	core.Executor
+}
+
+func (e *testDryRunExecutor) SupportsDryRun() bool {
+	return true
+}
+
+func newTestStaticExecutor(executed *bool) core.Executor {
+	return core.NewSimpleExecutor(core.ExecutorSpec{
+		DescriptorSpec:   core.DescriptorSpec{Name: "delete", Description: "delete"},
+		ParametersSchema: mgcSchemaPkg.NewObjectSchema(nil, nil),
+		ConfigsSchema:    mgcSchemaPkg.NewObjectSchema(nil, nil),
+		ResultSchema:     mgcSchemaPkg.NewNullSchema(),
+		Execute: func(core.Executor, context.Context, core.Parameters, core.Configs) (core.Result, error) {
+			*executed = true
+			return nil, nil
+		},
+	})
+}
+
+func Test_handleExecutorPre_dryRunNotSupported(t *testing.T) {
+	executed := false
+	exec := core.NewConfirmableExecutor(newTestStaticExecutor(&executed), nil)
+	ctx := core.NewDryRunContext(context.Background(), true)
+
+	_, err := handleExecutorPre(ctx, &mgcSdk.Sdk{}, &cobra.Command{}, exec, core.Parameters{}, core.Configs{})
+	if !errors.As(err, &core.UsageError{}) {
+		t.Errorf("expected usage error, got %v", err)
+	}
+	if executed {
+		t.Errorf("expected executor not to run in dry-run mode")
+	}
+}
+
+func Test_checkDryRun(t *testing.T) {
+	executed := false
+	static := newTestStaticExecutor(&executed)
+	supported := core.NewConfirmableExecutor(&testDryRunExecutor{static}, nil)
+	dryRun := core.NewDryRunContext(context.Background(), true)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		exec    core.Executor
+		wantErr bool
+	}{
+		{name: "static in dry-run", ctx: dryRun, exec: static, wantErr: true},
+		{name: "static without dry-run", ctx: context.Background(), exec: static},
+		{name: "wrapped executor supporting dry-run", ctx: dryRun, exec: supported},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkDryRun(tc.ctx, tc.exec)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("expected error %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,344 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/spf13/cobra"
+	"magalu.cloud/cli/cmd/schema_flags"
+	"magalu.cloud/core"
+	mgcAuthPkg "magalu.cloud/core/auth"
+	mgcHttpPkg "magalu.cloud/core/http"
+	"magalu.cloud/core/utils"
+	mgcSdk "magalu.cloud/sdk"
+)
+
+// Process exit codes, documented in the README. Keep them stable, scripts depend on them
+const (
+	exitCodeSuccess     = 0
+	exitCodeError       = 1
+	exitCodeUsage       = 2
+	exitCodeAuth        = 3
+	exitCodeNotFound    = 4
+	exitCodeConflict    = 5
+	exitCodeTimeout     = 6
+	exitCodeServer      = 7
+	exitCodeRateLimited = 8
+)
+
+// Values of "kind" in the JSON error output
+const (
+	errorKindError       = "error"
+	errorKindUsage       = "usage"
+	errorKindAuth        = "auth"
+	errorKindNotFound    = "not_found"
+	errorKindConflict    = "conflict"
+	errorKindTimeout     = "timeout"
+	errorKindServer      = "server"
+	errorKindRateLimited = "rate_limited"
+	errorKindNetwork     = "network"
+	errorKindCanceled    = "canceled"
+)
+
+var errorKindExitCodes = map[string]int{
+	errorKindError:       exitCodeError,
+	errorKindUsage:       exitCodeUsage,
+	errorKindAuth:        exitCodeAuth,
+	errorKindNotFound:    exitCodeNotFound,
+	errorKindConflict:    exitCodeConflict,
+	errorKindTimeout:     exitCodeTimeout,
+	errorKindServer:      exitCodeServer,
+	errorKindRateLimited: exitCodeRateLimited,
+	errorKindNetwork:     exitCodeError,
+	errorKindCanceled:    exitCodeError,
+}
+
+// authError is returned when the user is not logged in or lacks the required scopes
+type authError struct {
+	Err error
+}
+
+func (e authError) Unwrap() error {
+	return e.Err
+}
+
+func (e authError) Error() string {
+	return e.Err.Error()
+}
+
+// isAuthPkgError checks whether the credentials were missing or couldn't be refreshed
+func isAuthPkgError(err error) bool {
+	return errors.As(err, new(mgcAuthPkg.FailedRefreshAccessToken)) ||
+		errors.Is(err, mgcAuthPkg.ErrRefreshTokenNotSet) ||
+		errors.Is(err, mgcAuthPkg.ErrApiKeyNotSet) ||
+		errors.Is(err, mgcAuthPkg.ErrXTenantIDNotSet)
+}
+
+// errorOutputField points to the flag, and the path inside its value, that failed validation
+type errorOutputField struct {
+	Flag      string `json:"flag,omitempty"`
+	Parameter string `json:"parameter,omitempty"`
+	Path      string `json:"path,omitempty"`
+	Message   string `json:"message"`
+}
+
+// errorOutput is the JSON envelope written to stderr when the output is JSON
+type errorOutput struct {
+	Kind       string             `json:"kind"`
+	ExitCode   int                `json:"exitCode"`
+	Message    string             `json:"message"`
+	HttpStatus int                `json:"httpStatus,omitempty"`
+	Slug       string             `json:"slug,omitempty"`
+	RequestId  string             `json:"requestId,omitempty"`
+	Fields     []errorOutputField `json:"fields,omitempty"`
+	Retryable  bool               `json:"retryable"`
+}
+
+func httpErrorKind(code int) (kind string, retryable bool) {
+	switch {
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return errorKindAuth, false
+	case code == http.StatusNotFound:
+		return errorKindNotFound, false
+	case code == http.StatusConflict:
+		return errorKindConflict, false
+	case code == http.StatusRequestTimeout || code == http.StatusGatewayTimeout:
+		return errorKindTimeout, true
+	case code == http.StatusTooManyRequests:
+		return errorKindRateLimited, true
+	case code == http.StatusNotImplemented:
+		return errorKindServer, false
+	case code >= 500:
+		return errorKindServer, true
+	case code == http.StatusBadRequest || code == http.StatusUnprocessableEntity:
+		return errorKindUsage, false
+	default:
+		return errorKindError, false
+	}
+}
+
+func errorKind(err error) (kind string, retryable bool) {
+	var httpErr *mgcHttpPkg.HttpError
+	var netErr net.Error
+	var nameErr *resourceNameError
+
+	switch {
+	case errors.As(err, &httpErr):
+		return httpErrorKind(httpErr.Code)
+	case errors.As(err, new(authError)), isAuthPkgError(err):
+		return errorKindAuth, false
+	case errors.Is(err, context.DeadlineExceeded):
+		return errorKindTimeout, true
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return errorKindTimeout, true
+		}
+		return errorKindNetwork, true
+	case errors.Is(err, context.Canceled), errors.As(err, new(core.UserDeniedConfirmationError)):
+		return errorKindCanceled, false
+	case errors.As(err, &nameErr):
+		if len(nameErr.Matches) == 0 {
+			return errorKindNotFound, false
+		}
+		// ambiguous, the user must give one of the IDs instead
+		return errorKindUsage, false
+	case errors.As(err, new(core.UsageError)), errors.As(err, new(requiredFlagsError)), errors.Is(err, schema_flags.ErrRequiredFlag):
+		return errorKindUsage, false
+	default:
+		return errorKindError, false
+	}
+}
+
+// flagUsageError makes the Cobra errors parsing the flags, such as unknown flags, usage errors
+func flagUsageError(cmd *cobra.Command, err error) error {
+	return core.UsageError{Err: err}
+}
+
+// usageArgs makes the errors validating the positional arguments, such as
+// a wrong number of them, usage errors
+func usageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validate(cmd, args); err != nil {
+			return core.UsageError{Err: err}
+		}
+		return nil
+	}
+}
+
+// rootArgs reports unknown commands like Cobra does for a root command without Args,
+// but as usage errors
+func rootArgs(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 || !cmd.HasSubCommands() {
+		return nil
+	}
+	msg := fmt.Sprintf("unknown command %q for %q", args[0], cmd.CommandPath())
+	if suggestions := cmd.SuggestionsFor(args[0]); len(suggestions) > 0 {
+		msg += "\n\nDid you mean this?\n\t" + strings.Join(suggestions, "\n\t")
+	}
+	return core.UsageError{Err: errors.New(msg)}
+}
+
+// setUsageErrors makes the flag and positional argument errors of the command tree usage errors,
+// they're not typed by Cobra. Commands added afterwards are not changed.
+func setUsageErrors(root *cobra.Command) {
+	root.SetFlagErrorFunc(flagUsageError)
+	root.Args = rootArgs
+	var visit func(cmd *cobra.Command)
+	visit = func(cmd *cobra.Command) {
+		for _, child := range cmd.Commands() {
+			if child.Args != nil {
+				child.Args = usageArgs(child.Args)
+			}
+			visit(child)
+		}
+	}
+	visit(root)
+}
+
+// ExitCode returns the process exit code for the error returned by Execute()
+func ExitCode(err error) int {
+	if err == nil {
+		return exitCodeSuccess
+	}
+	kind, _ := errorKind(err)
+	return errorKindExitCodes[kind]
+}
+
+func schemaErrorPath(err *openapi3.SchemaError) string {
+	pointer := err.JSONPointer()
+	if len(pointer) == 0 {
+		return ""
+	}
+	return "/" + strings.Join(pointer, "/")
+}
+
+// errorFields collects the flags and schema paths that failed validation
+func errorFields(err error) (fields []errorOutputField) {
+	var required requiredFlagsError
+	var fErr *flagError
+	var schemaErr *openapi3.SchemaError
+	var multiErr utils.MultiError
+	var openapiMultiErr openapi3.MultiError
+
+	switch {
+	case errors.As(err, &multiErr):
+		for _, e := range multiErr {
+			fields = append(fields, errorFields(e)...)
+		}
+
+	case errors.As(err, &required):
+		for _, f := range required {
+			field := errorOutputField{Flag: f.Name, Message: schema_flags.ErrRequiredFlag.Error()}
+			if fv, ok := f.Value.(schema_flags.SchemaFlagValue); ok {
+				field.Parameter = fv.Desc().PropName
+			}
+			fields = append(fields, field)
+		}
+
+	case errors.As(err, &fErr):
+		field := errorOutputField{Flag: fErr.Flag.Name, Message: fErr.Err.Error()}
+		if fv, ok := fErr.Flag.Value.(schema_flags.SchemaFlagValue); ok {
+			field.Parameter = fv.Desc().PropName
+		}
+		if errors.As(fErr.Err, &schemaErr) {
+			field.Path = schemaErrorPath(schemaErr)
+			field.Message = schemaErr.Reason
+		}
+		fields = append(fields, field)
+
+	case errors.As(err, &openapiMultiErr):
+		for _, e := range openapiMultiErr {
+			fields = append(fields, errorFields(e)...)
+		}
+
+	case errors.As(err, &schemaErr):
+		fields = append(fields, errorOutputField{Path: schemaErrorPath(schemaErr), Message: schemaErr.Reason})
+	}
+
+	return fields
+}
+
+func newErrorOutput(err error) *errorOutput {
+	kind, retryable := errorKind(err)
+	output := &errorOutput{
+		Kind:      kind,
+		ExitCode:  errorKindExitCodes[kind],
+		Message:   err.Error(),
+		Fields:    errorFields(err),
+		Retryable: retryable,
+	}
+
+	var idErr *mgcHttpPkg.IdentifiableHttpError
+	if errors.As(err, &idErr) {
+		output.RequestId = idErr.RequestID
+	}
+
+	var httpErr *mgcHttpPkg.HttpError
+	if errors.As(err, &httpErr) {
+		output.HttpStatus = httpErr.Code
+		output.Slug = httpErr.Slug
+	}
+
+	return output
+}
+
+func getErrorOutput(sdk *mgcSdk.Sdk, cmd *cobra.Command) string {
+	output := getOutputFlag(cmd)
+	if output == "" {
+		output = getOutputConfig(sdk)
+	}
+	return output
+}
+
+// isJSONErrorOutput checks whether errors should be reported as JSON, given the output
+func isJSONErrorOutput(output string) bool {
+	name, _ := parseOutputFormatter(output)
+	return name == "json" || name == "ndjson"
+}
+
+func writeErrorOutput(w io.Writer, err error, output string) error {
+	name, _ := parseOutputFormatter(output)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	if name == "json" {
+		enc.SetIndent("", "  ")
+	}
+	return enc.Encode(newErrorOutput(err))
+}
+
+// reportedError wraps errors that were already written to the user, so
+// they are not printed again, but keep their exit code
+type reportedError struct {
+	Err error
+}
+
+func (e reportedError) Unwrap() error {
+	return e.Err
+}
+
+func (e reportedError) Error() string {
+	return e.Err.Error()
+}
+
+// IsErrorReported checks whether the error returned by Execute() was already written to the user
+func IsErrorReported(err error) bool {
+	return errors.As(err, new(reportedError))
+}
+
+func reportJSONError(w io.Writer, err error, output string) error {
+	if err == nil || !isJSONErrorOutput(output) || IsErrorReported(err) {
+		return err
+	}
+	if writeErr := writeErrorOutput(w, err, output); writeErr != nil {
+		logger().Debugw("failed to write error output", "error", writeErr)
+		return err
+	}
+	return reportedError{err}
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/spf13/cobra"
+	"magalu.cloud/core"
+	mgcAuthPkg "magalu.cloud/core/auth"
+	mgcHttpPkg "magalu.cloud/core/http"
+	mgcSchemaPkg "magalu.cloud/core/schema"
+	"magalu.cloud/core/utils"
+)
+
+func newTestHttpError(code int) error {
+	return &mgcHttpPkg.IdentifiableHttpError{
+		HttpError: &mgcHttpPkg.HttpError{
+			Code:    code,
+			Status:  fmt.Sprintf("%d Status", code),
+			Message: "some message",
+			Slug:    "some_slug",
+		},
+		RequestID: "request-1",
+	}
+}
+
+func Test_errorKind(t *testing.T) {
+	tests := []struct {
+		err       error
+		kind      string
+		retryable bool
+		exitCode  int
+	}{
+		{errors.New("other"), errorKindError, false, exitCodeError},
+		{core.UsageError{Err: errors.New("bad flag")}, errorKindUsage, false, exitCodeUsage},
+		{authError{errors.New("not logged in")}, errorKindAuth, false, exitCodeAuth},
+		{newTestHttpError(401), errorKindAuth, false, exitCodeAuth},
+		{newTestHttpError(403), errorKindAuth, false, exitCodeAuth},
+		{newTestHttpError(404), errorKindNotFound, false, exitCodeNotFound},
+		{newTestHttpError(409), errorKindConflict, false, exitCodeConflict},
+		{newTestHttpError(422), errorKindUsage, false, exitCodeUsage},
+		{newTestHttpError(429), errorKindRateLimited, true, exitCodeRateLimited},
+		{newTestHttpError(500), errorKindServer, true, exitCodeServer},
+		{newTestHttpError(501), errorKindServer, false, exitCodeServer},
+		{newTestHttpError(504), errorKindTimeout, true, exitCodeTimeout},
+		{fmt.Errorf("wrapped: %w", newTestHttpError(404)), errorKindNotFound, false, exitCodeNotFound},
+		{fmt.Errorf("waiting: %w", context.DeadlineExceeded), errorKindTimeout, true, exitCodeTimeout},
+		{core.UserDeniedConfirmationError{Prompt: "sure?"}, errorKindCanceled, false, exitCodeError},
+		{core.UsageError{Err: &resourceNameError{Name: "web"}}, errorKindNotFound, false, exitCodeNotFound},
+		{core.UsageError{Err: &resourceNameError{Name: "web", Matches: []string{"a", "b"}}}, errorKindUsage, false, exitCodeUsage},
+		{fmt.Errorf("resolving: %w", &resourceNameError{Name: "web", Matches: []string{"a", "b"}}), errorKindUsage, false, exitCodeUsage},
+		{fmt.Errorf("request: %w", mgcAuthPkg.ErrRefreshTokenNotSet), errorKindAuth, false, exitCodeAuth},
+		{mgcAuthPkg.ErrApiKeyNotSet, errorKindAuth, false, exitCodeAuth},
+		{mgcAuthPkg.FailedRefreshAccessToken{Message: "failed to refresh access token"}, errorKindAuth, false, exitCodeAuth},
+	}
+	for _, tc := range tests {
+		kind, retryable := errorKind(tc.err)
+		checkExpectedString(t, tc.err.Error(), tc.kind, kind)
+		if retryable != tc.retryable {
+			t.Errorf("%s: expected retryable=%v, got %v", tc.err.Error(), tc.retryable, retryable)
+		}
+		if code := ExitCode(tc.err); code != tc.exitCode {
+			t.Errorf("%s: expected exit code %d, got %d", tc.err.Error(), tc.exitCode, code)
+		}
+	}
+
+	if code := ExitCode(nil); code != exitCodeSuccess {
+		t.Errorf("nil: expected exit code %d, got %d", exitCodeSuccess, code)
+	}
+}
+
+func Test_setUsageErrors(t *testing.T) {
+	newRoot := func() *cobra.Command {
+		root := &cobra.Command{Use: "mgc", SilenceErrors: true, SilenceUsage: true, RunE: func(*cobra.Command, []string) error { return nil }}
+		group := &cobra.Command{Use: "vpcs"}
+		group.AddCommand(&cobra.Command{Use: "get", Args: cobra.ExactArgs(1), RunE: func(*cobra.Command, []string) error { return nil }})
+		root.AddCommand(group)
+		setUsageErrors(root)
+		return root
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		kind string
+	}{
+		{name: "valid", args: []string{"vpcs", "get", "id"}},
+		{name: "unknown flag", args: []string{"vpcs", "get", "id", "--unknown"}, kind: errorKindUsage},
+		{name: "unknown command", args: []string{"vpc", "get"}, kind: errorKindUsage},
+		{name: "wrong arg count", args: []string{"vpcs", "get"}, kind: errorKindUsage},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			root := newRoot()
+			root.SetArgs(tc.args)
+			err := root.Execute()
+			if tc.kind == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error of kind %q, got none", tc.kind)
+			}
+			kind, _ := errorKind(err)
+			checkExpectedString(t, err.Error(), tc.kind, kind)
+			if code := ExitCode(err); code != exitCodeUsage {
+				t.Errorf("expected exit code %d, got %d", exitCodeUsage, code)
+			}
+		})
+	}
+}
+
+func Test_errorFields(t *testing.T) {
+	type parameters struct {
+		Name    string `json:"name"`
+		Network struct {
+			Port int `json:"port" jsonschema:"maximum=10"`
+		} `json:"network"`
+	}
+
+	schema, err := mgcSchemaPkg.SchemaFromType[parameters]()
+	checkError(t, "SchemaFromType", nil, err)
+
+	flags, err := newCmdFlags(&cobra.Command{}, schema, &mgcSchemaPkg.Schema{}, nil, nil)
+	checkError(t, "newCmdFlags", nil, err)
+
+	networkFlag := flags.knownFlags["network"]
+	networkSchema := (*mgcSchemaPkg.Schema)(schema.Properties["network"].Value)
+	schemaErr := networkSchema.VisitJSON(map[string]any{"port": 20}, openapi3.MultiErrors())
+
+	err = core.UsageError{Err: utils.MultiError{
+		&flagError{Flag: networkFlag, Err: schemaErr},
+		requiredFlagsError{flags.knownFlags["name"]},
+	}}
+
+	var got []string
+	for _, f := range errorFields(err) {
+		got = append(got, fmt.Sprintf("%s %s %s: %s", f.Flag, f.Parameter, f.Path, f.Message))
+	}
+	checkExpectedArray(t, "fields", []string{
+		"network network /port: number must be at most 10",
+		"name name : is required",
+	}, got)
+}
+
+func Test_reportJSONError(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := reportJSONError(out, newTestHttpError(404), "table")
+	if IsErrorReported(err) || out.Len() > 0 {
+		t.Errorf("table: expected error not to be reported, got %q", out.String())
+	}
+
+	err = reportJSONError(out, newTestHttpError(404), "ndjson")
+	if !IsErrorReported(err) {
+		t.Errorf("ndjson: expected error to be reported")
+	}
+	if code := ExitCode(err); code != exitCodeNotFound {
+		t.Errorf("ndjson: expected exit code %d, got %d", exitCodeNotFound, code)
+	}
+	checkExpectedString(
+		t,
+		"ndjson",
+		`{"kind":"not_found","exitCode":4,"message":"(some_slug) 404 Status - some message (request-id: request-1)","httpStatus":404,"slug":"some_slug","requestId":"request-1","retryable":false}`+"\n",
+		out.String(),
+	)
+}
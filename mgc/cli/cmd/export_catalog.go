@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"encoding/json"
+
+	"github.com/spf13/cobra"
+	"magalu.cloud/core/catalog"
+	mgcSdk "magalu.cloud/sdk"
+)
+
+func newExportCatalogCmd(sdk *mgcSdk.Sdk) *cobra.Command {
+	return &cobra.Command{
+		Use:   "export-catalog",
+		Short: "Print the catalog of all commands",
+		Long: `Walks through the command tree, including the static commands, and prints a versioned catalog of all
+executors with their path, description, parameters, configs and result schemas, links, related executors
+and wait termination. Schemas are OpenAPI 3 Schema Objects. Internal executors are only included with
+--cli.show-internal. Defaults to JSON output, but "-o yaml" and other formats may be used`,
+		GroupID: "other",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := catalog.New(sdk.Group(), getShowInternalFlag(cmd), mgcSdk.Version)
+			if err != nil {
+				return err
+			}
+
+			// the formatters work on plain values, use the JSON names for all of them
+			data, err := json.Marshal(c)
+			if err != nil {
+				return err
+			}
+			var value any
+			if err = json.Unmarshal(data, &value); err != nil {
+				return err
+			}
+
+			output := getOutputFlag(cmd)
+			if output == "" {
+				output = "json"
+			}
+			name, options := parseOutputFormatter(output)
+			formatter, err := getOutputFormatter(name, options)
+			if err != nil {
+				return err
+			}
+
+			return formatter.Format(value, options, getRawOutputFlag(cmd))
+		},
+	}
+}
@@ -51,11 +51,11 @@ func checkScopes(sdk *mgcSdk.Sdk, exec core.Executor) error {
 	}
 
 	if k, s := a.AccessKeyPair(); (k == "" || s == "") && len(missing) > 0 {
-		return fmt.Errorf("you are not logged in. To authenticate, please run 'mgc auth login'")
+		return authError{fmt.Errorf("you are not logged in. To authenticate, please run 'mgc auth login'")}
 	}
 
 	if len(missing) > 0 {
-		return fmt.Errorf("you are missing the following scopes for this operation: %v", missing)
+		return authError{fmt.Errorf("you are missing the following scopes for this operation: %v", missing)}
 	}
 
 	return nil
@@ -69,6 +69,10 @@ func handleExecutorPre(
 	parameters core.Parameters,
 	configs core.Configs,
 ) (core.Result, error) {
+	if err := checkDryRun(ctx, exec); err != nil {
+		return nil, err
+	}
+
 	if err := checkScopes(sdk, exec); err != nil {
 		return nil, err
 	}
@@ -85,7 +89,10 @@ func handleExecutorPre(
 		ctx = progress_report.NewContext(ctx, pb.ReportProgress)
 	}
 
-	if cExec, ok := core.ExecutorAs[core.ConfirmableExecutor](exec); ok && !getBypassConfirmationFlag(cmd) {
+	// executors that support dry-run don't change anything in it, there is nothing to confirm
+	bypassConfirmation := getBypassConfirmationFlag(cmd) || (core.IsDryRun(ctx) && core.ExecutorSupportsDryRun(exec))
+
+	if cExec, ok := core.ExecutorAs[core.ConfirmableExecutor](exec); ok && !bypassConfirmation {
 		msg := cExec.ConfirmPrompt(parameters, configs)
 		run, err := ui.Confirm(msg)
 		if err != nil {
@@ -96,7 +103,7 @@ func handleExecutorPre(
 			return nil, core.UserDeniedConfirmationError{Prompt: msg}
 		}
 	}
-	if pExec, ok := core.ExecutorAs[core.PromptInputExecutor](exec); ok && !getBypassConfirmationFlag(cmd) {
+	if pExec, ok := core.ExecutorAs[core.PromptInputExecutor](exec); ok && !bypassConfirmation {
 		msg, validate := pExec.PromptInput(parameters, configs)
 
 		input, err := ui.RunPromptInput(msg)
@@ -151,6 +158,7 @@ func handleExecutor(
 	configs core.Configs,
 ) (core.Result, error) {
 	ctx = openapi.WithRawOutputFlag(ctx, getRawOutputFlag(cmd))
+	ctx = core.NewDryRunContext(ctx, getDryRunFlag(cmd))
 	result, err := handleExecutorPre(ctx, sdk, cmd, exec, parameters, configs)
 	err = handleExecutorResult(ctx, sdk, cmd, result, err)
 	if err != nil {
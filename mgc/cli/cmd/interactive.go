@@ -0,0 +1,493 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"golang.org/x/term"
+	"magalu.cloud/cli/cmd/schema_flags"
+	"magalu.cloud/cli/ui"
+	"magalu.cloud/core"
+	mgcSchemaPkg "magalu.cloud/core/schema"
+)
+
+const (
+	interactiveFlag = "interactive"
+
+	wizardOtherValue = "⌨ Other value"
+
+	// shown instead of secrets in the summary and the equivalent command, which
+	// may end up in the terminal scrollback or shell history
+	redactedValue = "********"
+)
+
+// names of the parameters that are always redacted, besides the write only and password ones
+var secretNames = []string{"password", "secret", "api_key", "token"}
+
+func addInteractiveFlag(cmd *cobra.Command) {
+	cmd.Root().PersistentFlags().Bool(
+		interactiveFlag,
+		false,
+		`Ask for the command parameters that were not given, with choices for enums and existing resources.
+A summary and the equivalent command line are shown before running it`,
+	)
+}
+
+func getInteractiveFlag(cmd *cobra.Command) bool {
+	interactive, err := cmd.Root().PersistentFlags().GetBool(interactiveFlag)
+	if err != nil {
+		return false
+	}
+	return interactive
+}
+
+// wizardPrompter asks the user for values, see uiWizardPrompter
+type wizardPrompter interface {
+	input(message, initialValue string) (string, error)
+	selectOne(message string, choices []*ui.SelectionChoice) (any, error)
+	selectMany(message string, choices []*ui.SelectionChoice) ([]any, error)
+	confirm(message string) (bool, error)
+}
+
+type uiWizardPrompter struct{}
+
+func (uiWizardPrompter) input(message, initialValue string) (string, error) {
+	return ui.RunPromptInputWithInitialValue(message, initialValue)
+}
+
+func (uiWizardPrompter) selectOne(message string, choices []*ui.SelectionChoice) (any, error) {
+	choice, err := ui.SelectionPromptChoice(message, choices)
+	if err != nil {
+		return nil, err
+	}
+	return choice.Value, nil
+}
+
+func (uiWizardPrompter) selectMany(message string, choices []*ui.SelectionChoice) ([]any, error) {
+	return ui.MultiSelectionPrompt[any](message, choices)
+}
+
+func (uiWizardPrompter) confirm(message string) (bool, error) {
+	return ui.Confirm(message)
+}
+
+// wizard walks the parameters schema asking for the values of the flags
+// that were not given in the command line.
+type wizard struct {
+	prompter wizardPrompter
+	out      io.Writer
+
+	// optional, lists the existing resources that may be given to a parameter
+	resources func(paramName string, schema *mgcSchemaPkg.Schema) []completionEntry
+}
+
+func schemaPropertyNames(schema *mgcSchemaPkg.Schema) (required, optional []string) {
+	for name := range schema.Properties {
+		if slices.Contains(schema.Required, name) {
+			required = append(required, name)
+		} else {
+			optional = append(optional, name)
+		}
+	}
+	slices.Sort(required)
+	slices.Sort(optional)
+	return
+}
+
+func schemaLabel(name string, schema *mgcSchemaPkg.Schema) string {
+	description := schema.Description
+	if description == "" {
+		description = schema.Title
+	}
+	if i := strings.IndexAny(description, ".\n"); i > 0 {
+		description = description[:i]
+	}
+	if description == "" {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, description)
+}
+
+// Nested "id" properties refer to the resource named by their parent, such as "vpc": {"id": ...}
+func wizardParamName(parent, name string) string {
+	if name == completionIdFieldName && parent != "" {
+		return parent + completionIdNameSuffix
+	}
+	return name
+}
+
+func parseWizardInput(schema *mgcSchemaPkg.Schema, input string) (any, error) {
+	switch schema.Type {
+	case "string":
+		return input, nil
+	case "integer":
+		return strconv.ParseInt(strings.TrimSpace(input), 10, 64)
+	case "number":
+		return strconv.ParseFloat(strings.TrimSpace(input), 64)
+	default:
+		var value any
+		if err := json.Unmarshal([]byte(input), &value); err != nil {
+			return input, nil
+		}
+		return value, nil
+	}
+}
+
+func (w *wizard) askObject(label string, schema *mgcSchemaPkg.Schema, parent string) (map[string]any, error) {
+	result := map[string]any{}
+	required, optional := schemaPropertyNames(schema)
+
+	for _, name := range required {
+		propSchema := (*mgcSchemaPkg.Schema)(schema.Properties[name].Value)
+		value, err := w.ask(label+"."+name, propSchema, wizardParamName(parent, name))
+		if err != nil {
+			return nil, err
+		}
+		result[name] = value
+	}
+
+	if len(optional) == 0 {
+		return result, nil
+	}
+
+	selected, err := w.selectOptional(label, schema, optional)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range selected {
+		propSchema := (*mgcSchemaPkg.Schema)(schema.Properties[name].Value)
+		value, err := w.ask(label+"."+name, propSchema, wizardParamName(parent, name))
+		if err != nil {
+			return nil, err
+		}
+		result[name] = value
+	}
+
+	return result, nil
+}
+
+func (w *wizard) selectOptional(label string, schema *mgcSchemaPkg.Schema, names []string) ([]string, error) {
+	choices := make([]*ui.SelectionChoice, len(names))
+	for i, name := range names {
+		propSchema := (*mgcSchemaPkg.Schema)(schema.Properties[name].Value)
+		choices[i] = &ui.SelectionChoice{Value: name, Label: schemaLabel(name, propSchema)}
+	}
+
+	message := "Select the optional fields to set"
+	if label != "" {
+		message += " in " + label
+	}
+
+	selected, err := w.prompter.selectMany(message+":", choices)
+	if err != nil {
+		return nil, err
+	}
+
+	names = make([]string, len(selected))
+	for i, v := range selected {
+		names[i] = v.(string)
+	}
+	return names, nil
+}
+
+func (w *wizard) askArray(label string, schema *mgcSchemaPkg.Schema, paramName string) ([]any, error) {
+	var itemSchema *mgcSchemaPkg.Schema
+	if schema.Items != nil && schema.Items.Value != nil {
+		itemSchema = (*mgcSchemaPkg.Schema)(schema.Items.Value)
+	} else {
+		itemSchema = mgcSchemaPkg.NewAnySchema()
+	}
+
+	if len(itemSchema.Enum) > 0 {
+		return w.prompter.selectMany(label+":", enumChoices(itemSchema.Enum))
+	}
+
+	if itemSchema.Type == "object" || itemSchema.Type == "array" {
+		var items []any
+		for {
+			item, err := w.ask(fmt.Sprintf("%s[%d]", label, len(items)), itemSchema, paramName)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+
+			more, err := w.prompter.confirm(fmt.Sprintf("Add another item to %s?", label))
+			if err != nil || !more {
+				return items, err
+			}
+		}
+	}
+
+	if entries := w.resourceEntries(paramName, itemSchema); len(entries) > 0 {
+		return w.prompter.selectMany(label+":", entryChoices(entries))
+	}
+
+	for {
+		input, err := w.prompter.input(label+" (comma separated):", "")
+		if err != nil {
+			return nil, err
+		}
+
+		items := []any{}
+		for _, s := range strings.Split(input, ",") {
+			if s = strings.TrimSpace(s); s == "" {
+				continue
+			}
+			item, err := parseWizardInput(itemSchema, s)
+			if err == nil {
+				err = itemSchema.VisitJSON(item, openapi3.MultiErrors())
+			}
+			if err != nil {
+				fmt.Fprintf(w.out, "invalid value %q: %s\n", s, err)
+				items = nil
+				break
+			}
+			items = append(items, item)
+		}
+		if items != nil {
+			return items, nil
+		}
+	}
+}
+
+func enumChoices(enum []any) []*ui.SelectionChoice {
+	choices := make([]*ui.SelectionChoice, len(enum))
+	for i, v := range enum {
+		choices[i] = &ui.SelectionChoice{Value: v}
+	}
+	return choices
+}
+
+func entryChoices(entries []completionEntry) []*ui.SelectionChoice {
+	choices := make([]*ui.SelectionChoice, len(entries))
+	for i, e := range entries {
+		label := e.Value
+		if e.Description != "" {
+			label += " (" + e.Description + ")"
+		}
+		choices[i] = &ui.SelectionChoice{Value: e.Value, Label: label}
+	}
+	return choices
+}
+
+func (w *wizard) resourceEntries(paramName string, schema *mgcSchemaPkg.Schema) []completionEntry {
+	if w.resources == nil || paramName == "" {
+		return nil
+	}
+	return w.resources(paramName, schema)
+}
+
+// ask returns the value of a single schema, with paramName used to look for resources to pick from
+func (w *wizard) ask(label string, schema *mgcSchemaPkg.Schema, paramName string) (any, error) {
+	if len(schema.Enum) > 0 {
+		return w.prompter.selectOne(label+":", enumChoices(schema.Enum))
+	}
+
+	switch schema.Type {
+	case "boolean":
+		return w.prompter.selectOne(label+":", enumChoices([]any{true, false}))
+	case "object":
+		if len(schema.Properties) > 0 {
+			return w.askObject(label, schema, paramName)
+		}
+	case "array":
+		return w.askArray(label, schema, paramName)
+	}
+
+	if entries := w.resourceEntries(paramName, schema); len(entries) > 0 {
+		choices := append(entryChoices(entries), &ui.SelectionChoice{Value: wizardOtherValue})
+		value, err := w.prompter.selectOne(label+":", choices)
+		if err != nil || value != wizardOtherValue {
+			return value, err
+		}
+	}
+
+	var initialValue string
+	if schema.Default != nil {
+		initialValue = fmt.Sprint(schema.Default)
+	}
+
+	for {
+		input, err := w.prompter.input(label+":", initialValue)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := parseWizardInput(schema, input)
+		if err == nil {
+			err = schema.VisitJSON(value, openapi3.MultiErrors())
+		}
+		if err == nil {
+			return value, nil
+		}
+		fmt.Fprintf(w.out, "invalid value %q: %s\n", input, err)
+	}
+}
+
+// flagRawValue returns the command line value that parses to value. Strings
+// are given as is, unless they would be parsed differently, such as "@file".
+func flagRawValue(value any) (string, error) {
+	if s, ok := value.(string); ok && s != schema_flags.ValueHelpIsRequired && !strings.HasPrefix(s, `"`) &&
+		!strings.HasPrefix(s, schema_flags.ValueLoadJSONFromFilePrefix) &&
+		!strings.HasPrefix(s, schema_flags.ValueLoadVerbatimFromFilePrefix) &&
+		!strings.HasPrefix(s, schema_flags.ValueVerbatimStringPrefix) {
+		return s, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func isSecretName(name string) bool {
+	name = strings.ReplaceAll(strings.ToLower(name), "-", "_")
+	return slices.Contains(secretNames, name)
+}
+
+// isSecretFlag returns whether the flag value must be redacted: write only parameters,
+// passwords and the ones in secretNames
+func isSecretFlag(f *flag.Flag) bool {
+	if fv, ok := f.Value.(schema_flags.SchemaFlagValue); ok {
+		desc := fv.Desc()
+		if desc.Schema != nil && (desc.Schema.WriteOnly || desc.Schema.Format == "password") {
+			return true
+		}
+		if isSecretName(desc.PropName) {
+			return true
+		}
+	}
+	return isSecretName(f.Name)
+}
+
+func displayFlagValue(f *flag.Flag, value string) string {
+	if isSecretFlag(f) {
+		return redactedValue
+	}
+	return value
+}
+
+func isWizardFlag(f *flag.Flag) bool {
+	fv, ok := f.Value.(schema_flags.SchemaFlagValue)
+	return ok && !fv.Changed() && !fv.Desc().IsConfig && !f.Hidden
+}
+
+func sortedFlags(flags []*flag.Flag) []*flag.Flag {
+	return slices.SortedFunc(slices.Values(flags), func(a, b *flag.Flag) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+// commandLine returns the equivalent command line with all the flags that
+// were set, either in the command line or by the wizard (rawValues). Secrets are redacted.
+func (cf *cmdFlags) commandLine(cmd *cobra.Command, cmdPath string, rawValues map[string]string) string {
+	args := strings.Fields(cmdPath)
+	seen := map[string]bool{interactiveFlag: true}
+
+	for _, f := range sortedFlags(cf.schemaFlags) {
+		if raw, ok := rawValues[f.Name]; ok {
+			args = append(args, fmt.Sprintf("--%s=%s", f.Name, displayFlagValue(f, raw)))
+		} else if f.Value.(schema_flags.SchemaFlagValue).Changed() {
+			args = append(args, fmt.Sprintf("--%s=%s", f.Name, displayFlagValue(f, f.Value.String())))
+		} else {
+			continue
+		}
+		seen[f.Name] = true
+	}
+
+	cmd.Flags().Visit(func(f *flag.Flag) {
+		if seen[f.Name] || slices.Contains(cf.childFlags, f) {
+			return
+		}
+		args = append(args, fmt.Sprintf("--%s=%s", f.Name, displayFlagValue(f, f.Value.String())))
+	})
+
+	return joinAliasCommand(args)
+}
+
+// runWizard asks for the parameters that were not given, sets their flags and
+// returns whether the command should be executed.
+func (cf *cmdFlags) runWizard(cmd *cobra.Command, cmdPath string) (bool, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, core.UsageError{Err: fmt.Errorf("--%s requires a terminal", interactiveFlag)}
+	}
+
+	w := &wizard{prompter: uiWizardPrompter{}, out: os.Stderr}
+	if cf.completer != nil {
+		groups, paths := cf.completer.commandGroups(cmd)
+		w.resources = func(paramName string, schema *mgcSchemaPkg.Schema) []completionEntry {
+			return cf.completer.resourceEntries(cf, groups, paths, paramName, schema)
+		}
+	}
+
+	return cf.runWizardWith(w, cmd, cmdPath)
+}
+
+func (cf *cmdFlags) runWizardWith(w *wizard, cmd *cobra.Command, cmdPath string) (bool, error) {
+	var required, optional []*flag.Flag
+	for _, f := range sortedFlags(cf.schemaFlags) {
+		if !isWizardFlag(f) {
+			continue
+		}
+		if f.Value.(schema_flags.SchemaFlagValue).Desc().IsRequired {
+			required = append(required, f)
+		} else {
+			optional = append(optional, f)
+		}
+	}
+
+	rawValues := map[string]string{}
+	ask := func(f *flag.Flag) error {
+		desc := f.Value.(schema_flags.SchemaFlagValue).Desc()
+		value, err := w.ask(f.Name, desc.Schema, desc.PropName)
+		if err != nil {
+			return err
+		}
+		raw, err := flagRawValue(value)
+		if err != nil {
+			return err
+		}
+		rawValues[f.Name] = raw
+		return cmd.Flags().Set(f.Name, raw)
+	}
+
+	for _, f := range required {
+		if err := ask(f); err != nil {
+			return false, err
+		}
+	}
+
+	if len(optional) > 0 {
+		choices := make([]*ui.SelectionChoice, len(optional))
+		for i, f := range optional {
+			choices[i] = &ui.SelectionChoice{Value: f, Label: getFlagActiveHelp(f)}
+		}
+		selected, err := w.prompter.selectMany("Select the optional parameters to set:", choices)
+		if err != nil {
+			return false, err
+		}
+		for _, v := range selected {
+			if err := ask(v.(*flag.Flag)); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	fmt.Fprintln(w.out, "\nSummary:")
+	for _, f := range sortedFlags(cf.schemaFlags) {
+		if fv := f.Value.(schema_flags.SchemaFlagValue); fv.Changed() && !fv.Desc().IsConfig {
+			fmt.Fprintf(w.out, "  %s: %s\n", f.Name, displayFlagValue(f, f.Value.String()))
+		}
+	}
+	fmt.Fprintf(w.out, "\nEquivalent command:\n  %s\n\n", cf.commandLine(cmd, cmdPath, rawValues))
+
+	return w.prompter.confirm("Run this command?")
+}
@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"magalu.cloud/cli/ui"
+	mgcSchemaPkg "magalu.cloud/core/schema"
+)
+
+// scriptedPrompter answers with the given values in order: strings for
+// input(), choice indexes for selectOne()/selectMany() and booleans for confirm()
+type scriptedPrompter struct {
+	answers  []any
+	messages []string
+}
+
+func (p *scriptedPrompter) next(message string) any {
+	p.messages = append(p.messages, message)
+	if len(p.answers) == 0 {
+		panic(fmt.Sprintf("no answer for %q", message))
+	}
+	answer := p.answers[0]
+	p.answers = p.answers[1:]
+	return answer
+}
+
+func (p *scriptedPrompter) input(message, initialValue string) (string, error) {
+	return p.next(message).(string), nil
+}
+
+func (p *scriptedPrompter) selectOne(message string, choices []*ui.SelectionChoice) (any, error) {
+	return choices[p.next(message).(int)].Value, nil
+}
+
+func (p *scriptedPrompter) selectMany(message string, choices []*ui.SelectionChoice) ([]any, error) {
+	var result []any
+	for _, i := range p.next(message).([]int) {
+		result = append(result, choices[i].Value)
+	}
+	return result, nil
+}
+
+func (p *scriptedPrompter) confirm(message string) (bool, error) {
+	return p.next(message).(bool), nil
+}
+
+func Test_wizard_ask(t *testing.T) {
+	type network struct {
+		Vpc struct {
+			Id string `json:"id"`
+		} `json:"vpc"`
+		Mode string `json:"mode,omitempty" jsonschema:"enum=public,enum=private"`
+	}
+
+	type testCase struct {
+		name      string
+		schema    func() (*mgcSchemaPkg.Schema, error)
+		answers   []any
+		resources map[string][]completionEntry
+		expected  string
+	}
+	tests := []testCase{
+		{
+			name:     "string",
+			schema:   func() (*mgcSchemaPkg.Schema, error) { return mgcSchemaPkg.NewStringSchema(), nil },
+			answers:  []any{"some value"},
+			expected: `some value`,
+		},
+		{
+			name:     "integer/retry",
+			schema:   func() (*mgcSchemaPkg.Schema, error) { return mgcSchemaPkg.NewIntegerSchema(), nil },
+			answers:  []any{"abc", "42"},
+			expected: `42`,
+		},
+		{
+			name:     "boolean",
+			schema:   func() (*mgcSchemaPkg.Schema, error) { return mgcSchemaPkg.NewBooleanSchema(), nil },
+			answers:  []any{1},
+			expected: `false`,
+		},
+		{
+			name:     "array/comma separated",
+			schema:   func() (*mgcSchemaPkg.Schema, error) { return mgcSchemaPkg.SchemaFromType[[]int]() },
+			answers:  []any{"1, 2,3"},
+			expected: `[1,2,3]`,
+		},
+		{
+			name:     "object/resources",
+			schema:   mgcSchemaPkg.SchemaFromType[network],
+			answers:  []any{1, []int{0}, 1},
+			expected: `{"mode":"private","vpc":{"id":"vpc-2"}}`,
+			resources: map[string][]completionEntry{
+				"vpc_id": {{Value: "vpc-1"}, {Value: "vpc-2", Description: "default"}},
+			},
+		},
+		{
+			name:     "object/other value",
+			schema:   mgcSchemaPkg.SchemaFromType[network],
+			answers:  []any{1, "vpc-3", []int{}},
+			expected: `{"vpc":{"id":"vpc-3"}}`,
+			resources: map[string][]completionEntry{
+				"vpc_id": {{Value: "vpc-1"}},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			schema, err := tc.schema()
+			checkError(t, "schema", nil, err)
+
+			w := &wizard{
+				prompter: &scriptedPrompter{answers: tc.answers},
+				out:      &bytes.Buffer{},
+				resources: func(paramName string, schema *mgcSchemaPkg.Schema) []completionEntry {
+					return tc.resources[paramName]
+				},
+			}
+			value, err := w.ask("param", schema, "param")
+			checkError(t, "ask", nil, err)
+
+			raw, err := flagRawValue(value)
+			checkError(t, "flagRawValue", nil, err)
+			checkExpectedString(t, "value", tc.expected, raw)
+		})
+	}
+}
+
+func Test_flagRawValue(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected string
+	}{
+		{"name", "name"},
+		{"with space", "with space"},
+		{"@file", `"@file"`},
+		{"%file", `"%file"`},
+		{"#text", `"#text"`},
+		{`"quoted"`, `"\"quoted\""`},
+		{"help", `"help"`},
+		{123, "123"},
+		{[]any{"a", "b"}, `["a","b"]`},
+	}
+	for _, tc := range tests {
+		raw, err := flagRawValue(tc.value)
+		checkError(t, fmt.Sprint(tc.value), nil, err)
+		checkExpectedString(t, fmt.Sprint(tc.value), tc.expected, raw)
+	}
+}
+
+func Test_cmdFlags_runWizardWith(t *testing.T) {
+	type parameters struct {
+		Name  string   `json:"name"`
+		Kind  string   `json:"kind" jsonschema:"enum=small,enum=large"`
+		Count int      `json:"count,omitempty"`
+		Tags  []string `json:"tags,omitempty"`
+	}
+
+	schema, err := mgcSchemaPkg.SchemaFromType[parameters]()
+	checkError(t, "SchemaFromType", nil, err)
+
+	flags, err := newCmdFlags(&cobra.Command{}, schema, &mgcSchemaPkg.Schema{}, nil, nil)
+	checkError(t, "newCmdFlags", nil, err)
+	cmd := &cobra.Command{Use: "create"}
+	flags.addFlags(cmd)
+
+	checkError(t, "parse flags", nil, cmd.ParseFlags([]string{"--name=web 01"}))
+
+	prompter := &scriptedPrompter{answers: []any{1, []int{0}, "x", "3", true}}
+	out := &bytes.Buffer{}
+	run, err := flags.runWizardWith(&wizard{prompter: prompter, out: out}, cmd, "mgc test create")
+	checkError(t, "runWizardWith", nil, err)
+	if !run {
+		t.Errorf("expected the command to run")
+	}
+
+	checkExpectedArray(t, "messages", []string{
+		"kind:",
+		"Select the optional parameters to set:",
+		"count:",
+		"count:",
+		"Run this command?",
+	}, prompter.messages)
+
+	params, _, err := flags.getValues(nil, nil)
+	checkError(t, "getValues", nil, err)
+	checkExpectedString(t, "params", "map[count:3 kind:large name:web 01]", fmt.Sprint(params))
+
+	checkExpectedString(
+		t,
+		"output",
+		`invalid value "x": strconv.ParseInt: parsing "x": invalid syntax
+
+Summary:
+  count: 3
+  kind: large
+  name: web 01
+
+Equivalent command:
+  mgc test create --count=3 --kind=large '--name=web 01'
+
+`,
+		out.String(),
+	)
+}
+
+func Test_cmdFlags_runWizardWith_redactsSecrets(t *testing.T) {
+	password := mgcSchemaPkg.NewStringSchema()
+	password.Format = "password"
+	token := mgcSchemaPkg.NewStringSchema()
+	token.WriteOnly = true
+	schema := mgcSchemaPkg.NewObjectSchema(map[string]*mgcSchemaPkg.Schema{
+		"name":         mgcSchemaPkg.NewStringSchema(),
+		"password":     password,
+		"token":        token,
+		"secret":       mgcSchemaPkg.NewStringSchema(),
+		"api_key":      mgcSchemaPkg.NewStringSchema(),
+		"ssh_key_name": mgcSchemaPkg.NewStringSchema(),
+	}, []string{"name", "password"})
+
+	flags, err := newCmdFlags(&cobra.Command{}, schema, &mgcSchemaPkg.Schema{}, nil, nil)
+	checkError(t, "newCmdFlags", nil, err)
+	cmd := &cobra.Command{Use: "create"}
+	flags.addFlags(cmd)
+
+	checkError(t, "parse flags", nil, cmd.ParseFlags([]string{"--name=db", "--token=t0k3n", "--secret=s3cr3t", "--api_key=k3y", "--ssh_key_name=my-key"}))
+
+	prompter := &scriptedPrompter{answers: []any{"p4ssw0rd", true}}
+	out := &bytes.Buffer{}
+	_, err = flags.runWizardWith(&wizard{prompter: prompter, out: out}, cmd, "mgc test create")
+	checkError(t, "runWizardWith", nil, err)
+
+	params, _, err := flags.getValues(nil, nil)
+	checkError(t, "getValues", nil, err)
+	checkExpectedString(t, "password", "p4ssw0rd", fmt.Sprint(params["password"]))
+
+	checkExpectedString(
+		t,
+		"output",
+		`
+Summary:
+  api_key: ********
+  name: db
+  password: ********
+  secret: ********
+  ssh_key_name: my-key
+  token: ********
+
+Equivalent command:
+  mgc test create --api_key=******** --name=db --password=******** --secret=******** --ssh_key_name=my-key --token=********
+
+`,
+		out.String(),
+	)
+}
@@ -167,6 +167,11 @@ func (c *cmdLinks) handle(originalResult core.Result, parentOutputFlag string) (
 	logger().Debugw("handling link", "link", link.Name(), "originalResult", originalResult.Source())
 
 	ctx := originalResult.Source().Context
+	if core.IsDryRun(ctx) {
+		logger().Debugw("dry-run, not handling link", "link", link.Name())
+		return
+	}
+
 	exec, err := link.CreateExecutor(originalResult)
 	if err != nil {
 		logger().Debugw("could not create link executor", "originalResult", originalResult, "error", err, "link", link.Name())
@@ -247,6 +252,7 @@ func (c *cmdLinks) initCommands() {
 	})
 
 	addOutputFlag(c.root)
+	addOutputTransformFlags(c.root)
 	addWaitTerminationFlag(c.root)
 	addRetryUntilFlag(c.root)
 	addBypassConfirmationFlag(c.root)
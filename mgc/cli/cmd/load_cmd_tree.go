@@ -281,6 +281,7 @@ func addAction(
 	if err != nil {
 		return
 	}
+	flags.completer = newResourceCompleter(sdk)
 
 	name, aliases := getCommandNameAndAliases(exec.Name())
 	cmdPath := fmt.Sprintf("%s %s", parentCmd.CommandPath(), name)
@@ -313,12 +314,22 @@ func addAction(
 				return err
 			}
 
+			if getInteractiveFlag(cmd) {
+				if run, err := flags.runWizard(cmd, cmdPath); err != nil || !run {
+					return err
+				}
+			}
+
 			config := sdk.Config()
 			parameters, configs, err := flags.getValues(config, args)
 			if err != nil {
 				return err
 			}
 
+			if err := flags.resolveResourceNames(cmd, parameters); err != nil {
+				return err
+			}
+
 			ctx := sdk.NewContext()
 			result, err := handleExecutor(ctx, sdk, cmd, exec, parameters, configs)
 			if err != nil {
@@ -39,3 +39,14 @@ func (o *osArgParser) ChainedArgs() [][]string {
 	}
 	return o.chainedArgs
 }
+
+// Replaces the arguments until the first "!" separator, such as when expanding aliases
+func (o *osArgParser) SetMainArgs(args []string) {
+	chainedArgs := o.ChainedArgs()
+	if len(chainedArgs) > 0 {
+		chainedArgs[0] = args
+	} else {
+		o.chainedArgs = [][]string{args}
+	}
+	o.mainArgs = args
+}
@@ -82,7 +82,8 @@ func getOutputFor(sdk *mgcSdk.Sdk, cmd *cobra.Command, result core.Result) strin
 		output = getOutputConfig(sdk)
 	}
 
-	if output == "" {
+	// default output options may refer to fields removed by --fields
+	if output == "" && len(getFieldsFlag(cmd)) == 0 {
 		if outputOptions, ok := core.ResultAs[core.ResultWithDefaultOutputOptions](result); ok {
 			return outputOptions.DefaultOutputOptions()
 		}
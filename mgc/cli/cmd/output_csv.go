@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"magalu.cloud/core/utils"
+)
+
+const flatColumnDefaultName = "VALUE"
+
+type delimitedOutputFormatter struct {
+	comma rune
+	name  string
+}
+
+// flatRows evaluates the table columns against val, one row per item of list
+// results. Objects are a single row, as they would be rendered vertically by
+// the table formatter.
+func flatRows(val any, options *tableOptions) (header []string, rows [][]any, err error) {
+	header = make([]string, len(options.Columns))
+	for colIdx, col := range options.Columns {
+		header[colIdx] = flatColumnName(col)
+
+		var values []any
+		values, err = flatColumnValues(val, col.JSONPath, options.BuildVertically)
+		if err != nil {
+			return
+		}
+
+		for rowIdx, v := range values {
+			if rowIdx >= len(rows) {
+				rows = append(rows, make([]any, len(options.Columns)))
+			}
+			rows[rowIdx][colIdx] = v
+		}
+	}
+	return
+}
+
+// flatColumnValues returns the column value of each row. Paths over a list, ex:
+// "$.instances[*].machine.id", are evaluated on each item, so items without the
+// field have empty cells instead of shifting the values of the next ones up
+func flatColumnValues(val any, jsonPath string, vertical bool) ([]any, error) {
+	idx := strings.Index(jsonPath, "[*]")
+	if idx < 0 || vertical {
+		result, err := utils.GetJsonPath(jsonPath, val)
+		if err != nil {
+			return nil, err
+		}
+		if arr, ok := result.([]any); ok && !vertical {
+			return arr, nil
+		}
+		return []any{result}, nil
+	}
+
+	itemsPath, itemPath := jsonPath[:idx+len("[*]")], "$"+jsonPath[idx+len("[*]"):]
+	items, err := utils.GetJsonPath(itemsPath, val)
+	if err != nil {
+		return nil, err
+	}
+	itemJSONPath, err := utils.NewJsonPath(itemPath)
+	if err != nil {
+		return nil, err
+	}
+
+	arr, _ := items.([]any)
+	values := make([]any, len(arr))
+	for i, item := range arr {
+		// missing fields are evaluation errors, leave them empty
+		values[i], _ = itemJSONPath(context.Background(), item)
+	}
+	return values, nil
+}
+
+// Parent columns are joined with ".", so {"machine": {"id": ...}} becomes "MACHINE.ID"
+func flatColumnName(col *column) string {
+	parts := make([]string, 0, len(col.Parents)+1)
+	parts = append(parts, col.Parents...)
+	if col.Name != "" {
+		parts = append(parts, col.Name)
+	}
+	if len(parts) == 0 {
+		return flatColumnDefaultName
+	}
+	return strings.Join(parts, ".")
+}
+
+func flatTableOptions(val any, options string) (*tableOptions, error) {
+	if options != "" {
+		return tableOptionsFromString(options, val)
+	}
+	return tableOptionsFromAny(val, "$", nil)
+}
+
+func flatCellString(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32:
+		return fmt.Sprint(v), nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+}
+
+func (f *delimitedOutputFormatter) write(w io.Writer, val any, options string) error {
+	tableOptions, err := flatTableOptions(val, options)
+	if err != nil {
+		return err
+	}
+
+	header, rows, err := flatRows(val, tableOptions)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = f.comma
+	if err = writer.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(header))
+	for _, row := range rows {
+		for i, value := range row {
+			if record[i], err = flatCellString(value); err != nil {
+				return err
+			}
+		}
+		if err = writer.Write(record); err != nil {
+			return err
+		}
+		// flush each line so the output may be consumed as it's written
+		writer.Flush()
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func (f *delimitedOutputFormatter) Format(val any, options string, isRaw bool) error {
+	return f.write(os.Stdout, val, options)
+}
+
+func (f *delimitedOutputFormatter) Description() string {
+	return fmt.Sprintf(`Format as %s, one line per item with a header line.`, f.name) +
+		` Columns are inferred from data layout and nested fields are named "PARENT.FIELD".` +
+		fmt.Sprintf(` Use "%s=COLNAME1:jsonpath-expression1,COLNAME2:jsonpath-expression2" to select and rename columns, like the table formatter.`, strings.ToLower(f.name))
+}
+
+func init() {
+	outputFormatters["csv"] = &delimitedOutputFormatter{comma: ',', name: "CSV"}
+	outputFormatters["tsv"] = &delimitedOutputFormatter{comma: '\t', name: "TSV"}
+}
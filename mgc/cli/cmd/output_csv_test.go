@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+)
+
+func testListResult() any {
+	return map[string]any{
+		"instances": []any{
+			map[string]any{"id": "1", "name": "web-01", "machine": map[string]any{"vcpus": float64(2)}},
+			map[string]any{"id": "2", "name": "web, \"02\"", "machine": map[string]any{"vcpus": float64(4)}},
+		},
+	}
+}
+
+func TestDelimitedOutputFormatter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		comma    rune
+		options  string
+		val      any
+		expected string
+	}{
+		{
+			name:     "csv inferred columns",
+			comma:    ',',
+			val:      testListResult(),
+			expected: "ID,MACHINE.VCPUS,NAME\n1,2,web-01\n2,4,\"web, \"\"02\"\"\"\n",
+		},
+		{
+			name:     "tsv selected columns",
+			comma:    '\t',
+			options:  "NAME:$.instances[*].name,CPUS:$.instances[*].machine.vcpus",
+			val:      testListResult(),
+			expected: "NAME\tCPUS\nweb-01\t2\n\"web, \"\"02\"\"\"\t4\n",
+		},
+		{
+			name:    "missing fields are empty cells",
+			comma:   ',',
+			options: "NAME:$.instances[*].name,CPUS:$.instances[*].machine.vcpus",
+			val: map[string]any{"instances": []any{
+				map[string]any{"name": "web-01"},
+				map[string]any{"name": "web-02", "machine": map[string]any{"vcpus": float64(4)}},
+			}},
+			expected: "NAME,CPUS\nweb-01,\nweb-02,4\n",
+		},
+		{
+			name:     "object is a single row",
+			comma:    ',',
+			val:      map[string]any{"id": "1", "tags": []any{"a", "b"}},
+			expected: "ID,TAGS\n1,\"[\"\"a\"\",\"\"b\"\"]\"\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			f := &delimitedOutputFormatter{comma: tc.comma}
+			if err := f.write(&buf, tc.val, tc.options); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkExpectedString(t, tc.name, tc.expected, buf.String())
+		})
+	}
+}
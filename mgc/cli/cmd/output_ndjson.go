@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+)
+
+type ndjsonOutputFormatter struct{}
+
+func (*ndjsonOutputFormatter) write(w io.Writer, val any, options string) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	if options == "" {
+		items, ok := listItems(val)
+		if !ok {
+			return enc.Encode(val)
+		}
+		for _, item := range items {
+			if err := enc.Encode(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tableOptions, err := tableOptionsFromString(options, val)
+	if err != nil {
+		return err
+	}
+
+	header, rows, err := flatRows(val, tableOptions)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		item := make(map[string]any, len(header))
+		for i, value := range row {
+			item[header[i]] = value
+		}
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *ndjsonOutputFormatter) Format(val any, options string, isRaw bool) error {
+	return f.write(os.Stdout, val, options)
+}
+
+func (*ndjsonOutputFormatter) Description() string {
+	return `Format as newline delimited JSON, one compact JSON object per line for each item of list results.` +
+		` Use "ndjson=NAME1:jsonpath-expression1,NAME2:jsonpath-expression2" to select and rename fields, like the table formatter.`
+}
+
+func init() {
+	outputFormatters["ndjson"] = &ndjsonOutputFormatter{}
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNdjsonOutputFormatter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		options  string
+		val      any
+		expected string
+	}{
+		{
+			name:     "one item per line",
+			val:      testListResult(),
+			expected: `{"id":"1","machine":{"vcpus":2},"name":"web-01"}` + "\n" + `{"id":"2","machine":{"vcpus":4},"name":"web, \"02\""}` + "\n",
+		},
+		{
+			name:     "selected fields",
+			options:  "name:$.instances[*].name",
+			val:      testListResult(),
+			expected: `{"name":"web-01"}` + "\n" + `{"name":"web, \"02\""}` + "\n",
+		},
+		{
+			name:    "missing fields are null",
+			options: "name:$[*].name,cpus:$[*].machine.vcpus",
+			val: []any{
+				map[string]any{"name": "web-01"},
+				map[string]any{"name": "web-02", "machine": map[string]any{"vcpus": float64(4)}},
+			},
+			expected: `{"cpus":null,"name":"web-01"}` + "\n" + `{"cpus":4,"name":"web-02"}` + "\n",
+		},
+		{
+			name:     "object",
+			val:      map[string]any{"id": "1", "name": "web-01"},
+			expected: `{"id":"1","name":"web-01"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := (&ndjsonOutputFormatter{}).write(&buf, tc.val, tc.options); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkExpectedString(t, tc.name, tc.expected, buf.String())
+		})
+	}
+}
@@ -21,7 +21,8 @@ func (*templateOutputFormatter) Format(value any, options string, isRaw bool) er
 
 func (*templateOutputFormatter) Description() string {
 	return `Format using https://pkg.go.dev/text/template. Use "template=your-template-here."` +
-		` For more complex specifications, see "template-file".`
+		` For more complex specifications, see "template-file". Functions such as "date", "fileSize", "default",` +
+		` "toJson", "join" and "table" are available, see the list below.`
 }
 
 func init() {
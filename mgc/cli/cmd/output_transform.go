@@ -0,0 +1,290 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"magalu.cloud/core"
+	"magalu.cloud/core/utils"
+)
+
+const (
+	filterFlag = "filter"
+	sortByFlag = "sort-by"
+	fieldsFlag = "fields"
+
+	sortDescendingPrefix = "-"
+	fieldSeparator       = "."
+)
+
+func addOutputTransformFlags(cmd *cobra.Command) {
+	flags := cmd.Root().PersistentFlags()
+	flags.String(
+		filterFlag,
+		"",
+		`Only keep the list items matching the JSONPath predicate, evaluated against each item.
+The fields it uses must exist in all items. Example: --filter='$.status == "running"'`)
+	flags.String(
+		sortByFlag,
+		"",
+		`Sort the list items by the JSONPath expression, evaluated against each item.
+Prefix with "-" to sort in descending order. Example: --sort-by=-created_at`)
+	flags.StringSlice(
+		fieldsFlag,
+		nil,
+		`Only keep the given fields of each list item, or of the result object, using "." for nested fields.
+Example: --fields=id,name,machine_type.name`)
+}
+
+func getStringPersistentFlag(cmd *cobra.Command, name string) string {
+	if f := cmd.Root().PersistentFlags().Lookup(name); f != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+func getFilterFlag(cmd *cobra.Command) string {
+	return getStringPersistentFlag(cmd, filterFlag)
+}
+
+func getSortByFlag(cmd *cobra.Command) string {
+	return getStringPersistentFlag(cmd, sortByFlag)
+}
+
+func getFieldsFlag(cmd *cobra.Command) []string {
+	fields, err := cmd.Root().PersistentFlags().GetStringSlice(fieldsFlag)
+	if err != nil {
+		return nil
+	}
+	return fields
+}
+
+// resultTransform filters, sorts and projects the items of list results
+// before they are formatted, so it works with every output formatter.
+type resultTransform struct {
+	filter         func(document any) (bool, error)
+	sortBy         func(document any) (any, error)
+	sortDescending bool
+	fields         [][]string
+}
+
+var bareFieldPathRe = regexp.MustCompile(`^[A-Za-z_][\w]*(\.[A-Za-z_][\w]*)*$`)
+
+// Field paths may omit the leading "$.", as in "name" or "machine_type.name"
+func itemJsonPath(expression string) string {
+	if bareFieldPathRe.MatchString(expression) {
+		return "$." + expression
+	}
+	return expression
+}
+
+func newResultTransform(filter, sortBy string, fields []string) (t *resultTransform, err error) {
+	t = &resultTransform{}
+
+	if filter != "" {
+		t.filter, err = utils.CreateJsonPathChecker(itemJsonPath(filter))
+		if err != nil {
+			return nil, core.UsageError{Err: fmt.Errorf("invalid --%s expression %q: %w", filterFlag, filter, err)}
+		}
+	}
+
+	if sortBy != "" {
+		sortBy, t.sortDescending = strings.CutPrefix(sortBy, sortDescendingPrefix)
+		jp, err := utils.NewJsonPath(itemJsonPath(sortBy))
+		if err != nil {
+			return nil, core.UsageError{Err: fmt.Errorf("invalid --%s expression %q: %w", sortByFlag, sortBy, err)}
+		}
+		t.sortBy = func(document any) (any, error) {
+			return jp(context.Background(), document)
+		}
+	}
+
+	for _, field := range fields {
+		if field = strings.TrimSpace(field); field != "" {
+			t.fields = append(t.fields, strings.Split(field, fieldSeparator))
+		}
+	}
+
+	return t, nil
+}
+
+func (t *resultTransform) isEmpty() bool {
+	return t.filter == nil && t.sortBy == nil && len(t.fields) == 0
+}
+
+func (t *resultTransform) apply(value any) (any, error) {
+	switch v := value.(type) {
+	case []any:
+		return t.applyItems(v)
+
+	case map[string]any:
+		// same as findListSchema(): objects with a single array property are lists
+		if len(v) == 1 {
+			for k, items := range v {
+				if array, ok := items.([]any); ok {
+					result, err := t.applyItems(array)
+					return map[string]any{k: result}, err
+				}
+			}
+		}
+		if err := t.checkList(); err != nil {
+			return nil, err
+		}
+		return t.project(v), nil
+
+	default:
+		if err := t.checkList(); err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+}
+
+// checkList fails if the transform needs a list, as the filter and sort flags would be ignored otherwise
+func (t *resultTransform) checkList() error {
+	if t.filter != nil || t.sortBy != nil {
+		return core.UsageError{Err: fmt.Errorf("--%s and --%s require a list result", filterFlag, sortByFlag)}
+	}
+	return nil
+}
+
+func (t *resultTransform) applyItems(items []any) (result []any, err error) {
+	result = make([]any, 0, len(items))
+	for i, item := range items {
+		if t.filter != nil {
+			ok, err := t.filter(item)
+			if err != nil {
+				return nil, core.UsageError{Err: fmt.Errorf("--%s failed on item %d: %w", filterFlag, i, err)}
+			}
+			if !ok {
+				continue
+			}
+		}
+		result = append(result, item)
+	}
+
+	if t.sortBy != nil {
+		keys := make(map[int]any, len(result))
+		indexes := make([]int, len(result))
+		for i, item := range result {
+			indexes[i] = i
+			keys[i], _ = t.sortBy(item)
+		}
+		slices.SortStableFunc(indexes, func(a, b int) int {
+			c := compareSortKeys(keys[a], keys[b])
+			if t.sortDescending && keys[a] != nil && keys[b] != nil {
+				return -c
+			}
+			return c
+		})
+		sorted := make([]any, len(result))
+		for i, idx := range indexes {
+			sorted[i] = result[idx]
+		}
+		result = sorted
+	}
+
+	if len(t.fields) > 0 {
+		for i, item := range result {
+			if m, ok := item.(map[string]any); ok {
+				result[i] = t.project(m)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (t *resultTransform) project(m map[string]any) map[string]any {
+	if len(t.fields) == 0 {
+		return m
+	}
+
+	result := map[string]any{}
+	for _, field := range t.fields {
+		value, ok := lookupField(m, field)
+		if !ok {
+			continue
+		}
+
+		target := result
+		for _, name := range field[:len(field)-1] {
+			child, ok := target[name].(map[string]any)
+			if !ok {
+				child = map[string]any{}
+				target[name] = child
+			}
+			target = child
+		}
+		target[field[len(field)-1]] = value
+	}
+	return result
+}
+
+func lookupField(m map[string]any, field []string) (value any, ok bool) {
+	value = m
+	for _, name := range field {
+		var obj map[string]any
+		if obj, ok = value.(map[string]any); !ok {
+			return nil, false
+		}
+		if value, ok = obj[name]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func sortKeyNumber(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+// missing keys go last, even in descending order. Numbers are compared as such,
+// everything else as strings
+func compareSortKeys(a, b any) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return 1
+		default:
+			return -1
+		}
+	}
+
+	if na, ok := sortKeyNumber(a); ok {
+		if nb, ok := sortKeyNumber(b); ok {
+			return cmp.Compare(na, nb)
+		}
+	}
+
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func transformResultValue(cmd *cobra.Command, value any) (any, error) {
+	t, err := newResultTransform(getFilterFlag(cmd), getSortByFlag(cmd), getFieldsFlag(cmd))
+	if err != nil {
+		return nil, err
+	}
+	if t.isEmpty() {
+		return value, nil
+	}
+	return t.apply(value)
+}
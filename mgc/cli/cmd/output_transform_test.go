@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"magalu.cloud/core"
+)
+
+func TestResultTransform(t *testing.T) {
+	list := func() any {
+		return map[string]any{
+			"instances": []any{
+				map[string]any{"id": "1", "name": "web-02", "status": "running", "machine": map[string]any{"vcpus": float64(4)}},
+				map[string]any{"id": "2", "name": "db-01", "status": "stopped", "machine": map[string]any{"vcpus": float64(8)}},
+				map[string]any{"id": "3", "name": "web-01", "status": "running", "machine": map[string]any{"vcpus": float64(2)}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		filter   string
+		sortBy   string
+		fields   []string
+		value    any
+		expected any
+	}{
+		{
+			name:   "filter",
+			filter: `$.status == "running"`,
+			fields: []string{"id"},
+			value:  list(),
+			expected: map[string]any{"instances": []any{
+				map[string]any{"id": "1"},
+				map[string]any{"id": "3"},
+			}},
+		},
+		{
+			name:   "filter function",
+			filter: `startsWith($.name, "db")`,
+			fields: []string{"id"},
+			value:  list(),
+			expected: map[string]any{"instances": []any{
+				map[string]any{"id": "2"},
+			}},
+		},
+		{
+			name:   "sort by number descending without prefix",
+			sortBy: "-machine.vcpus",
+			fields: []string{"id", "machine.vcpus"},
+			value:  list(),
+			expected: map[string]any{"instances": []any{
+				map[string]any{"id": "2", "machine": map[string]any{"vcpus": float64(8)}},
+				map[string]any{"id": "1", "machine": map[string]any{"vcpus": float64(4)}},
+				map[string]any{"id": "3", "machine": map[string]any{"vcpus": float64(2)}},
+			}},
+		},
+		{
+			name:   "sort by string on plain array",
+			sortBy: "$.name",
+			fields: []string{"name"},
+			value:  list().(map[string]any)["instances"],
+			expected: []any{
+				map[string]any{"name": "db-01"},
+				map[string]any{"name": "web-01"},
+				map[string]any{"name": "web-02"},
+			},
+		},
+		{
+			name:     "fields of object",
+			fields:   []string{"id", "missing"},
+			value:    map[string]any{"id": "1", "name": "web-01"},
+			expected: map[string]any{"id": "1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			transform, err := newResultTransform(tc.filter, tc.sortBy, tc.fields)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			result, err := transform.apply(tc.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tc.expected, result) {
+				t.Errorf("expected %#v, got %#v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestResultTransformInvalidExpression(t *testing.T) {
+	if _, err := newResultTransform("$.status ==", "", nil); !errors.As(err, &core.UsageError{}) {
+		t.Errorf("expected usage error for invalid filter, got %v", err)
+	}
+}
+
+func TestResultTransformUsageErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		filter string
+		sortBy string
+		value  any
+	}{
+		{
+			name:   "filter evaluation error",
+			filter: `$.machine.vcpus > 2`,
+			value:  []any{map[string]any{"machine": map[string]any{"vcpus": float64(4)}}, map[string]any{"machine": "BV1-1-10"}},
+		},
+		{
+			name:   "filter on object",
+			filter: `$.status == "running"`,
+			value:  map[string]any{"id": "1", "status": "running"},
+		},
+		{
+			name:   "sort by on object",
+			sortBy: "name",
+			value:  map[string]any{"id": "1", "name": "web-01"},
+		},
+		{
+			name:   "filter on scalar",
+			filter: `$.status == "running"`,
+			value:  "running",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			transform, err := newResultTransform(tc.filter, tc.sortBy, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err = transform.apply(tc.value); !errors.As(err, &core.UsageError{}) {
+				t.Errorf("expected usage error, got %v", err)
+			}
+		})
+	}
+}
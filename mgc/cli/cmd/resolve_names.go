@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"magalu.cloud/cli/cmd/schema_flags"
+	"magalu.cloud/core"
+	mgcSchemaPkg "magalu.cloud/core/schema"
+	"magalu.cloud/core/utils"
+)
+
+const (
+	resourceNamePrefix     = "name:"
+	resourceNameMaxPages   = 20
+	resourceLimitParam     = "_limit"
+	resourceOffsetParam    = "_offset"
+	resourceUUIDFormat     = "uuid"
+	resourceDefaultNameKey = "name"
+	resourceIDParam        = "id"
+	resourceIDSuffix       = "_id"
+)
+
+var uuidRe = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+type resourceNameError struct {
+	Name    string
+	List    string // command used to look for the name
+	Matches []string
+}
+
+var _ error = (*resourceNameError)(nil)
+
+func (e *resourceNameError) Error() string {
+	if len(e.Matches) == 0 {
+		return fmt.Sprintf("no resource named %q found by %q", e.Name, e.List)
+	}
+	return fmt.Sprintf(
+		"%d resources named %q found by %q: %s. Use one of the IDs instead",
+		len(e.Matches),
+		e.Name,
+		e.List,
+		strings.Join(e.Matches, ", "),
+	)
+}
+
+// isIDParameter tells whether the parameter holds resource IDs, that may be given as names
+func isIDParameter(propName string, schema *mgcSchemaPkg.Schema) bool {
+	return schema.Format == resourceUUIDFormat || propName == resourceIDParam || strings.HasSuffix(propName, resourceIDSuffix)
+}
+
+// resourceNameFromValue returns the resource name to be resolved, given to an ID
+// parameter either as "name:<name>" or as a plain value when an UUID is expected.
+// Values of other parameters are never names to be resolved.
+func resourceNameFromValue(value string, propName string, schema *mgcSchemaPkg.Schema) (name string, explicit bool, ok bool) {
+	if !isIDParameter(propName, schema) {
+		return "", false, false
+	}
+	if name, ok = strings.CutPrefix(value, resourceNamePrefix); ok {
+		return name, true, true
+	}
+	if schema.Format == resourceUUIDFormat && value != "" && !uuidRe.MatchString(value) {
+		return value, false, true
+	}
+	return "", false, false
+}
+
+func toInt(value any) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
+
+// findByName lists the resources, going through the pages when the list has
+// "_limit" and "_offset" parameters, and returns the IDs of those named name.
+func (c *resourceCompleter) findByName(cf *cmdFlags, list resourceList, name string) (ids []string, err error) {
+	parameters := completionParameters(list.exec, core.Parameters{})
+	configs := c.configs(cf, list.exec)
+
+	nameKeys := []string{resourceDefaultNameKey}
+	if humanExec, ok := core.ExecutorAs[core.HumanIdentifiableFieldsExecutor](list.exec); ok && len(humanExec.HumanIdentifiableFields()) > 0 {
+		nameKeys = humanExec.HumanIdentifiableFields()
+	}
+
+	limit, paged := toInt(parameters[resourceLimitParam])
+	paged = paged && limit > 0 && list.exec.ParametersSchema().Properties[resourceOffsetParam] != nil
+
+	ctx := c.sdk.NewContext()
+	for page := 0; page < resourceNameMaxPages; page++ {
+		if paged {
+			parameters[resourceOffsetParam] = page * limit
+		}
+
+		var result core.Result
+		result, err = list.exec.Execute(ctx, parameters, configs)
+		if err != nil {
+			return
+		}
+
+		resultWithValue, ok := core.ResultAs[core.ResultWithValue](result)
+		if !ok {
+			return nil, fmt.Errorf("list returned no value")
+		}
+
+		items, ok := listItems(resultWithValue.Value())
+		if !ok {
+			return nil, fmt.Errorf("list expected to return array, got %T instead", resultWithValue.Value())
+		}
+
+		for _, item := range items {
+			m, ok := item.(map[string]any)
+			if !ok || m[list.field] == nil {
+				continue
+			}
+			for _, k := range nameKeys {
+				if k != list.field && m[k] != nil && fmt.Sprint(m[k]) == name {
+					ids = append(ids, fmt.Sprint(m[list.field]))
+					break
+				}
+			}
+		}
+
+		if !paged || len(items) < limit {
+			return
+		}
+	}
+
+	return
+}
+
+// resolveNames replaces the resource names given to ID parameters, as
+// "name:web-01" or as a plain "web-01" when an UUID is expected, by the ID
+// of the single resource with that name.
+func (c *resourceCompleter) resolveNames(cf *cmdFlags, cmd *cobra.Command, parameters core.Parameters) error {
+	var errs utils.MultiError
+	var groups []core.Grouper
+	var paths [][]string
+
+	for _, f := range cf.schemaFlags {
+		desc := f.Value.(schema_flags.SchemaFlagValue).Desc()
+		if desc.IsConfig {
+			continue
+		}
+
+		value, ok := parameters[desc.PropName].(string)
+		if !ok {
+			continue
+		}
+
+		name, explicit, ok := resourceNameFromValue(value, desc.PropName, desc.Schema)
+		if !ok {
+			continue
+		}
+
+		if groups == nil {
+			groups, paths = c.commandGroups(cmd)
+		}
+
+		list, ok := findResourceList(groups, paths, desc.PropName, desc.Schema)
+		if !ok {
+			if explicit {
+				errs = append(errs, &flagError{Flag: f, Err: fmt.Errorf("unable to find how to list resources to resolve the name %q", name)})
+			}
+			continue
+		}
+
+		ids, err := c.findByName(cf, list, name)
+		if err != nil {
+			errs = append(errs, &flagError{Flag: f, Err: fmt.Errorf("unable to resolve the name %q: %w", name, err)})
+			continue
+		}
+
+		if len(ids) != 1 {
+			errs = append(errs, &flagError{Flag: f, Err: &resourceNameError{name, strings.Join(list.path, " "), ids}})
+			continue
+		}
+
+		logger().Debugw("resolved resource name", "flag", f.Name, "name", name, "id", ids[0])
+		parameters[desc.PropName] = ids[0]
+	}
+
+	if len(errs) > 0 {
+		return core.UsageError{Err: errs}
+	}
+	return nil
+}
+
+func (cf *cmdFlags) resolveResourceNames(cmd *cobra.Command, parameters core.Parameters) error {
+	if cf.completer == nil {
+		return nil
+	}
+	return cf.completer.resolveNames(cf, cmd, parameters)
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"testing"
+
+	mgcSchemaPkg "magalu.cloud/core/schema"
+)
+
+func Test_resourceNameFromValue(t *testing.T) {
+	uuidSchema := &mgcSchemaPkg.Schema{Type: "string", Format: "uuid"}
+	stringSchema := mgcSchemaPkg.NewStringSchema()
+
+	type testCase struct {
+		name     string
+		value    string
+		propName string
+		schema   *mgcSchemaPkg.Schema
+		resolved string
+		explicit bool
+		ok       bool
+	}
+
+	testCases := []testCase{
+		{"explicit name to id", "name:web-01", "id", stringSchema, "web-01", true, true},
+		{"explicit name to _id", "name:web-01", "instance_id", stringSchema, "web-01", true, true},
+		{"explicit name to uuid", "name:web-01", "instance", uuidSchema, "web-01", true, true},
+		{"plain name to uuid", "web-01", "instance", uuidSchema, "web-01", false, true},
+		{"uuid", "0b8f5c6e-7d2a-4f1e-9c3b-2a1d0e9f8c7b", "id", uuidSchema, "", false, false},
+		{"plain string", "web-01", "id", stringSchema, "", false, false},
+		{"empty", "", "id", uuidSchema, "", false, false},
+		{"name prefix to non-id", "name:prod", "label", stringSchema, "", false, false},
+		{"name prefix to name", "name:web", "name", stringSchema, "", false, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			name, explicit, ok := resourceNameFromValue(tc.value, tc.propName, tc.schema)
+			if ok != tc.ok || explicit != tc.explicit {
+				t.Fatalf("expected ok=%v explicit=%v, got ok=%v explicit=%v", tc.ok, tc.explicit, ok, explicit)
+			}
+			checkExpectedString(t, "name", tc.resolved, name)
+		})
+	}
+}
+
+func Test_resourceNameError(t *testing.T) {
+	err := &resourceNameError{Name: "web-01", List: "virtual-machine instances list"}
+	checkExpectedString(t, "not found", `no resource named "web-01" found by "virtual-machine instances list"`, err.Error())
+
+	err.Matches = []string{"id-1", "id-2"}
+	checkExpectedString(
+		t,
+		"ambiguous",
+		`2 resources named "web-01" found by "virtual-machine instances list": id-1, id-2. Use one of the IDs instead`,
+		err.Error(),
+	)
+}
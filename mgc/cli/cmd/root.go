@@ -79,51 +79,34 @@ It allows you to interact with the Magalu Cloud to manage your resources.
 	rootCmd.SetCompletionCommandGroupID("other")
 	configureOutputColor(rootCmd, nil)
 	addOutputFlag(rootCmd)
+	addOutputTransformFlags(rootCmd)
 	addLogFilterFlag(rootCmd, getLogFilterConfig(sdk))
 	addLogDebugFlag(rootCmd)
 	addTimeoutFlag(rootCmd)
 	addWaitTerminationFlag(rootCmd)
 	addRetryUntilFlag(rootCmd)
 	addBypassConfirmationFlag(rootCmd)
+	addDryRunFlag(rootCmd)
+	addInteractiveFlag(rootCmd)
 	addShowInternalFlag(rootCmd)
 	addShowHiddenFlag(rootCmd)
 	addRawOutputFlag(rootCmd)
 	addApiKeyFlag(rootCmd)
+	addWorkspaceFlag(rootCmd)
 
 	rootCmd.InitDefaultHelpFlag()
 	rootCmd.InitDefaultVersionFlag()
 
 	// Immediately parse flags for root command because we'll access the global flags prior
 	// to calling Execute (which is when Cobra parses the flags)
-	args := argParser.MainArgs()
-	// This loop will parse flags even if unknown flag error arises.
-	// A flag error means that ParseFlags will early return and not parse the rest of the args.
-	// This happens because some flags aren't available until further down the code.
-	for {
-		err = rootCmd.ParseFlags(args)
-		// Either we parsed all the flags or there are no more args to parse
-		if err == nil || len(args) == 0 {
-			break
-		}
+	parseRootFlags(rootCmd, argParser.MainArgs())
 
-		if strings.HasPrefix(err.Error(), "flag needs an argument:") {
-			break
-		}
+	defer func() {
+		err = reportJSONError(os.Stderr, err, getErrorOutput(sdk, rootCmd))
+	}()
 
-		flag, found := strings.CutPrefix(err.Error(), "unknown flag: ")
-		if found && len(flag) > 0 {
-			skipTo := slices.IndexFunc(args, func(arg string) bool {
-				return strings.Split(arg, "=")[0] == flag
-			})
-			args = args[skipTo+1:]
-			continue
-		}
-		flag, found = strings.CutPrefix(err.Error(), "unknown shorthand flag: ")
-		if found && len(flag) > 0 {
-			flag = getLastFlag(flag)
-			skipTo := slices.Index(args, flag)
-			args = args[skipTo+1:]
-		}
+	if err = useWorkspace(rootCmd, sdk); err != nil {
+		return err
 	}
 
 	if hasOutputFormatHelp(rootCmd) {
@@ -135,6 +118,18 @@ It allows you to interact with the Magalu Cloud to manage your resources.
 	}
 
 	rootCmd.AddCommand(newDumpTreeCmd(sdk))
+	rootCmd.AddCommand(newExportCatalogCmd(sdk))
+	rootCmd.AddCommand(newAliasCmd(sdk))
+	rootCmd.AddCommand(newBatchCmd(sdk))
+	rootCmd.AddCommand(newBlueprintCmd(sdk))
+
+	// user aliases expand to other commands and their flags
+	if args, ok, aliasErr := expandUserAlias(sdk, rootCmd, argParser.MainArgs()); aliasErr != nil {
+		return aliasErr
+	} else if ok {
+		argParser.SetMainArgs(args)
+		parseRootFlags(rootCmd, args)
+	}
 
 	mainArgs := argParser.MainArgs()
 
@@ -159,15 +154,49 @@ It allows you to interact with the Magalu Cloud to manage your resources.
 	setApiKey(rootCmd, sdk)
 	setKeyPair(sdk)
 
+	setUsageErrors(rootCmd)
 	err = rootCmd.Execute()
 	if err == nil && loadErr != nil {
 		err = loadErr
 	}
 
-	err = showHelpForError(rootCmd, mainArgs, err) // since we SilenceUsage and SilenceErrors
+	jsonErrors := isJSONErrorOutput(getErrorOutput(sdk, rootCmd))
+	err = showHelpForError(rootCmd, mainArgs, err, jsonErrors) // since we SilenceUsage and SilenceErrors
 	return err
 }
 
+// parseRootFlags parses the global flags even if unknown flag errors arise.
+// A flag error means that ParseFlags will early return and not parse the rest of the args.
+// This happens because some flags aren't available until further down the code.
+func parseRootFlags(rootCmd *cobra.Command, args []string) {
+	for {
+		err := rootCmd.ParseFlags(args)
+		// Either we parsed all the flags or there are no more args to parse
+		if err == nil || len(args) == 0 {
+			break
+		}
+
+		if strings.HasPrefix(err.Error(), "flag needs an argument:") {
+			break
+		}
+
+		flag, found := strings.CutPrefix(err.Error(), "unknown flag: ")
+		if found && len(flag) > 0 {
+			skipTo := slices.IndexFunc(args, func(arg string) bool {
+				return strings.Split(arg, "=")[0] == flag
+			})
+			args = args[skipTo+1:]
+			continue
+		}
+		flag, found = strings.CutPrefix(err.Error(), "unknown shorthand flag: ")
+		if found && len(flag) > 0 {
+			flag = getLastFlag(flag)
+			skipTo := slices.Index(args, flag)
+			args = args[skipTo+1:]
+		}
+	}
+}
+
 func setKeyPair(sdk *mgcSdk.Sdk) {
 	objId := os.Getenv("MGC_OBJ_KEY_ID")
 	objKey := os.Getenv("MGC_OBJ_KEY_SECRET")
@@ -466,6 +466,7 @@ func addSelectHelperCommand(sdk *mgcSdk.Sdk, parentCmd *cobra.Command, setExec,
 	if err != nil {
 		return
 	}
+	listFlags.completer = newResourceCompleter(sdk)
 
 	setExecNameSuffix := setExec.Name()[len(setExecNamePrefix):]
 	selectName, selectAliases := getCommandNameAndAliases(selectExecNamePrefix + setExecNameSuffix)
@@ -15,30 +15,38 @@ import (
 	"magalu.cloud/cli/cmd/schema_flags"
 	"magalu.cloud/core"
 	mgcSchemaPkg "magalu.cloud/core/schema"
+	"magalu.cloud/core/utils"
 )
 
-func showFormatHelp() {
+func newHelpTableWriter(firstColumnWidth int) table.Writer {
 	writer := table.NewWriter()
-	writer.AppendHeader(table.Row{"Formatter", "Description"})
-	maxLen := 0
-	for k, f := range outputFormatters {
-		if maxLen < len(k) {
-			maxLen = len(k)
-		}
-		writer.AppendRow(table.Row{k, f.Description()})
-	}
-
 	termColumns := getTermColumns()
 	tablePadding := 4 // 2 columns x 2 spaces per column
 	writer.SetColumnConfigs([]table.ColumnConfig{
-		{Number: 1, Align: text.AlignLeft, VAlign: text.VAlignTop, WidthMax: maxLen},
-		{Number: 2, Align: text.AlignLeft, VAlign: text.VAlignTop, WidthMax: termColumns - maxLen - tablePadding},
+		{Number: 1, Align: text.AlignLeft, VAlign: text.VAlignTop, WidthMax: firstColumnWidth},
+		{Number: 2, Align: text.AlignLeft, VAlign: text.VAlignTop, WidthMax: termColumns - firstColumnWidth - tablePadding},
 	})
 	style := table.StyleDefault
 	style.Options = table.OptionsNoBordersAndSeparators
 	style.Options.SeparateHeader = true
 
 	writer.SetStyle(style)
+	return writer
+}
+
+func showFormatHelp() {
+	maxLen := 0
+	for k := range outputFormatters {
+		if maxLen < len(k) {
+			maxLen = len(k)
+		}
+	}
+
+	writer := newHelpTableWriter(maxLen)
+	writer.AppendHeader(table.Row{"Formatter", "Description"})
+	for k, f := range outputFormatters {
+		writer.AppendRow(table.Row{k, f.Description()})
+	}
 	writer.SortBy([]table.SortBy{{Name: "Formatter", Mode: table.Asc}})
 
 	fmt.Println("For plain data types, the following values are accepted:")
@@ -46,14 +54,37 @@ func showFormatHelp() {
 	fmt.Println(writer.Render())
 
 	fmt.Println("\nFor streams, use the file name to save to or '-' to write to stdout (default).")
+
+	showTemplateFuncsHelp()
+}
+
+func showTemplateFuncsHelp() {
+	funcs := utils.TemplateFuncs()
+	maxLen := 0
+	for _, f := range funcs {
+		if maxLen < len(f.Usage) {
+			maxLen = len(f.Usage)
+		}
+	}
+
+	writer := newHelpTableWriter(maxLen)
+	writer.AppendHeader(table.Row{"Function", "Description"})
+	for _, f := range funcs {
+		writer.AppendRow(table.Row{f.Usage, f.Description})
+	}
+
+	fmt.Println("\nTemplates, such as in 'template' and 'template-file', may also use the following functions." +
+		" The last argument may be given with a pipeline, as in '{{ .name | default \"-\" | upper }}':")
+
+	fmt.Println(writer.Render())
 }
 
-func showHelpForError(cmd *cobra.Command, args []string, err error) error {
+func showHelpForError(cmd *cobra.Command, args []string, err error, jsonOutput bool) error {
 	switch {
 	case err == schema_flags.ErrWantHelp:
 		return nil
 
-	case errors.As(err, new(core.UsageError)):
+	case errors.As(err, new(core.UsageError)) && !jsonOutput:
 		// we can't call UsageString() on the root, we need to find the actual leaf command that failed:
 		subCmd, _, _ := cmd.Find(args)
 		cmd.PrintErrln(subCmd.UsageString())
@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"magalu.cloud/core"
+	mgcConfigPkg "magalu.cloud/core/config"
+	mgcSdk "magalu.cloud/sdk"
+)
+
+const (
+	userAliasesConfigKey = "aliases"
+	userAliasAllArgs     = "$@"
+	userAliasListOutput  = "table=NAME:$[*].name,COMMAND:$[*].command"
+)
+
+var (
+	userAliasNameRe        = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+	userAliasPlaceholderRe = regexp.MustCompile(`\$(\d+)`)
+)
+
+// cobra commands that are not in the SDK tree, but can't be shadowed by aliases
+var reservedRootCommands = []string{
+	"help",
+	"completion",
+	"__complete",
+	"__completeNoDesc",
+}
+
+// readUserAliases returns the aliases of the current workspace, mapping the
+// alias name to the command line it expands to.
+func readUserAliases(config *mgcConfigPkg.Config) (map[string]string, error) {
+	// the config decoder only unmarshals the stored YAML into untyped values
+	var value any
+	if err := config.Get(userAliasesConfigKey, &value); err != nil {
+		return nil, fmt.Errorf("unable to read aliases: %w", err)
+	}
+
+	aliases := map[string]string{}
+	if value == nil {
+		return aliases, nil
+	}
+
+	m, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unable to read aliases: expected a map, got %T", value)
+	}
+	for name, line := range m {
+		aliases[name] = fmt.Sprint(line)
+	}
+	return aliases, nil
+}
+
+func writeUserAliases(config *mgcConfigPkg.Config, aliases map[string]string) error {
+	if len(aliases) == 0 {
+		return config.Delete(userAliasesConfigKey)
+	}
+	return config.Set(userAliasesConfigKey, aliases)
+}
+
+// splitAliasCommand splits the command line like a shell would, handling
+// single quotes, double quotes and backslash escapes.
+func splitAliasCommand(s string) (args []string, err error) {
+	var current strings.Builder
+	var quote rune
+	inArg := false
+	escaped := false
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote in %q", quote, s)
+	}
+	if escaped {
+		return nil, fmt.Errorf("unterminated escape in %q", s)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// joinAliasCommand is the inverse of splitAliasCommand()
+func joinAliasCommand(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg != "" && !strings.ContainsAny(arg, " \t\n'\"\\") {
+			quoted[i] = arg
+			continue
+		}
+		quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
+
+// expandAliasArgs replaces "$1", "$2"... in the alias command by the
+// arguments given after the alias, and "$@" by all of them. Arguments that
+// are not referenced are appended to the command.
+func expandAliasArgs(name string, command []string, args []string) ([]string, error) {
+	used := make([]bool, len(args))
+	result := make([]string, 0, len(command)+len(args))
+	var err error
+
+	for _, arg := range command {
+		if arg == userAliasAllArgs {
+			result = append(result, args...)
+			for i := range used {
+				used[i] = true
+			}
+			continue
+		}
+
+		arg = userAliasPlaceholderRe.ReplaceAllStringFunc(arg, func(placeholder string) string {
+			n, _ := strconv.Atoi(placeholder[1:])
+			if n < 1 || n > len(args) {
+				if err == nil {
+					err = core.UsageError{Err: fmt.Errorf("alias %q expects at least %d arguments, got %d", name, n, len(args))}
+				}
+				return placeholder
+			}
+			used[n-1] = true
+			return args[n-1]
+		})
+		result = append(result, arg)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, arg := range args {
+		if !used[i] {
+			result = append(result, arg)
+		}
+	}
+	return result, nil
+}
+
+// isRootCommandName checks whether name is a command, or one of its aliases,
+// shipped with the CLI. These always take precedence over user aliases.
+func isRootCommandName(sdk *mgcSdk.Sdk, rootCmd *cobra.Command, name string) bool {
+	if slices.Contains(reservedRootCommands, name) || isExistingCommand(rootCmd, name) {
+		return true
+	}
+	_, err := findChildByNameOrAliases(sdk.Group(), name)
+	return err == nil
+}
+
+// expandUserAlias replaces the command name in args by the command line of the
+// user alias with that name, if any.
+func expandUserAlias(sdk *mgcSdk.Sdk, rootCmd *cobra.Command, args []string) (expanded []string, ok bool, err error) {
+	name, rest := getNextUnknownCommand(rootCmd, args)
+	if name == nil {
+		return args, false, nil
+	}
+
+	aliases, err := readUserAliases(sdk.Config())
+	if err != nil {
+		logger().Debugw("ignoring user aliases", "error", err)
+		return args, false, nil
+	}
+
+	line, ok := aliases[*name]
+	if !ok || isRootCommandName(sdk, rootCmd, *name) {
+		return args, false, nil
+	}
+
+	command, err := splitAliasCommand(line)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid alias %q: %w", *name, err)
+	}
+
+	command, err = expandAliasArgs(*name, command, rest)
+	if err != nil {
+		return nil, false, err
+	}
+
+	prefix := args[:len(args)-len(rest)-1]
+	expanded = append(slices.Clone(prefix), command...)
+	logger().Debugw("expanded user alias", "alias", *name, "args", expanded)
+	return expanded, true, nil
+}
+
+func newAliasSetCmd(sdk *mgcSdk.Sdk) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set [name] [command...]",
+		Short: "Create or replace an alias in the current workspace",
+		Long: `Create or replace an alias in the current workspace.
+
+The alias may be a simple rename of a command or a macro with arguments: "$1",
+"$2"... are replaced by the arguments given after the alias and "$@" by all of
+them. Arguments that are not referenced are appended to the command.
+
+Quote the command or give it after "--", so its flags are not taken as flags of
+"alias set".`,
+		Example: `  mgc alias set vmls 'virtual-machine instances list -o table=ID:$.instances[*].id,NAME:$.instances[*].name'
+  mgc alias set vmget -- virtual-machine instances get --id=$1`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if !userAliasNameRe.MatchString(name) {
+				return core.UsageError{Err: fmt.Errorf("invalid alias name %q, use letters, digits, '-' and '_'", name)}
+			}
+			if isRootCommandName(sdk, cmd.Root(), name) {
+				return core.UsageError{Err: fmt.Errorf("alias %q conflicts with an existing command or its alias", name)}
+			}
+
+			command := args[1:]
+			if len(command) == 1 {
+				var err error
+				if command, err = splitAliasCommand(command[0]); err != nil {
+					return core.UsageError{Err: err}
+				}
+			}
+			if len(command) == 0 {
+				return core.UsageError{Err: fmt.Errorf("alias %q has an empty command", name)}
+			}
+			if command[0] == name {
+				return core.UsageError{Err: fmt.Errorf("alias %q can't expand to itself", name)}
+			}
+
+			config := sdk.Config()
+			aliases, err := readUserAliases(config)
+			if err != nil {
+				return err
+			}
+			aliases[name] = joinAliasCommand(command)
+			return writeUserAliases(config, aliases)
+		},
+	}
+}
+
+func newAliasListCmd(sdk *mgcSdk.Sdk) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the aliases of the current workspace",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			aliases, err := readUserAliases(sdk.Config())
+			if err != nil {
+				return err
+			}
+
+			names := make([]string, 0, len(aliases))
+			for name := range aliases {
+				names = append(names, name)
+			}
+			slices.Sort(names)
+
+			result := make([]any, len(names))
+			for i, name := range names {
+				result[i] = map[string]any{"name": name, "command": aliases[name]}
+			}
+
+			output := getOutputFlag(cmd)
+			if output == "" {
+				output = userAliasListOutput
+			}
+			return handleSimpleResultValue(result, output)
+		},
+	}
+}
+
+func newAliasDeleteCmd(sdk *mgcSdk.Sdk) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete [name]",
+		Short: "Delete an alias from the current workspace",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := sdk.Config()
+			aliases, err := readUserAliases(config)
+			if err != nil {
+				return err
+			}
+			if _, ok := aliases[args[0]]; !ok {
+				return core.UsageError{Err: fmt.Errorf("no alias named %q", args[0])}
+			}
+			delete(aliases, args[0])
+			return writeUserAliases(config, aliases)
+		},
+	}
+}
+
+func newAliasCmd(sdk *mgcSdk.Sdk) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "alias",
+		Short:   "Manage command aliases of the current workspace",
+		GroupID: "settings",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newAliasSetCmd(sdk), newAliasListCmd(sdk), newAliasDeleteCmd(sdk))
+	return cmd
+}
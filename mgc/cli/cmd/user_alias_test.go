@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"testing"
+
+	mgcConfigPkg "magalu.cloud/core/config"
+	"magalu.cloud/core/profile_manager"
+)
+
+func Test_splitAliasCommand(t *testing.T) {
+	type testCase struct {
+		name     string
+		line     string
+		expected []string
+		err      bool
+	}
+
+	testCases := []testCase{
+		{"simple", "virtual-machine instances list", []string{"virtual-machine", "instances", "list"}, false},
+		{"extra spaces", "  vm   list ", []string{"vm", "list"}, false},
+		{"single quotes", `vm list -o 'table=ID:$.id, NAME:$.name'`, []string{"vm", "list", "-o", "table=ID:$.id, NAME:$.name"}, false},
+		{"double quotes", `vm get --name="web 01"`, []string{"vm", "get", "--name=web 01"}, false},
+		{"escape", `vm get --name=web\ 01`, []string{"vm", "get", "--name=web 01"}, false},
+		{"empty quotes", `vm get ''`, []string{"vm", "get", ""}, false},
+		{"unterminated quote", `vm get 'web`, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := splitAliasCommand(tc.line)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			checkError(t, "splitAliasCommand", nil, err)
+			checkExpectedArray(t, "args", tc.expected, got)
+
+			roundTrip, err := splitAliasCommand(joinAliasCommand(got))
+			checkError(t, "joinAliasCommand", nil, err)
+			checkExpectedArray(t, "round trip", got, roundTrip)
+		})
+	}
+}
+
+func Test_expandAliasArgs(t *testing.T) {
+	type testCase struct {
+		name     string
+		command  []string
+		args     []string
+		expected []string
+		err      bool
+	}
+
+	testCases := []testCase{
+		{"rename", []string{"virtual-machine"}, []string{"instances", "list"}, []string{"virtual-machine", "instances", "list"}, false},
+		{"placeholders", []string{"vm", "get", "--id=$1"}, []string{"abc", "-o", "json"}, []string{"vm", "get", "--id=abc", "-o", "json"}, false},
+		{"reordered placeholders", []string{"cp", "$2", "$1"}, []string{"a", "b"}, []string{"cp", "b", "a"}, false},
+		{"all args", []string{"vm", "$@", "--raw"}, []string{"a", "b"}, []string{"vm", "a", "b", "--raw"}, false},
+		{"missing argument", []string{"vm", "get", "--id=$2"}, []string{"abc"}, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := expandAliasArgs("test", tc.command, tc.args)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			checkError(t, "expandAliasArgs", nil, err)
+			checkExpectedArray(t, "args", tc.expected, got)
+		})
+	}
+}
+
+func Test_userAliasesConfig(t *testing.T) {
+	m, _ := profile_manager.NewInMemoryProfileManager()
+	config := mgcConfigPkg.New(m)
+
+	aliases, err := readUserAliases(config)
+	checkError(t, "readUserAliases", nil, err)
+	if len(aliases) != 0 {
+		t.Fatalf("expected no aliases, got %v", aliases)
+	}
+
+	aliases["vmls"] = "virtual-machine instances list -o 'table=ID:$.id'"
+	err = writeUserAliases(config, aliases)
+	checkError(t, "writeUserAliases", nil, err)
+
+	got, err := readUserAliases(mgcConfigPkg.New(m))
+	checkError(t, "readUserAliases", nil, err)
+	checkExpectedString(t, "vmls", aliases["vmls"], got["vmls"])
+
+	err = writeUserAliases(config, map[string]string{})
+	checkError(t, "writeUserAliases", nil, err)
+
+	got, err = readUserAliases(mgcConfigPkg.New(m))
+	checkError(t, "readUserAliases", nil, err)
+	if len(got) != 0 {
+		t.Errorf("expected aliases to be deleted, got %v", got)
+	}
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"magalu.cloud/core/profile_manager"
+	mgcSdk "magalu.cloud/sdk"
+)
+
+const workspaceFlag = "workspace"
+
+func addWorkspaceFlag(cmd *cobra.Command) {
+	cmd.Root().PersistentFlags().String(
+		workspaceFlag,
+		os.Getenv(profile_manager.WorkspaceEnvVar),
+		`Use the given workspace for this execution only, without changing the current workspace.
+Can be set with environment variable `+profile_manager.WorkspaceEnvVar,
+	)
+}
+
+func getWorkspaceFlag(cmd *cobra.Command) string {
+	workspace, err := cmd.Root().PersistentFlags().GetString(workspaceFlag)
+	if err != nil {
+		return ""
+	}
+	return workspace
+}
+
+// useWorkspace binds the SDK to the workspace given by the flag or environment
+// variable. Flag defaults loaded from the previous workspace config are reloaded.
+func useWorkspace(cmd *cobra.Command, sdk *mgcSdk.Sdk) error {
+	name := getWorkspaceFlag(cmd)
+	if name == "" {
+		// the environment variable may still be pinned, report it if invalid
+		_, err := sdk.ProfileManager().CurrentE()
+		return err
+	}
+
+	if err := sdk.UseWorkspace(name); err != nil {
+		return err
+	}
+
+	if f := cmd.Root().PersistentFlags().Lookup(logFilterFlag); f != nil && !f.Changed {
+		if def := getLogFilterConfig(sdk); def != "" {
+			f.DefValue = def
+			_ = f.Value.Set(def)
+		}
+	}
+	return nil
+}
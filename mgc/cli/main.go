@@ -16,8 +16,10 @@ func main() {
 
 	err := cmd.Execute()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		os.Exit(1)
+		if !cmd.IsErrorReported(err) {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		}
+		os.Exit(cmd.ExitCode(err))
 	}
 }
 
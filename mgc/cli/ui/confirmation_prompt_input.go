@@ -12,3 +12,9 @@ func RunPromptInput(message string) (string, error) {
 	}
 	return ready, nil
 }
+
+func RunPromptInputWithInitialValue(message string, initialValue string) (string, error) {
+	input := textinput.New(message)
+	input.InitialValue = initialValue
+	return input.RunPrompt()
+}
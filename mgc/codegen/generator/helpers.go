@@ -26,11 +26,16 @@ var (
 	//go:embed helpers_executors.go.template
 	helpersExecutorsTemplateContents string
 	helpersExecutorsTemplate         *template.Template
+
+	//go:embed helpers_unions.go.template
+	helpersUnionsTemplateContents string
+	helpersUnionsTemplate         *template.Template
 )
 
 func init() {
 	helpersConvertersTemplate = templateMust("helpers_converters.go.template", helpersConvertersTemplateContents)
 	helpersExecutorsTemplate = templateMust("helpers_executors.go.template", helpersExecutorsTemplateContents)
+	helpersUnionsTemplate = templateMust("helpers_unions.go.template", helpersUnionsTemplateContents)
 }
 
 func generateHelpers(dirname string, sdk *mgcSdkPkg.Sdk, ctx *GeneratorContext) (err error) {
@@ -56,10 +61,20 @@ func generateHelpers(dirname string, sdk *mgcSdkPkg.Sdk, ctx *GeneratorContext)
 		return
 	}
 
-	return templateWrite(
+	err = templateWrite(
 		ctx,
 		path.Join(p, "executors.go"),
 		helpersExecutorsTemplate,
 		data,
 	)
+	if err != nil {
+		return
+	}
+
+	return templateWrite(
+		ctx,
+		path.Join(p, "unions.go"),
+		helpersUnionsTemplate,
+		data,
+	)
 }
@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"slices"
@@ -19,21 +20,33 @@ type generatorTemplateTypeField struct {
 	Comment string
 }
 
+// one of the alternatives of an union, the field holds a pointer to Type
+type generatorTemplateTypeVariant struct {
+	Name   string
+	Type   string
+	Schema string // JSON, used to select the variant when decoding
+
+	isInteger bool
+}
+
 type generatorTemplateTypeKind string
 
 var (
 	generatorTemplateTypeKindAlias  = generatorTemplateTypeKind("alias")
 	generatorTemplateTypeKindStruct = generatorTemplateTypeKind("struct")
+	generatorTemplateTypeKindUnion  = generatorTemplateTypeKind("union")
 )
 
 type generatorTemplateTypeDefinition struct {
 	Name string
 	Doc  string
-	Kind generatorTemplateTypeKind // alias or struct
+	Kind generatorTemplateTypeKind // alias, struct or union
 
 	Target string // if alias
 
 	Fields []generatorTemplateTypeField // if struct
+
+	Variants []generatorTemplateTypeVariant // if union
 }
 
 type generatorTemplateTypes struct {
@@ -69,7 +82,8 @@ func (t *generatorTemplateTypes) addSchema(name string, schema *mgcSchemaPkg.Sch
 	}
 
 	//check if OAD schema is nullable and if the property is not required
-	if schema.Nullable || !required {
+	// unions are always pointers, so they are converted as a whole and not field by field
+	if schema.Nullable || !required || t.isUnion(signature) {
 		signature = "*" + signature
 	}
 	return
@@ -181,6 +195,82 @@ func (t *generatorTemplateTypes) addArray(name string, schema *mgcSchemaPkg.Sche
 	return def.Name, nil
 }
 
+func (t *generatorTemplateTypes) isUnion(signature string) bool {
+	def := t.ByName[signature]
+	return def != nil && def.Kind == generatorTemplateTypeKindUnion
+}
+
+var variantNamesByType = map[string]string{
+	"string":  "String",
+	"integer": "Int",
+	"number":  "Float64",
+	"boolean": "Bool",
+	"array":   "Array",
+	"object":  "Object",
+}
+
+// variantName is the schema title or type, ex: String, Object
+func variantName(schema *mgcSchemaPkg.Schema) string {
+	if schema.Title != "" {
+		if name := removeChars(strcase.UpperCamelCase(schema.Title), ".-_ "); name != "" {
+			return name
+		}
+	}
+	if name, ok := variantNamesByType[schema.Type]; ok {
+		return name
+	}
+	return "Value"
+}
+
+// schemaWithoutRefs copies the schema replacing the references by their values, so it can be
+// embedded in the generated code. Recursive references accept any value.
+func schemaWithoutRefs(schema *openapi3.Schema, visiting map[*openapi3.Schema]bool) *openapi3.Schema {
+	if schema == nil {
+		return nil
+	}
+	if visiting[schema] {
+		return &openapi3.Schema{}
+	}
+	visiting[schema] = true
+	defer delete(visiting, schema)
+
+	refWithoutRefs := func(ref *openapi3.SchemaRef) *openapi3.SchemaRef {
+		if ref == nil {
+			return nil
+		}
+		return &openapi3.SchemaRef{Value: schemaWithoutRefs(ref.Value, visiting)}
+	}
+	refsWithoutRefs := func(refs openapi3.SchemaRefs) (result openapi3.SchemaRefs) {
+		for _, ref := range refs {
+			result = append(result, refWithoutRefs(ref))
+		}
+		return
+	}
+
+	c := *schema
+	// not needed to validate
+	c.Extensions = nil
+	c.Description = ""
+	c.Example = nil
+
+	c.Items = refWithoutRefs(schema.Items)
+	c.Not = refWithoutRefs(schema.Not)
+	c.OneOf = refsWithoutRefs(schema.OneOf)
+	c.AnyOf = refsWithoutRefs(schema.AnyOf)
+	c.AllOf = refsWithoutRefs(schema.AllOf)
+	c.AdditionalProperties.Schema = refWithoutRefs(schema.AdditionalProperties.Schema)
+	if schema.Properties != nil {
+		c.Properties = make(openapi3.Schemas, len(schema.Properties))
+		for k, ref := range schema.Properties {
+			c.Properties[k] = refWithoutRefs(ref)
+		}
+	}
+	return &c
+}
+
+// addAlternatives creates a tagged union: a struct with one pointer field per alternative,
+// only one of them is set. The generated UnmarshalJSON() selects the first alternative
+// whose schema matches the value, integers are tried before the other alternatives.
 func (t *generatorTemplateTypes) addAlternatives(name string, doc string, schema *mgcSchemaPkg.Schema, schemaRefs mgcSchemaPkg.SchemaRefs) (signature string, err error) {
 	var def *generatorTemplateTypeDefinition
 	if def = t.ByName[name]; def != nil {
@@ -191,13 +281,15 @@ func (t *generatorTemplateTypes) addAlternatives(name string, doc string, schema
 	}
 
 	def = &generatorTemplateTypeDefinition{
-		Name:   name,
-		Doc:    doc + ": ",
-		Kind:   generatorTemplateTypeKindAlias,
-		Target: "any",
+		Name:     name,
+		Doc:      doc + ": ",
+		Kind:     generatorTemplateTypeKindUnion,
+		Variants: make([]generatorTemplateTypeVariant, 0, len(schemaRefs)),
 	}
 	t.add(def, schema)
 
+	variantNameCount := map[string]int{}
+	variantIndexes := make([]string, 0, len(schemaRefs))
 	for i, childRef := range schemaRefs {
 		if childRef == nil || childRef.Value == nil {
 			continue
@@ -210,12 +302,49 @@ func (t *generatorTemplateTypes) addAlternatives(name string, doc string, schema
 			return
 		}
 
+		var childSchema []byte
+		childSchema, err = json.Marshal(schemaWithoutRefs(childRef.Value, map[*openapi3.Schema]bool{}))
+		if err != nil {
+			err = &utils.ChainedError{Name: k, Err: err}
+			return
+		}
+
 		if i != 0 {
 			def.Doc += ", "
 		}
 		def.Doc += childSignature
+
+		variant := generatorTemplateTypeVariant{
+			Name:      variantName((*mgcSchemaPkg.Schema)(childRef.Value)),
+			Type:      strings.TrimPrefix(childSignature, "*"),
+			Schema:    string(childSchema),
+			isInteger: childRef.Value.Type == "integer",
+		}
+		variantNameCount[variant.Name]++
+		variantIndexes = append(variantIndexes, k)
+		def.Variants = append(def.Variants, variant)
 	}
 
+	// ex: two objects are named Object0 and Object1
+	for i := range def.Variants {
+		if variantNameCount[def.Variants[i].Name] > 1 {
+			def.Variants[i].Name += variantIndexes[i]
+		}
+	}
+
+	// integers also match the number schemas, they must be tried first or they would
+	// always be decoded as numbers
+	slices.SortStableFunc(def.Variants, func(a, b generatorTemplateTypeVariant) int {
+		switch {
+		case a.isInteger == b.isInteger:
+			return 0
+		case a.isInteger:
+			return -1
+		default:
+			return 1
+		}
+	})
+
 	return def.Name, nil
 }
 
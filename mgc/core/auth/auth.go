@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -139,6 +140,13 @@ type accessTokenClaims struct {
 	ScopesStr        core.ScopesString `json:"scope"`
 }
 
+// Returned when the credentials of the current security method are missing, the user must log in
+var (
+	ErrRefreshTokenNotSet = errors.New("RefreshToken is not set")
+	ErrApiKeyNotSet       = errors.New("API Key not set")
+	ErrXTenantIDNotSet    = errors.New("x Tenant ID not set")
+)
+
 type FailedRefreshAccessToken struct {
 	Message string
 }
@@ -236,16 +244,22 @@ func (o *Auth) AccessToken(ctx context.Context) (string, error) {
 	return o.accessToken, nil
 }
 
+// HasTokens reports whether an access or refresh token is available, that is,
+// whether the user has logged in at some point.
+func (o *Auth) HasTokens() bool {
+	return o.accessToken != "" || o.refreshToken != ""
+}
+
 func (o *Auth) ApiKey(ctx context.Context) (string, error) {
 	if o.apiKey == "" {
-		return "", fmt.Errorf("API Key not set")
+		return "", ErrApiKeyNotSet
 	}
 	return o.apiKey, nil
 }
 
 func (o *Auth) XTenantID(ctx context.Context) (string, error) {
 	if o.xTenantID == "" {
-		return "", fmt.Errorf("x Tenant ID not set")
+		return "", ErrXTenantIDNotSet
 	}
 	return o.xTenantID, nil
 }
@@ -600,7 +614,7 @@ func (o *Auth) doRefreshAccessToken(ctx context.Context) (string, error) {
 
 func (o *Auth) newRefreshAccessTokenRequest(ctx context.Context) (*http.Request, error) {
 	if o.refreshToken == "" {
-		return nil, fmt.Errorf("RefreshToken is not set")
+		return nil, ErrRefreshTokenNotSet
 	}
 
 	config := o.GetConfig()
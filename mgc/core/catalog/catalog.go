@@ -0,0 +1,169 @@
+// Package catalog describes every executor of a command tree in a stable, versioned format
+// to be consumed by other tools, such as IDE plugins and documentation sites.
+//
+// Schemas are OpenAPI 3.0 Schema Objects, the JSON Schema dialect used by the SDK.
+package catalog
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	"magalu.cloud/core"
+)
+
+// Version of the catalog format. Incompatible changes increase the major version,
+// new fields increase the minor version.
+const Version = "1.0.0"
+
+type Catalog struct {
+	Version string `json:"version"`
+	// Version of the SDK that generated the catalog
+	SdkVersion string      `json:"sdkVersion,omitempty"`
+	Executors  []*Executor `json:"executors"`
+}
+
+type WaitTermination struct {
+	MaxRetries int `json:"maxRetries,omitempty"`
+	// Go duration between retries, ex: "5s"
+	Interval string `json:"interval,omitempty"`
+	// Condition that terminates the wait, one of JSON Path or Go template, evaluated on
+	// the document with the result, parameters and configs
+	JSONPathQuery string `json:"jsonPathQuery,omitempty"`
+	TemplateQuery string `json:"templateQuery,omitempty"`
+	// Condition that stops the wait with an error
+	ErrorJSONPathQuery string `json:"errorJsonPathQuery,omitempty"`
+	ErrorTemplateQuery string `json:"errorTemplateQuery,omitempty"`
+}
+
+type Link struct {
+	Name                 string       `json:"name"`
+	Description          string       `json:"description"`
+	IsInternal           bool         `json:"isInternal,omitempty"`
+	AdditionalParameters *core.Schema `json:"additionalParameters"`
+	AdditionalConfigs    *core.Schema `json:"additionalConfigs"`
+	Result               *core.Schema `json:"result"`
+	// Whether the link target is executed until it terminates
+	WaitTermination bool `json:"waitTermination,omitempty"`
+}
+
+type Related struct {
+	Name string `json:"name"`
+	// Path of the related executor, if it's in the catalog
+	Path []string `json:"path,omitempty"`
+}
+
+type Executor struct {
+	// Names of the groups from the root to the executor, then the executor name
+	Path            []string         `json:"path"`
+	Name            string           `json:"name"`
+	Version         string           `json:"version"`
+	Description     string           `json:"description"`
+	Summary         string           `json:"summary,omitempty"`
+	Observations    string           `json:"observations,omitempty"`
+	IsInternal      bool             `json:"isInternal,omitempty"`
+	Scopes          core.Scopes      `json:"scopes,omitempty"`
+	PositionalArgs  []string         `json:"positionalArgs,omitempty"`
+	HiddenFlags     []string         `json:"hiddenFlags,omitempty"`
+	Parameters      *core.Schema     `json:"parameters"`
+	Configs         *core.Schema     `json:"configs"`
+	Result          *core.Schema     `json:"result"`
+	Confirmable     bool             `json:"confirmable,omitempty"`
+	WaitTermination *WaitTermination `json:"waitTermination,omitempty"`
+	Links           []*Link          `json:"links,omitempty"`
+	Related         []*Related       `json:"related,omitempty"`
+}
+
+func newLinks(links core.Links) []*Link {
+	result := make([]*Link, 0, len(links))
+	for _, name := range slices.Sorted(maps.Keys(links)) {
+		link := links[name]
+		result = append(result, &Link{
+			Name:                 name,
+			Description:          link.Description(),
+			IsInternal:           link.IsInternal(),
+			AdditionalParameters: link.AdditionalParametersSchema(),
+			AdditionalConfigs:    link.AdditionalConfigsSchema(),
+			Result:               link.ResultSchema(),
+			WaitTermination:      link.IsTargetTerminatorExecutor(),
+		})
+	}
+	return result
+}
+
+func newExecutor(exec core.Executor, path []string) *Executor {
+	spec := exec.DescriptorSpec()
+	e := &Executor{
+		Path:           slices.Clone(path),
+		Name:           exec.Name(),
+		Version:        exec.Version(),
+		Description:    exec.Description(),
+		Summary:        exec.Summary(),
+		Observations:   spec.Observations,
+		IsInternal:     exec.IsInternal(),
+		Scopes:         exec.Scopes(),
+		PositionalArgs: exec.PositionalArgs(),
+		HiddenFlags:    exec.HiddenFlags(),
+		Parameters:     exec.ParametersSchema(),
+		Configs:        exec.ConfigsSchema(),
+		Result:         exec.ResultSchema(),
+		Links:          newLinks(exec.Links()),
+	}
+
+	if _, ok := core.ExecutorAs[core.ConfirmableExecutor](exec); ok {
+		e.Confirmable = true
+	}
+
+	if tExec, ok := core.ExecutorAs[core.TerminatorExecutorWithCheck](exec); ok {
+		e.WaitTermination = &WaitTermination{MaxRetries: tExec.MaxRetries(), Interval: tExec.Interval().String()}
+		if config := tExec.WaitTerminationConfig(); config != nil {
+			e.WaitTermination.JSONPathQuery = config.JSONPathQuery
+			e.WaitTermination.TemplateQuery = config.TemplateQuery
+			e.WaitTermination.ErrorJSONPathQuery = config.ErrorJSONPathQuery
+			e.WaitTermination.ErrorTemplateQuery = config.ErrorTemplateQuery
+		}
+	} else if _, ok := core.ExecutorAs[core.TerminatorExecutor](exec); ok {
+		e.WaitTermination = &WaitTermination{}
+	}
+
+	return e
+}
+
+// executors are compared by identity, but not all of them are comparable
+func isComparable(exec core.Executor) bool {
+	return reflect.TypeOf(exec).Comparable()
+}
+
+// New visits all executors of root with core.VisitAllExecutors and describes them.
+// Internal executors are only included if includeInternal is true.
+func New(root core.Grouper, includeInternal bool, sdkVersion string) (*Catalog, error) {
+	c := &Catalog{Version: Version, SdkVersion: sdkVersion, Executors: []*Executor{}}
+	related := map[*Executor]map[string]core.Executor{}
+	paths := map[core.Executor][]string{}
+
+	_, err := core.VisitAllExecutors(root, []string{}, includeInternal, func(exec core.Executor, path []string) (bool, error) {
+		e := newExecutor(exec, path)
+		c.Executors = append(c.Executors, e)
+		related[e] = exec.Related()
+		if isComparable(exec) {
+			paths[exec] = e.Path
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to visit all executors: %w", err)
+	}
+
+	for _, e := range c.Executors {
+		for _, name := range slices.Sorted(maps.Keys(related[e])) {
+			r := &Related{Name: name}
+			if exec := related[e][name]; exec != nil && isComparable(exec) {
+				r.Path = paths[exec]
+			}
+			e.Related = append(e.Related, r)
+		}
+	}
+
+	return c, nil
+}
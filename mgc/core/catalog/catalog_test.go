@@ -0,0 +1,144 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"magalu.cloud/core"
+	mgcSchemaPkg "magalu.cloud/core/schema"
+	"magalu.cloud/core/utils"
+)
+
+func newTestExecutor(name string, isInternal bool, related func() map[string]core.Executor) *core.SimpleExecutor {
+	return core.NewSimpleExecutor(core.ExecutorSpec{
+		DescriptorSpec:   core.DescriptorSpec{Name: name, Description: name, IsInternal: utils.BoolPtr(isInternal)},
+		ParametersSchema: mgcSchemaPkg.NewObjectSchema(map[string]*mgcSchemaPkg.Schema{"id": mgcSchemaPkg.NewStringSchema()}, []string{"id"}),
+		ConfigsSchema:    mgcSchemaPkg.NewObjectSchema(nil, nil),
+		ResultSchema:     mgcSchemaPkg.NewStringSchema(),
+		Related:          related,
+		PositionalArgs:   []string{"id"},
+		Execute: func(core.Executor, context.Context, core.Parameters, core.Configs) (core.Result, error) {
+			return nil, nil
+		},
+	})
+}
+
+func newTestGroup(name string, children ...core.Descriptor) core.Grouper {
+	return core.NewSimpleGrouper(core.DescriptorSpec{Name: name, Description: name}, func() ([]core.Descriptor, error) {
+		return children, nil
+	})
+}
+
+func newTestTree() core.Grouper {
+	var get core.Executor
+	get = newTestExecutor("get", false, nil)
+	waitTermination := core.WaitTerminationConfig{MaxRetries: 10, Interval: 5 * time.Second, JSONPathQuery: `$.result.status == "running"`}
+	get, err := waitTermination.Build(get, func(result core.ResultWithValue) any { return result.Value() })
+	if err != nil {
+		panic(err)
+	}
+	del := core.NewConfirmableExecutor(newTestExecutor("delete", false, func() map[string]core.Executor {
+		return map[string]core.Executor{"get": get}
+	}), nil)
+	internal := newTestExecutor("internal", true, nil)
+	return newTestGroup("root", newTestGroup("instances", get, del), internal)
+}
+
+func findExecutor(c *Catalog, path ...string) *Executor {
+	for _, e := range c.Executors {
+		if slices.Equal(e.Path, path) {
+			return e
+		}
+	}
+	return nil
+}
+
+func TestNew(t *testing.T) {
+	c, err := New(newTestTree(), false, "v1.0.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Version != Version || c.SdkVersion != "v1.0.0" {
+		t.Errorf("expected versions %q and %q, got %q and %q", Version, "v1.0.0", c.Version, c.SdkVersion)
+	}
+	if len(c.Executors) != 2 {
+		t.Fatalf("expected 2 executors, got %d", len(c.Executors))
+	}
+
+	get := findExecutor(c, "instances", "get")
+	if get == nil {
+		t.Fatalf("missing executor instances get")
+	}
+	expectedWait := WaitTermination{MaxRetries: 10, Interval: "5s", JSONPathQuery: `$.result.status == "running"`}
+	if get.WaitTermination == nil || *get.WaitTermination != expectedWait {
+		t.Errorf("expected wait termination %+v, got %+v", expectedWait, get.WaitTermination)
+	}
+	if get.Confirmable {
+		t.Errorf("expected instances get not to be confirmable")
+	}
+	if !slices.Equal(get.PositionalArgs, []string{"id"}) {
+		t.Errorf("expected positional args [id], got %v", get.PositionalArgs)
+	}
+	if get.Parameters == nil || get.Parameters.Properties["id"] == nil {
+		t.Errorf("expected parameters schema with id, got %v", get.Parameters)
+	}
+
+	del := findExecutor(c, "instances", "delete")
+	if del == nil {
+		t.Fatalf("missing executor instances delete")
+	}
+	if !del.Confirmable {
+		t.Errorf("expected instances delete to be confirmable")
+	}
+	if del.WaitTermination != nil {
+		t.Errorf("expected instances delete without wait termination, got %+v", del.WaitTermination)
+	}
+	if len(del.Related) != 1 || del.Related[0].Name != "get" || !slices.Equal(del.Related[0].Path, []string{"instances", "get"}) {
+		t.Errorf("expected related get at [instances get], got %+v", del.Related)
+	}
+
+	if findExecutor(c, "internal") != nil {
+		t.Errorf("expected internal executor to be excluded")
+	}
+}
+
+func TestNewIncludeInternal(t *testing.T) {
+	c, err := New(newTestTree(), true, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	internal := findExecutor(c, "internal")
+	if internal == nil || !internal.IsInternal {
+		t.Errorf("expected internal executor to be included, got %+v", internal)
+	}
+}
+
+func TestCatalogJSON(t *testing.T) {
+	c, err := New(newTestTree(), false, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded map[string]any
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded["version"] != Version {
+		t.Errorf("expected version %q, got %v", Version, decoded["version"])
+	}
+	executors, ok := decoded["executors"].([]any)
+	if !ok || len(executors) != 2 {
+		t.Fatalf("expected 2 executors, got %v", decoded["executors"])
+	}
+	parameters := executors[0].(map[string]any)["parameters"].(map[string]any)
+	if parameters["type"] != "object" {
+		t.Errorf("expected object parameters schema, got %v", parameters)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 )
 
 type FileLoader struct {
@@ -14,8 +15,25 @@ func (f FileLoader) Load(name string) ([]byte, error) {
 	return os.ReadFile(path.Join(f.Dir, name))
 }
 
+func (f FileLoader) Glob(pattern string) (names []string, err error) {
+	matches, err := filepath.Glob(filepath.Join(f.Dir, pattern))
+	if err != nil {
+		return
+	}
+	names = make([]string, len(matches))
+	for i, match := range matches {
+		name, err := filepath.Rel(f.Dir, match)
+		if err != nil {
+			return nil, err
+		}
+		names[i] = filepath.ToSlash(name)
+	}
+	return names, nil
+}
+
 func (f FileLoader) String() string {
 	return fmt.Sprintf("FileLoader(dir: %s)", f.Dir)
 }
 
 var _ Loader = (*FileLoader)(nil)
+var _ Globber = (*FileLoader)(nil)
@@ -4,3 +4,9 @@ package dataloader
 type Loader interface {
 	Load(name string) ([]byte, error)
 }
+
+// Implemented by loaders that can list the names they are able to load
+type Globber interface {
+	// Given a pattern (see path.Match), return the matching names, sorted
+	Glob(pattern string) ([]string, error)
+}
@@ -0,0 +1,31 @@
+package core
+
+import "context"
+
+// dryRunContextKey is the key for the dry-run mode in Contexts. It is
+// unexported; clients use NewDryRunContext() and IsDryRun() instead of
+// using this key directly.
+var dryRunContextKey contextKey = "magalu.cloud/core/DryRun"
+
+// NewDryRunContext enables or disables the dry-run mode. Executors that support it
+// must not change anything, but return a description of what they would do instead.
+func NewDryRunContext(parent context.Context, dryRun bool) context.Context {
+	return context.WithValue(parent, dryRunContextKey, dryRun)
+}
+
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunContextKey).(bool)
+	return dryRun
+}
+
+// Implemented by executors that honor IsDryRun(). Executors that don't implement it
+// would change things even in dry-run mode, so they must not be executed in it.
+type DryRunExecutor interface {
+	Executor
+	SupportsDryRun() bool
+}
+
+func ExecutorSupportsDryRun(exec Executor) bool {
+	dExec, ok := ExecutorAs[DryRunExecutor](exec)
+	return ok && dExec.SupportsDryRun()
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"magalu.cloud/core"
+	mgcSchemaPkg "magalu.cloud/core/schema"
+)
+
+// DryRunCredentials replaces the credentials of dry-run requests, so they're neither
+// read nor refreshed. Unlike actual credentials, it's not redacted.
+const DryRunCredentials = "<credentials>"
+
+var dryRunResultSchema = mgcSchemaPkg.NewObjectSchema(
+	map[string]*mgcSchemaPkg.Schema{
+		"method":  mgcSchemaPkg.NewStringSchema(),
+		"url":     mgcSchemaPkg.NewStringSchema(),
+		"headers": (*mgcSchemaPkg.Schema)(openapi3.NewObjectSchema().WithAnyAdditionalProperties()),
+		"body":    mgcSchemaPkg.NewAnySchema(),
+	},
+	[]string{"method", "url", "headers"},
+)
+
+// RedactedHeaders returns the headers with the sensitive values, such as Authorization,
+// replaced by their length. Unlike the logs, they are always redacted.
+func RedactedHeaders(h http.Header) map[string]any {
+	headers := make(map[string]any, len(h))
+	for key, list := range h {
+		switch {
+		case len(list) == 0:
+			continue
+		case isHeaderSensitive(key) && len(list) == 1 && strings.HasSuffix(list[0], DryRunCredentials):
+			headers[key] = list[0]
+		case isHeaderSensitive(key) && len(list) == 1:
+			headers[key] = fmt.Sprintf("[REDACTED %d CHARS]", len(list[0]))
+		case isHeaderSensitive(key):
+			headers[key] = fmt.Sprintf("[REDACTED %d ENTRIES]", len(list))
+		case len(list) == 1:
+			headers[key] = list[0]
+		default:
+			headers[key] = list
+		}
+	}
+	return headers
+}
+
+// NewDryRunResult describes the request that would be sent, instead of its response.
+// The requestBody is the value used to create the request body, after the transforms.
+func NewDryRunResult(source core.ResultSource, req *http.Request, requestBody core.Value) *core.SimpleResult {
+	var url string
+	if req.URL != nil {
+		url = req.URL.String()
+	}
+	return core.NewSimpleResult(source, dryRunResultSchema, map[string]any{
+		"method":  req.Method,
+		"url":     url,
+		"headers": RedactedHeaders(req.Header),
+		"body":    requestBody,
+	})
+}
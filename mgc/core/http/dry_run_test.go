@@ -0,0 +1,35 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"magalu.cloud/core"
+)
+
+func TestNewDryRunResult(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://api.magalu.cloud/vpcs", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+DryRunCredentials)
+	req.Header.Set("X-Api-Key", "secret")
+	req.Header.Set("Accept", "application/json")
+
+	result := NewDryRunResult(core.ResultSource{}, req, nil)
+	if err := result.ValidateSchema(); err != nil {
+		t.Errorf("expected the result to match its schema, got: %v", err)
+	}
+
+	headers := result.Value().(map[string]any)["headers"].(map[string]any)
+	expected := map[string]any{
+		"Authorization": "Bearer " + DryRunCredentials,
+		"X-Api-Key":     "[REDACTED 6 CHARS]",
+		"Accept":        "application/json",
+	}
+	for key, value := range expected {
+		if headers[key] != value {
+			t.Errorf("expected header %s to be %q, got %q", key, value, headers[key])
+		}
+	}
+}
@@ -481,3 +481,22 @@ func TestConvertComplexJSONNumbers(t *testing.T) {
 		t.Error("Expected structure not found in the result")
 	}
 }
+
+func TestRedactedHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer 123")
+	header.Set("X-Api-Key", "secret")
+	header.Set("Content-Type", "application/json")
+	header.Add("X-Tenant-Id", "a")
+	header.Add("X-Tenant-Id", "b")
+
+	expected := map[string]any{
+		"Authorization": "[REDACTED 10 CHARS]",
+		"X-Api-Key":     "[REDACTED 6 CHARS]",
+		"Content-Type":  "application/json",
+		"X-Tenant-Id":   []string{"a", "b"},
+	}
+	if got := RedactedHeaders(header); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
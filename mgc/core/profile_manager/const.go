@@ -2,3 +2,6 @@ package profile_manager
 
 const currentProfileNameFile = "current"
 const defaultProfileName = "default"
+
+// WorkspaceEnvVar selects the workspace of a single process, see ProfileManager.Pin()
+const WorkspaceEnvVar = "MGC_WORKSPACE"
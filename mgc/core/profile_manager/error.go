@@ -10,3 +10,4 @@ var errorInvalidName = errors.New("name should only contain alphanumric characte
 var errorProfileAlreadyExists = errors.New("profile already exists")
 var errorDeleteCurrentNotAllowed = errors.New("cannot delete current profile")
 var errorCopyToSelf = errors.New("cannot copy to itself")
+var errorProfileNotFound = errors.New("profile not found")
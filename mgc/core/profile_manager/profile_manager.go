@@ -3,6 +3,7 @@ package profile_manager
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path"
@@ -16,8 +17,9 @@ import (
 )
 
 type ProfileManager struct {
-	dir string
-	fs  afero.Fs
+	dir    string
+	fs     afero.Fs
+	pinned string
 }
 
 type contextKey string
@@ -39,12 +41,18 @@ func New() *ProfileManager {
 		dir = "."
 	}
 
-	return &ProfileManager{dir, afero.NewOsFs()}
+	m := &ProfileManager{dir: dir, fs: afero.NewOsFs()}
+	// Not checked with Pin() so a missing workspace is never silently replaced
+	// by the current one. Callers may call Pin() to report such errors.
+	if name := os.Getenv(WorkspaceEnvVar); name != "" {
+		m.pinned = name
+	}
+	return m
 }
 
 func NewInMemoryProfileManager() (*ProfileManager, afero.Fs) {
 	fs := afero.NewMemMapFs()
-	pf := &ProfileManager{"/", fs}
+	pf := &ProfileManager{dir: "/", fs: fs}
 	return pf, fs
 }
 
@@ -103,6 +111,30 @@ func (m *ProfileManager) Get(name string) (p *Profile, err error) {
 }
 
 func (m *ProfileManager) Current() *Profile {
+	if m.pinned != "" {
+		if p, err := m.Get(m.pinned); err == nil {
+			return p
+		}
+	}
+
+	return m.persistedCurrent()
+}
+
+// CurrentE is like Current(), but an invalid pinned name is reported instead of
+// being replaced by the persisted current profile.
+func (m *ProfileManager) CurrentE() (*Profile, error) {
+	if m.pinned != "" {
+		p, err := m.Get(m.pinned)
+		if err != nil {
+			return nil, fmt.Errorf("invalid workspace %q: %w", m.pinned, err)
+		}
+		return p, nil
+	}
+
+	return m.persistedCurrent(), nil
+}
+
+func (m *ProfileManager) persistedCurrent() *Profile {
 	var name string
 
 	data, err := m.read(currentProfileNameFile)
@@ -124,6 +156,25 @@ func (m *ProfileManager) Current() *Profile {
 	return p
 }
 
+// Pin makes Current() return the given profile for this ProfileManager only,
+// without changing the persisted current profile. The profile must exist,
+// except for the default one which is always available.
+func (m *ProfileManager) Pin(name string) error {
+	p, err := m.Get(name)
+	if err != nil {
+		return err
+	}
+
+	if p.Name != defaultProfileName {
+		if exists, err := afero.DirExists(m.fs, m.buildPath(p.Name)); err != nil || !exists {
+			return fmt.Errorf("%w: %s", errorProfileNotFound, p.Name)
+		}
+	}
+
+	m.pinned = p.Name
+	return nil
+}
+
 func (m *ProfileManager) SetCurrent(p *Profile) error {
 	return m.write(currentProfileNameFile, []byte(p.Name))
 }
@@ -162,8 +213,9 @@ func (m *ProfileManager) Copy(src, dst *Profile) error {
 	})
 }
 
+// Delete refuses to delete both the persisted current profile and the pinned one
 func (m *ProfileManager) Delete(p *Profile) error {
-	if m.Current().Name == p.Name {
+	if m.persistedCurrent().Name == p.Name || m.pinned == p.Name {
 		return errorDeleteCurrentNotAllowed
 	}
 	return m.remove(p.Name)
@@ -111,6 +111,26 @@ func createProfileManagerSetCurrentTest(testName string, profileName string, pro
 	}
 }
 
+func createProfileManagerPinTest(testName string, profileName string, expectedError error, provided []utils.TestFsEntry) testCaseProfileManager {
+	provided = utils.AutoMkdirAll(provided)
+	return testCaseProfileManager{
+		name:          fmt.Sprintf("ProfileManager.Pin(%q)[%s]", profileName, testName),
+		providedFs:    provided,
+		expectedFs:    provided,
+		expectedError: expectedError,
+		run: func(m *ProfileManager) error {
+			if err := m.Pin(profileName); err != nil {
+				return err
+			}
+			p := m.Current()
+			if profileName != p.Name {
+				return fmt.Errorf("expected name %q, got %q", profileName, p.Name)
+			}
+			return nil
+		},
+	}
+}
+
 func createProfileManagerCreateTest(testName string, profileName string, expectedError error, provided []utils.TestFsEntry, expected []utils.TestFsEntry) testCaseProfileManager {
 	provided = utils.AutoMkdirAll(provided)
 	expected = utils.AutoMkdirAll(utils.MergeFsEntries(expected, provided))
@@ -246,6 +266,21 @@ func TestProfileManager(t *testing.T) {
 				},
 			},
 		),
+		// Pin()
+		createProfileManagerPinTest("default-empty-fs", defaultProfileName, nil, nil),
+		createProfileManagerPinTest("missing-profile", "a-profile-name", errorProfileNotFound, nil),
+		createProfileManagerPinTest("invalid-name", "*+&le", errorInvalidName, nil),
+		createProfileManagerPinTest("existing-profile-keeps-current-file", "other-name", nil, []utils.TestFsEntry{
+			{
+				Path: path.Join(dir, currentProfileNameFile),
+				Mode: utils.FILE_PERMISSION,
+				Data: []byte("a-profile-name"),
+			},
+			{
+				Path: path.Join(dir, "other-name"),
+				Mode: utils.DIR_PERMISSION | fs.ModeDir,
+			},
+		}),
 		// Create()
 		createProfileManagerCreateTest("empty-fs", defaultProfileName, nil, nil, []utils.TestFsEntry{
 			{
@@ -374,7 +409,7 @@ func TestProfileManager(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			fs := afero.NewMemMapFs()
-			m := &ProfileManager{dir, fs}
+			m := &ProfileManager{dir: dir, fs: fs}
 			err := utils.PrepareFs(fs, tc.providedFs)
 			if err != nil {
 				t.Errorf("could not prepare provided FS: %s", err.Error())
@@ -390,3 +425,47 @@ func TestProfileManager(t *testing.T) {
 		})
 	}
 }
+
+func TestNewPinsWorkspaceFromEnv(t *testing.T) {
+	t.Setenv(WorkspaceEnvVar, "ci-tenant")
+
+	m := New()
+	if p := m.Current(); p.Name != "ci-tenant" {
+		t.Errorf("expected pinned profile %q, got %q", "ci-tenant", p.Name)
+	}
+}
+
+func TestCurrentInvalidPinnedName(t *testing.T) {
+	t.Setenv(WorkspaceEnvVar, "../other")
+
+	m := New()
+	if p, err := m.CurrentE(); err == nil {
+		t.Errorf("expected error for invalid pinned name, got profile %q", p.Name)
+	}
+}
+
+func TestDeleteCurrentWhilePinned(t *testing.T) {
+	m, fs := NewInMemoryProfileManager()
+	for _, name := range []string{"a", "b"} {
+		if _, err := m.Create(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	a, _ := m.Get("a")
+	b, _ := m.Get("b")
+	if err := m.SetCurrent(a); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Pin("b"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range []*Profile{a, b} {
+		if err := m.Delete(p); !errors.Is(err, errorDeleteCurrentNotAllowed) {
+			t.Errorf("expected error deleting %q, got %v", p.Name, err)
+		}
+		if exists, _ := afero.DirExists(fs, m.buildPath(p.Name)); !exists {
+			t.Errorf("expected profile %q not to be deleted", p.Name)
+		}
+	}
+}
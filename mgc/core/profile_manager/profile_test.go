@@ -150,7 +150,7 @@ func TestProfile(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			fs := afero.NewMemMapFs()
-			m := &ProfileManager{dir, fs}
+			m := &ProfileManager{dir: dir, fs: fs}
 
 			p, err := m.Get("profile")
 			if err != nil {
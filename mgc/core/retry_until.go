@@ -68,7 +68,8 @@ type RetryUntil struct {
 type RetryUntilCb func() (result Result, err error)
 
 func (r *RetryUntil) Run(ctx context.Context, cb RetryUntilCb) (result Result, err error) {
-	if r == nil {
+	// nothing would change in dry-run mode, so there is nothing to wait for
+	if r == nil || IsDryRun(ctx) {
 		return cb()
 	}
 
@@ -11,6 +11,16 @@ type TerminatorExecutor interface {
 	ExecuteUntilTermination(context context.Context, parameters Parameters, configs Configs) (result Result, err error)
 }
 
+// Implemented by terminators that execute and check the result repeatedly,
+// describing how often and how many times they do it
+type TerminatorExecutorWithCheck interface {
+	TerminatorExecutor
+	MaxRetries() int
+	Interval() time.Duration
+	// The conditions checked, if the executor was built from a configuration. May be nil
+	WaitTerminationConfig() *WaitTerminationConfig
+}
+
 type FailedTerminationError struct {
 	Result  Result
 	Message string
@@ -25,12 +35,25 @@ type executeTerminatorWithCheck struct {
 	maxRetries     int
 	interval       time.Duration
 	checkTerminate func(ctx context.Context, exec Executor, result ResultWithValue) (terminated bool, err error)
+	config         *WaitTerminationConfig
 }
 
 func (o *executeTerminatorWithCheck) Unwrap() Executor {
 	return o.Executor
 }
 
+func (o *executeTerminatorWithCheck) MaxRetries() int {
+	return o.maxRetries
+}
+
+func (o *executeTerminatorWithCheck) Interval() time.Duration {
+	return o.interval
+}
+
+func (o *executeTerminatorWithCheck) WaitTerminationConfig() *WaitTerminationConfig {
+	return o.config
+}
+
 func (o *executeTerminatorWithCheck) Execute(ctx context.Context, parameters Parameters, configs Configs) (result Result, err error) {
 	result, err = o.Executor.Execute(ctx, parameters, configs)
 	return ExecutorWrapResult(o, result, err)
@@ -42,6 +65,10 @@ func (o *executeTerminatorWithCheck) ExecuteUntilTermination(context context.Con
 }
 
 func (o *executeTerminatorWithCheck) executeUntilTermination(context context.Context, parameters Parameters, configs Configs) (result Result, err error) {
+	if IsDryRun(context) {
+		return o.Execute(context, parameters, configs)
+	}
+
 	var exec func() (Result, error)
 	if tExec, ok := ExecutorAs[TerminatorExecutor](o.Unwrap()); ok {
 		exec = func() (result Result, err error) {
@@ -84,7 +111,7 @@ func (o *executeTerminatorWithCheck) executeUntilTermination(context context.Con
 	return result, FailedTerminationError{Result: result, Message: msg}
 }
 
-var _ TerminatorExecutor = (*executeTerminatorWithCheck)(nil)
+var _ TerminatorExecutorWithCheck = (*executeTerminatorWithCheck)(nil)
 var _ ExecutorWrapper = (*executeTerminatorWithCheck)(nil)
 
 // Execute the operation and check the results until it's considered terminated.
@@ -95,5 +122,5 @@ func NewTerminatorExecutorWithCheck(
 	interval time.Duration,
 	checkTerminate func(ctx context.Context, exec Executor, result ResultWithValue) (terminated bool, err error),
 ) TerminatorExecutor {
-	return &executeTerminatorWithCheck{executor, maxRetries, interval, checkTerminate}
+	return &executeTerminatorWithCheck{Executor: executor, maxRetries: maxRetries, interval: interval, checkTerminate: checkTerminate}
 }
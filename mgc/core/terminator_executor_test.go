@@ -112,3 +112,35 @@ func TestExecuteTerminatorWithCheck(t *testing.T) {
 	}
 
 }
+
+func TestExecuteTerminatorWithCheckDryRun(t *testing.T) {
+	checks := 0
+	executor := &executeTerminatorWithCheck{
+		Executor: NewStaticExecuteSimple(
+			DescriptorSpec{
+				Name:        "ExceedMaxRetries",
+				Description: "ExceedMaxRetries",
+			},
+			func(ctx context.Context) (bool, error) {
+				return IsDryRun(ctx), nil
+			}),
+		maxRetries: 3,
+		interval:   1 * time.Second,
+		checkTerminate: func(ctx context.Context, exec Executor, result ResultWithValue) (terminated bool, err error) {
+			checks++
+			return false, nil
+		},
+	}
+
+	ctx := NewDryRunContext(context.Background(), true)
+	exeRes, err := executor.ExecuteUntilTermination(ctx, map[string]any{}, map[string]any{})
+	if err != nil {
+		t.Fatalf("expected no error, found: %s", err.Error())
+	}
+	if checks != 0 {
+		t.Errorf("expected no termination checks in dry-run, found: %d", checks)
+	}
+	if resWV, ok := ResultAs[ResultWithValue](exeRes); !ok || resWV.Value() != true {
+		t.Errorf("expected the executor to run in dry-run mode")
+	}
+}
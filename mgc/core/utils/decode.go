@@ -1,6 +1,36 @@
 package utils
 
-import "github.com/mitchellh/mapstructure"
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+var jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+// Types implementing json.Unmarshaler, such as the tagged unions of the generated SDK,
+// are decoded using their UnmarshalJSON() instead of having their fields filled in.
+//
+// The root type is excluded, as UnmarshalJSON() may be implemented using DecodeValue()
+func jsonUnmarshalerHookFunc(root reflect.Type) mapstructure.DecodeHookFuncValue {
+	return func(from reflect.Value, to reflect.Value) (any, error) {
+		if !from.IsValid() || !to.IsValid() || from.Type() == to.Type() || to.Type() == root || !reflect.PointerTo(to.Type()).Implements(jsonUnmarshalerType) {
+			return from.Interface(), nil
+		}
+
+		data, err := json.Marshal(from.Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		target := reflect.New(to.Type())
+		if err = target.Interface().(json.Unmarshaler).UnmarshalJSON(data); err != nil {
+			return nil, err
+		}
+		return target.Elem().Interface(), nil
+	}
+}
 
 // Attempts to decode the value passed in as input to the structure specified by the template
 // Structs can be converted to maps and vice-versa, strings to integers, etc...
@@ -12,6 +42,7 @@ func DecodeValue[T any, U any](input T, output *U) (err error) {
 		TagName:          "json",
 		WeaklyTypedInput: true,
 		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			jsonUnmarshalerHookFunc(reflect.TypeFor[U]()),
 			mapstructure.RecursiveStructToMapHookFunc(),
 			mapstructure.StringToTimeDurationHookFunc(),
 		),
@@ -34,6 +65,7 @@ func DecodeNewValue[T any](input any) (*T, error) {
 		TagName:          "json",
 		WeaklyTypedInput: true,
 		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			jsonUnmarshalerHookFunc(reflect.TypeFor[T]()),
 			mapstructure.RecursiveStructToMapHookFunc(),
 			mapstructure.StringToTimeDurationHookFunc(),
 		),
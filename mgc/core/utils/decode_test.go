@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// decodes to one of its fields, like the tagged unions of the generated SDK
+type jsonUnmarshalerUnion struct {
+	Str *string
+	Int *int
+}
+
+func (u *jsonUnmarshalerUnion) UnmarshalJSON(data []byte) error {
+	var i int
+	if err := json.Unmarshal(data, &i); err == nil {
+		*u = jsonUnmarshalerUnion{Int: &i}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*u = jsonUnmarshalerUnion{Str: &s}
+		return nil
+	}
+	return errors.New("expected string or int")
+}
+
+type unmarshalerFieldStruct struct {
+	Union    *jsonUnmarshalerUnion  `json:"union"`
+	Unions   []jsonUnmarshalerUnion `json:"unions"`
+	Optional *jsonUnmarshalerUnion  `json:"optional,omitempty"`
+}
+
+func TestDecodeValueJSONUnmarshaler(t *testing.T) {
+	str := "hello"
+	one := 1
+
+	input := map[string]any{
+		"union":  "hello",
+		"unions": []any{float64(1), "hello"},
+	}
+	expected := unmarshalerFieldStruct{
+		Union:  &jsonUnmarshalerUnion{Str: &str},
+		Unions: []jsonUnmarshalerUnion{{Int: &one}, {Str: &str}},
+	}
+
+	var output unmarshalerFieldStruct
+	if err := DecodeValue(input, &output); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected, output) {
+		t.Errorf("expected %#v, got %#v", expected, output)
+	}
+
+	output = unmarshalerFieldStruct{}
+	if err := DecodeValue(map[string]any{"union": true}, &output); err == nil {
+		t.Errorf("expected UnmarshalJSON() error, got %#v", output)
+	}
+}
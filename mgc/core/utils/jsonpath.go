@@ -60,45 +60,45 @@ func performStringsFunction(name string, function func(string, string) bool, arg
 	return function(l, r), nil
 }
 
+func fileSize(val any) (string, error) {
+	switch v := val.(type) {
+	case int:
+		return humanize.Bytes(uint64(v)), nil
+	case int8:
+		return humanize.Bytes(uint64(v)), nil
+	case int16:
+		return humanize.Bytes(uint64(v)), nil
+	case int32:
+		return humanize.Bytes(uint64(v)), nil
+	case int64:
+		return humanize.Bytes(uint64(v)), nil
+	case uint:
+		return humanize.Bytes(uint64(v)), nil
+	case uint8:
+		return humanize.Bytes(uint64(v)), nil
+	case uint16:
+		return humanize.Bytes(uint64(v)), nil
+	case uint32:
+		return humanize.Bytes(uint64(v)), nil
+	case uint64:
+		return humanize.Bytes(v), nil
+	case float32:
+		bi, _ := big.NewFloat(float64(v)).Int(nil)
+		return humanize.BigBytes(bi), nil
+	case float64:
+		bi, _ := big.NewFloat(v).Int(nil)
+		return humanize.BigBytes(bi), nil
+
+	default:
+		return "", fmt.Errorf("fileSize can't handle type %T (%#v)", v, v)
+	}
+}
+
 var fileSizeFunc = gval.Function("fileSize", func(args ...any) (result any, err error) {
 	if len(args) != 1 {
 		return nil, fmt.Errorf("'fileSize' jsonpath function expects a single argument with the value or slice to be formatted. Got %#v instead", args)
 	}
 
-	fileSize := func(val any) (string, error) {
-		switch v := val.(type) {
-		case int:
-			return humanize.Bytes(uint64(v)), nil
-		case int8:
-			return humanize.Bytes(uint64(v)), nil
-		case int16:
-			return humanize.Bytes(uint64(v)), nil
-		case int32:
-			return humanize.Bytes(uint64(v)), nil
-		case int64:
-			return humanize.Bytes(uint64(v)), nil
-		case uint:
-			return humanize.Bytes(uint64(v)), nil
-		case uint8:
-			return humanize.Bytes(uint64(v)), nil
-		case uint16:
-			return humanize.Bytes(uint64(v)), nil
-		case uint32:
-			return humanize.Bytes(uint64(v)), nil
-		case uint64:
-			return humanize.Bytes(v), nil
-		case float32:
-			bi, _ := big.NewFloat(float64(v)).Int(nil)
-			return humanize.BigBytes(bi), nil
-		case float64:
-			bi, _ := big.NewFloat(v).Int(nil)
-			return humanize.BigBytes(bi), nil
-
-		default:
-			return "", fmt.Errorf("fileSize can't handle type %T (%#v)", v, v)
-		}
-	}
-
 	switch val := args[0].(type) {
 	case []any:
 		r := make([]any, len(val))
@@ -145,54 +145,56 @@ var humanTimeStringParseLayouts = []string{
 	time.StampNano,  // "Jan _2 15:04:05.000000000"
 }
 
+func asTime(val any) (time.Time, error) {
+	switch v := val.(type) {
+	case time.Time:
+		return v, nil
+	case int:
+		return time.UnixMilli(int64(v)), nil
+	case int8:
+		return time.UnixMilli(int64(v)), nil
+	case int16:
+		return time.UnixMilli(int64(v)), nil
+	case int32:
+		return time.UnixMilli(int64(v)), nil
+	case int64:
+		return time.UnixMilli(v), nil
+	case uint:
+		return time.UnixMilli(int64(v)), nil
+	case uint8:
+		return time.UnixMilli(int64(v)), nil
+	case uint16:
+		return time.UnixMilli(int64(v)), nil
+	case uint32:
+		return time.UnixMilli(int64(v)), nil
+	case uint64:
+		return time.UnixMilli(int64(v)), nil
+	case float32:
+		i, _ := big.NewFloat(float64(v)).Int64()
+		return time.UnixMilli(i), nil
+	case float64:
+		i, _ := big.NewFloat(v).Int64()
+		return time.UnixMilli(i), nil
+
+	case string:
+		for _, layout := range humanTimeStringParseLayouts {
+			t, err := time.Parse(layout, v)
+			if err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("can't parse time string: %q", v)
+
+	default:
+		return time.Time{}, fmt.Errorf("can't handle type %T (%#v) as time", v, v)
+	}
+}
+
 var humanTimeFunc = gval.Function("humanTime", func(args ...any) (result any, err error) {
 	if len(args) != 1 {
 		return nil, fmt.Errorf("'humanTime' jsonpath function expects a single argument with the value or slice to be formatted. Got %#v instead", args)
 	}
 
-	asTime := func(val any) (time.Time, error) {
-		switch v := val.(type) {
-		case int:
-			return time.UnixMilli(int64(v)), nil
-		case int8:
-			return time.UnixMilli(int64(v)), nil
-		case int16:
-			return time.UnixMilli(int64(v)), nil
-		case int32:
-			return time.UnixMilli(int64(v)), nil
-		case int64:
-			return time.UnixMilli(v), nil
-		case uint:
-			return time.UnixMilli(int64(v)), nil
-		case uint8:
-			return time.UnixMilli(int64(v)), nil
-		case uint16:
-			return time.UnixMilli(int64(v)), nil
-		case uint32:
-			return time.UnixMilli(int64(v)), nil
-		case uint64:
-			return time.UnixMilli(int64(v)), nil
-		case float32:
-			i, _ := big.NewFloat(float64(v)).Int64()
-			return time.UnixMilli(i), nil
-		case float64:
-			i, _ := big.NewFloat(v).Int64()
-			return time.UnixMilli(i), nil
-
-		case string:
-			for _, layout := range humanTimeStringParseLayouts {
-				t, err := time.Parse(layout, v)
-				if err == nil {
-					return t, nil
-				}
-			}
-			return time.Time{}, fmt.Errorf("humanTime can't parse string: %q", v)
-
-		default:
-			return time.Time{}, fmt.Errorf("humanTime can't handle type %T (%#v)", v, v)
-		}
-	}
-
 	humanTime := func(val any) (string, error) {
 		t, err := asTime(val)
 		if err != nil {
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"reflect"
 )
@@ -16,6 +18,11 @@ func SimplifyAny(value any) (converted any, err error) {
 
 	v := reflect.ValueOf(value)
 
+	// pointers are dereferenced first, as the nil ones can't be marshaled
+	if m, ok := value.(json.Marshaler); ok && v.Kind() != reflect.Pointer {
+		return simplifyJSONMarshaler(m)
+	}
+
 	switch v.Kind() {
 	case reflect.Bool:
 		return v.Bool(), nil
@@ -99,3 +106,40 @@ func simplifyMap(v reflect.Value) (map[string]any, error) {
 	}
 	return result, nil
 }
+
+// simplifyJSONMarshaler converts values with custom JSON encoding, such as the tagged unions
+// of the generated SDK, to the value they encode to. Numbers are kept as int64 if possible.
+func simplifyJSONMarshaler(m json.Marshaler) (any, error) {
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var decoded any
+	if err = decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	return simplifyJSONNumbers(decoded), nil
+}
+
+func simplifyJSONNumbers(value any) any {
+	switch value := value.(type) {
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return i
+		}
+		f, _ := value.Float64()
+		return f
+	case []any:
+		for i, item := range value {
+			value[i] = simplifyJSONNumbers(item)
+		}
+	case map[string]any:
+		for k, item := range value {
+			value[k] = simplifyJSONNumbers(item)
+		}
+	}
+	return value
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -24,6 +25,25 @@ type complexStruct struct {
 	BoolField      bool
 }
 
+// encodes as one of its fields, like the tagged unions of the generated SDK
+type jsonUnion struct {
+	Str *string
+	Int *int
+}
+
+func (u jsonUnion) MarshalJSON() ([]byte, error) {
+	if u.Str != nil {
+		return json.Marshal(u.Str)
+	}
+	return json.Marshal(u.Int)
+}
+
+type unionFieldStruct struct {
+	Union    *jsonUnion  `json:"union"`
+	Unions   []jsonUnion `json:"unions"`
+	Optional *jsonUnion  `json:"optional,omitempty"`
+}
+
 type CustomStr string
 type CustomInt int
 type CustomUInt uint
@@ -160,6 +180,13 @@ var dataResultArr = []testCase{
 			},
 		},
 	},
+	{Name: "json marshaler to its encoded value", Input: jsonUnion{Str: strPtr}, Output: "hello"},
+	{Name: "json marshaler ptr to its encoded value", Input: &jsonUnion{Int: new(int)}, Output: int64(0)},
+	{
+		Name:   "struct with json marshaler fields to map[string]any",
+		Input:  unionFieldStruct{Union: &jsonUnion{Str: strPtr}, Unions: []jsonUnion{{Str: strPtr}, {Int: new(int)}}},
+		Output: map[string]any{"union": "hello", "unions": []any{"hello", int64(0)}},
+	},
 	{Input: func() {}, Output: nil},
 }
 
@@ -19,7 +19,7 @@ func NewTemplate(expression string) (tmpl *template.Template, err error) {
 }
 
 func NewTemplateFilename(expression string, fileName string) (tmpl *template.Template, err error) {
-	return template.New(fileName).Parse(expression)
+	return template.New(fileName).Funcs(templateFuncMap).Parse(expression)
 }
 
 func CreateTemplateChecker(expression string) (checker func(document any) (bool, error), err error) {
@@ -0,0 +1,432 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+// TemplateFunc is a function available to every template created by
+// NewTemplate() and NewTemplateFilename(), such as the "template" output,
+// wait termination "templateQuery" and blueprint conditions and checks.
+//
+// Like in most template libraries, the value to operate on is the last
+// argument, so functions may be chained in pipelines:
+// {{ .name | default "-" | upper }}
+type TemplateFunc struct {
+	Name        string
+	Usage       string
+	Description string
+	Func        any
+}
+
+var templateFuncs = []TemplateFunc{
+	// dates and durations
+	{"now", "now", "Current time", time.Now},
+	{"date", "date LAYOUT TIME", `Format TIME (RFC3339 string or Unix milliseconds) with a Go layout or one of "RFC3339", "DateTime", "DateOnly", "TimeOnly" and "Kitchen"`, templateDate},
+	{"humanTime", "humanTime TIME", `Format TIME relative to now, such as "3 days ago"`, templateHumanTime},
+	{"since", "since TIME", "Duration since TIME, rounded to seconds", templateSince},
+	{"duration", "duration VALUE", `Format VALUE (seconds or a duration string such as "90m") as a duration, such as "1h30m0s"`, templateDuration},
+
+	// sizes and numbers
+	{"fileSize", "fileSize BYTES", `Format BYTES in human units, such as "1.2 GB"`, fileSize},
+	{"add", "add A B", "A + B", templateMath(func(a, b float64) float64 { return a + b })},
+	{"sub", "sub A B", "A - B", templateMath(func(a, b float64) float64 { return a - b })},
+	{"mul", "mul A B", "A * B", templateMath(func(a, b float64) float64 { return a * b })},
+	{"div", "div A B", "A / B", templateMath(func(a, b float64) float64 { return a / b })},
+	{"mod", "mod A B", "Remainder of A / B", templateMath(math.Mod)},
+	{"max", "max A B", "Largest of A and B", templateMath(math.Max)},
+	{"min", "min A B", "Smallest of A and B", templateMath(math.Min)},
+	{"round", "round PRECISION VALUE", "Round VALUE to PRECISION decimal places", templateRound},
+
+	// defaults
+	{"default", "default DEFAULT VALUE", "VALUE, or DEFAULT if VALUE is empty", templateDefault},
+	{"coalesce", "coalesce VALUES...", "First non-empty of VALUES", templateCoalesce},
+	{"empty", "empty VALUE", "Whether VALUE is nil, zero or has no elements", templateIsEmpty},
+
+	// encoding
+	{"toJson", "toJson VALUE", "Encode VALUE as compact JSON", templateToJson},
+	{"toPrettyJson", "toPrettyJson VALUE", "Encode VALUE as indented JSON", templateToPrettyJson},
+	{"fromJson", "fromJson STRING", "Decode the JSON STRING", templateFromJson},
+	{"b64enc", "b64enc STRING", "Encode STRING as base64", templateB64Enc},
+	{"b64dec", "b64dec STRING", "Decode the base64 STRING", templateB64Dec},
+
+	// strings
+	{"upper", "upper STRING", "STRING in upper case", strings.ToUpper},
+	{"lower", "lower STRING", "STRING in lower case", strings.ToLower},
+	{"trim", "trim STRING", "STRING without leading and trailing spaces", strings.TrimSpace},
+	{"replace", "replace OLD NEW STRING", "Replace all OLD with NEW in STRING", templateReplace},
+	{"contains", "contains SUBSTRING STRING", "Whether STRING contains SUBSTRING", templateContains},
+	{"hasPrefix", "hasPrefix PREFIX STRING", "Whether STRING starts with PREFIX", templateHasPrefix},
+	{"hasSuffix", "hasSuffix SUFFIX STRING", "Whether STRING ends with SUFFIX", templateHasSuffix},
+	{"split", "split SEPARATOR STRING", "Split STRING into a list", templateSplit},
+	{"repeat", "repeat COUNT STRING", "STRING repeated COUNT times", templateRepeat},
+	{"truncate", "truncate LENGTH STRING", `STRING cut to LENGTH characters, ending with "…" if cut`, templateTruncate},
+	{"padLeft", "padLeft WIDTH VALUE", "VALUE right aligned in WIDTH characters", templatePadLeft},
+	{"padRight", "padRight WIDTH VALUE", "VALUE left aligned in WIDTH characters", templatePadRight},
+	{"quote", "quote VALUE", "VALUE as a double quoted string", templateQuote},
+
+	// lists
+	{"list", "list VALUES...", "Create a list with VALUES", templateList},
+	{"join", "join SEPARATOR LIST", "Join the LIST elements into a string", templateJoin},
+	{"first", "first LIST", "First element of LIST", templateFirst},
+	{"last", "last LIST", "Last element of LIST", templateLast},
+	{"uniq", "uniq LIST", "LIST without repeated elements", templateUniq},
+	{"sortAlpha", "sortAlpha LIST", "LIST sorted as strings", templateSortAlpha},
+	{"pluck", "pluck FIELD LIST", `The FIELD of each object in LIST, nested fields are separated by "."`, templatePluck},
+
+	// tables
+	{"table", "table COLUMNS LIST", `Render the objects in LIST as aligned columns. COLUMNS are separated by "," and each is "FIELD" or "HEADER:FIELD"`, templateTable},
+}
+
+// TemplateFuncs lists the functions available to templates, in documentation order
+func TemplateFuncs() []TemplateFunc {
+	return slices.Clone(templateFuncs)
+}
+
+var templateFuncMap = func() template.FuncMap {
+	m := make(template.FuncMap, len(templateFuncs))
+	for _, f := range templateFuncs {
+		m[f.Name] = f.Func
+	}
+	return m
+}()
+
+var templateTimeLayouts = map[string]string{
+	"RFC3339":  time.RFC3339,
+	"DateTime": time.DateTime,
+	"DateOnly": time.DateOnly,
+	"TimeOnly": time.TimeOnly,
+	"Kitchen":  time.Kitchen,
+}
+
+func templateDate(layout string, value any) (string, error) {
+	t, err := asTime(value)
+	if err != nil {
+		return "", err
+	}
+	if l, ok := templateTimeLayouts[layout]; ok {
+		layout = l
+	}
+	return t.Format(layout), nil
+}
+
+func templateHumanTime(value any) (string, error) {
+	t, err := asTime(value)
+	if err != nil {
+		return "", err
+	}
+	return humanize.Time(t), nil
+}
+
+func templateSince(value any) (time.Duration, error) {
+	t, err := asTime(value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Since(t).Round(time.Second), nil
+}
+
+func templateDuration(value any) (time.Duration, error) {
+	if s, ok := value.(string); ok {
+		return time.ParseDuration(s)
+	}
+	seconds, err := templateToFloat(value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+func templateToFloat(value any) (float64, error) {
+	switch v := value.(type) {
+	case string:
+		return strconv.ParseFloat(v, 64)
+	case time.Duration:
+		return v.Seconds(), nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.CanInt():
+		return float64(rv.Int()), nil
+	case rv.CanUint():
+		return float64(rv.Uint()), nil
+	case rv.CanFloat():
+		return rv.Float(), nil
+	default:
+		return 0, fmt.Errorf("can't handle type %T (%#v) as number", value, value)
+	}
+}
+
+func templateMath(op func(a, b float64) float64) func(a, b any) (float64, error) {
+	return func(a, b any) (float64, error) {
+		fa, err := templateToFloat(a)
+		if err != nil {
+			return 0, err
+		}
+		fb, err := templateToFloat(b)
+		if err != nil {
+			return 0, err
+		}
+		return op(fa, fb), nil
+	}
+}
+
+func templateRound(precision int, value any) (float64, error) {
+	f, err := templateToFloat(value)
+	if err != nil {
+		return 0, err
+	}
+	p := math.Pow10(precision)
+	return math.Round(f*p) / p, nil
+}
+
+func templateIsEmpty(value any) bool {
+	if value == nil {
+		return true
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return rv.IsNil()
+	default:
+		return rv.IsZero()
+	}
+}
+
+func templateDefault(def any, value ...any) any {
+	if len(value) == 0 || templateIsEmpty(value[0]) {
+		return def
+	}
+	return value[0]
+}
+
+func templateCoalesce(values ...any) any {
+	for _, v := range values {
+		if !templateIsEmpty(v) {
+			return v
+		}
+	}
+	return nil
+}
+
+func templateToJson(value any) (string, error) {
+	data, err := json.Marshal(value)
+	return string(data), err
+}
+
+func templateToPrettyJson(value any) (string, error) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	return string(data), err
+}
+
+func templateFromJson(s string) (value any, err error) {
+	err = json.Unmarshal([]byte(s), &value)
+	return
+}
+
+func templateB64Enc(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+func templateB64Dec(s string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	return string(data), err
+}
+
+func templateReplace(old, new, s string) string {
+	return strings.ReplaceAll(s, old, new)
+}
+
+func templateContains(substr, s string) bool {
+	return strings.Contains(s, substr)
+}
+
+func templateHasPrefix(prefix, s string) bool {
+	return strings.HasPrefix(s, prefix)
+}
+
+func templateHasSuffix(suffix, s string) bool {
+	return strings.HasSuffix(s, suffix)
+}
+
+func templateSplit(sep, s string) []string {
+	return strings.Split(s, sep)
+}
+
+func templateRepeat(count int, s string) string {
+	return strings.Repeat(s, count)
+}
+
+func templateTruncate(length int, s string) string {
+	runes := []rune(s)
+	if length < 1 || len(runes) <= length {
+		return s
+	}
+	return string(runes[:length-1]) + "…"
+}
+
+func templatePadLeft(width int, value any) string {
+	return fmt.Sprintf("%*v", width, value)
+}
+
+func templatePadRight(width int, value any) string {
+	return fmt.Sprintf("%-*v", width, value)
+}
+
+func templateQuote(value any) string {
+	return strconv.Quote(fmt.Sprint(value))
+}
+
+func templateList(values ...any) []any {
+	return values
+}
+
+// any list type, as templates may operate on []any from JSON or []string from split
+func templateAsList(value any) ([]any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if l, ok := value.([]any); ok {
+		return l, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("can't handle type %T (%#v) as list", value, value)
+	}
+
+	l := make([]any, rv.Len())
+	for i := range l {
+		l[i] = rv.Index(i).Interface()
+	}
+	return l, nil
+}
+
+func templateJoin(sep string, value any) (string, error) {
+	l, err := templateAsList(value)
+	if err != nil {
+		return "", err
+	}
+	s := make([]string, len(l))
+	for i, v := range l {
+		s[i] = fmt.Sprint(v)
+	}
+	return strings.Join(s, sep), nil
+}
+
+func templateFirst(value any) (any, error) {
+	l, err := templateAsList(value)
+	if err != nil || len(l) == 0 {
+		return nil, err
+	}
+	return l[0], nil
+}
+
+func templateLast(value any) (any, error) {
+	l, err := templateAsList(value)
+	if err != nil || len(l) == 0 {
+		return nil, err
+	}
+	return l[len(l)-1], nil
+}
+
+func templateUniq(value any) ([]any, error) {
+	l, err := templateAsList(value)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]any, 0, len(l))
+	for _, v := range l {
+		if !slices.ContainsFunc(result, func(r any) bool { return reflect.DeepEqual(r, v) }) {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+func templateSortAlpha(value any) ([]string, error) {
+	l, err := templateAsList(value)
+	if err != nil {
+		return nil, err
+	}
+	s := make([]string, len(l))
+	for i, v := range l {
+		s[i] = fmt.Sprint(v)
+	}
+	slices.Sort(s)
+	return s, nil
+}
+
+func templateField(value any, field string) any {
+	for _, name := range strings.Split(field, ".") {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = m[name]
+	}
+	return value
+}
+
+func templatePluck(field string, value any) ([]any, error) {
+	l, err := templateAsList(value)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]any, len(l))
+	for i, v := range l {
+		result[i] = templateField(v, field)
+	}
+	return result, nil
+}
+
+func templateTable(columnsSpec string, value any) (string, error) {
+	l, err := templateAsList(value)
+	if err != nil {
+		return "", err
+	}
+
+	columns := strings.Split(columnsSpec, ",")
+	headers := make([]string, len(columns))
+	fields := make([]string, len(columns))
+	for i, col := range columns {
+		header, field, ok := strings.Cut(col, ":")
+		if !ok {
+			field = col
+			header = strings.ToUpper(col)
+		}
+		headers[i] = header
+		fields[i] = field
+	}
+
+	var buf strings.Builder
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, item := range l {
+		cells := make([]string, len(fields))
+		for i, field := range fields {
+			if v := templateField(item, field); v != nil {
+				cells[i] = fmt.Sprint(v)
+			}
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	if err = w.Flush(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	doc := map[string]any{
+		"name":       "web-01",
+		"empty":      "",
+		"created_at": "2024-03-01T10:20:30Z",
+		"size":       float64(1500000000),
+		"vcpus":      float64(4),
+		"tags":       []any{"b", "a", "b"},
+		"encoded":    "aGVsbG8=",
+		"json":       `{"id": "abc"}`,
+		"instances": []any{
+			map[string]any{"id": "1", "name": "web-01", "machine": map[string]any{"vcpus": float64(4)}},
+			map[string]any{"id": "2", "name": "db-01", "machine": map[string]any{"vcpus": float64(16)}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"date layout name", `{{ date "DateOnly" .created_at }}`, "2024-03-01"},
+		{"date go layout", `{{ .created_at | date "02/01 15:04" }}`, "01/03 10:20"},
+		{"duration seconds", `{{ duration 5400 }}`, "1h30m0s"},
+		{"duration string", `{{ duration "90s" }}`, "1m30s"},
+		{"file size", `{{ fileSize .size }}`, "1.5 GB"},
+		{"math", `{{ mul .vcpus 2 | add 1 }}`, "9"},
+		{"round", `{{ div 10 3 | round 2 }}`, "3.33"},
+		{"default missing", `{{ .missing | default "-" }}`, "-"},
+		{"default empty", `{{ default "-" .empty }}`, "-"},
+		{"default set", `{{ .name | default "-" }}`, "web-01"},
+		{"coalesce", `{{ coalesce .missing .empty .name }}`, "web-01"},
+		{"to json", `{{ toJson .tags }}`, `["b","a","b"]`},
+		{"from json", `{{ (fromJson .json).id }}`, "abc"},
+		{"base64", `{{ b64dec .encoded }} {{ b64enc "hello" }}`, "hello aGVsbG8="},
+		{"strings", `{{ .name | upper | replace "-" "_" }}`, "WEB_01"},
+		{"contains", `{{ if contains "web" .name }}yes{{ end }}`, "yes"},
+		{"truncate", `{{ truncate 4 .name }}`, "web…"},
+		{"pad", `[{{ padLeft 8 .name }}][{{ padRight 8 .name }}]`, "[  web-01][web-01  ]"},
+		{"split join", `{{ split "-" .name | join "+" }}`, "web+01"},
+		{"lists", `{{ first .tags }} {{ last .tags }} {{ uniq .tags | sortAlpha | join "," }}`, "b b a,b"},
+		{"pluck", `{{ pluck "machine.vcpus" .instances | join "," }}`, "4,16"},
+		{"table", `{{ table "ID:id,name" .instances }}`, "ID  NAME\n1   web-01\n2   db-01"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl, err := NewTemplate(tc.template)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %s", err)
+			}
+			got, err := ExecuteTemplateTrimmed(tmpl, doc)
+			if err != nil {
+				t.Fatalf("unexpected execute error: %s", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestTemplateFuncsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{"invalid time", `{{ date "DateOnly" "not a date" }}`},
+		{"invalid number", `{{ add "x" 1 }}`},
+		{"invalid json", `{{ fromJson "{" }}`},
+		{"not a list", `{{ join "," 1 }}`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl, err := NewTemplate(tc.template)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %s", err)
+			}
+			if _, err = ExecuteTemplateTrimmed(tmpl, nil); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -55,7 +55,7 @@ func (c *WaitTerminationConfig) Build(exec Executor, getDocument func(result Res
 		return nil, err
 	}
 
-	return NewTerminatorExecutorWithCheck(exec, maxRetries, interval, func(ctx context.Context, exec Executor, result ResultWithValue) (terminated bool, err error) {
+	checkTerminate := func(ctx context.Context, exec Executor, result ResultWithValue) (terminated bool, err error) {
 		doc := getDocument(result)
 
 		terminated, err = expChecker(doc)
@@ -80,7 +80,16 @@ func (c *WaitTerminationConfig) Build(exec Executor, getDocument func(result Res
 		}
 
 		return
-	}), nil
+	}
+
+	config := *c
+	return &executeTerminatorWithCheck{
+		Executor:       exec,
+		maxRetries:     maxRetries,
+		interval:       interval,
+		checkTerminate: checkTerminate,
+		config:         &config,
+	}, nil
 }
 
 func (c *WaitTerminationConfig) UnmarshalJSON(data []byte) (err error) {
@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 
@@ -14,6 +15,17 @@ func ConvertResult[T any](r mgcCore.Result) (result T, err error) {
 		err = &mgcUtils.ChainedError{Name: "result", Err: fmt.Errorf("result does not contain a value: %#v", r)}
 		return
 	}
+	// DecodeValue() doesn't use UnmarshalJSON() of the root value, ex: unions
+	if unmarshaler, ok := any(&result).(json.Unmarshaler); ok {
+		var data []byte
+		if data, err = json.Marshal(rValue.Value()); err == nil {
+			err = unmarshaler.UnmarshalJSON(data)
+		}
+		if err != nil {
+			err = &mgcUtils.ChainedError{Name: "result", Err: err}
+		}
+		return
+	}
 	if err = mgcUtils.DecodeValue(rValue.Value(), &result); err != nil {
 		err = &mgcUtils.ChainedError{Name: "result", Err: err}
 		return
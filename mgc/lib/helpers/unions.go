@@ -0,0 +1,86 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	mgcSchemaPkg "magalu.cloud/core/schema"
+)
+
+// UnionVariant is one of the alternatives of the "one of" and "any of" types
+type UnionVariant struct {
+	// JSON Schema the value must match to be decoded as this variant
+	Schema string
+	// Pointer to the variant field, ex: &u.String
+	Target any
+}
+
+var unionSchemas sync.Map // JSON -> *mgcSchemaPkg.Schema
+
+func getUnionSchema(data string) (schema *mgcSchemaPkg.Schema, err error) {
+	if cached, ok := unionSchemas.Load(data); ok {
+		return cached.(*mgcSchemaPkg.Schema), nil
+	}
+	schema = &mgcSchemaPkg.Schema{}
+	if err = json.Unmarshal([]byte(data), schema); err != nil {
+		return nil, err
+	}
+	unionSchemas.Store(data, schema)
+	return schema, nil
+}
+
+// MarshalUnion encodes the first variant that is set, or null if none is.
+// Variants are the pointer fields of the union, in order.
+//
+// Integral floats are encoded with a decimal point, ex: 3.0 instead of 3, otherwise
+// they would be decoded back as the integer variant, if any.
+func MarshalUnion(variants ...any) ([]byte, error) {
+	for _, variant := range variants {
+		v := reflect.ValueOf(variant)
+		if !v.IsValid() || v.IsNil() {
+			continue
+		}
+		data, err := json.Marshal(variant)
+		if err != nil {
+			return nil, err
+		}
+		if kind := v.Elem().Kind(); (kind == reflect.Float32 || kind == reflect.Float64) && !bytes.ContainsAny(data, ".eE") {
+			data = append(data, ".0"...)
+		}
+		return data, nil
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalUnion decodes the data into the first variant whose schema matches it and
+// whose type can hold it, ex: integers that overflow int are decoded as float64.
+// The null value leaves all variants unset.
+func UnmarshalUnion(data []byte, variants ...UnionVariant) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if value == nil {
+		return nil
+	}
+
+	for _, variant := range variants {
+		schema, err := getUnionSchema(variant.Schema)
+		if err != nil {
+			return err
+		}
+		if schema.VisitJSON(value) != nil {
+			continue
+		}
+		target := reflect.New(reflect.TypeOf(variant.Target).Elem())
+		if json.Unmarshal(data, target.Interface()) == nil {
+			reflect.ValueOf(variant.Target).Elem().Set(target.Elem())
+			return nil
+		}
+	}
+
+	return fmt.Errorf("value %s doesn't match any of the alternatives", data)
+}
@@ -0,0 +1,114 @@
+package helpers
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// testUnion is generated for "any of: integer, number, boolean, string, object"
+type testUnion struct {
+	Int     *int
+	Float64 *float64
+	Bool    *bool
+	String  *string
+	Object  *testUnionObject
+}
+
+type testUnionObject struct {
+	Name string `json:"name"`
+}
+
+func (u testUnion) MarshalJSON() ([]byte, error) {
+	return MarshalUnion(u.Int, u.Float64, u.Bool, u.String, u.Object)
+}
+
+func (u *testUnion) UnmarshalJSON(data []byte) error {
+	*u = testUnion{}
+	return UnmarshalUnion(
+		data,
+		UnionVariant{Schema: `{"type":"integer"}`, Target: &u.Int},
+		UnionVariant{Schema: `{"type":"number"}`, Target: &u.Float64},
+		UnionVariant{Schema: `{"type":"boolean"}`, Target: &u.Bool},
+		UnionVariant{Schema: `{"type":"string"}`, Target: &u.String},
+		UnionVariant{Schema: `{"type":"object","required":["name"],"properties":{"name":{"type":"string"}}}`, Target: &u.Object},
+	)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestUnmarshalUnion(t *testing.T) {
+	tests := []struct {
+		data     string
+		expected testUnion
+	}{
+		{data: `3`, expected: testUnion{Int: ptr(3)}},
+		{data: `3.0`, expected: testUnion{Float64: ptr(3.0)}},
+		{data: `0.5`, expected: testUnion{Float64: ptr(0.5)}},
+		{data: `1e21`, expected: testUnion{Float64: ptr(1e21)}},
+		{data: `true`, expected: testUnion{Bool: ptr(true)}},
+		{data: `"3"`, expected: testUnion{String: ptr("3")}},
+		{data: `{"name": "db"}`, expected: testUnion{Object: &testUnionObject{Name: "db"}}},
+		{data: `null`, expected: testUnion{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.data, func(t *testing.T) {
+			var u testUnion
+			if err := json.Unmarshal([]byte(tc.data), &u); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tc.expected, u) {
+				t.Errorf("expected %+v, got %+v", tc.expected, u)
+			}
+		})
+	}
+
+	var u testUnion
+	if err := json.Unmarshal([]byte(`[1]`), &u); err == nil {
+		t.Errorf("expected error for a value matching no variant, got %+v", u)
+	}
+	if err := json.Unmarshal([]byte(`{"size": 1}`), &u); err == nil {
+		t.Errorf("expected error for an object not matching the schema, got %+v", u)
+	}
+}
+
+func TestMarshalUnion(t *testing.T) {
+	tests := []struct {
+		value    testUnion
+		expected string
+	}{
+		{value: testUnion{Int: ptr(3)}, expected: `3`},
+		{value: testUnion{Float64: ptr(3.0)}, expected: `3.0`},
+		{value: testUnion{Float64: ptr(0.5)}, expected: `0.5`},
+		{value: testUnion{Float64: ptr(1e21)}, expected: `1e+21`},
+		{value: testUnion{Bool: ptr(false)}, expected: `false`},
+		{value: testUnion{String: ptr("3")}, expected: `"3"`},
+		{value: testUnion{Object: &testUnionObject{Name: "db"}}, expected: `{"name":"db"}`},
+		{value: testUnion{}, expected: `null`},
+		// only the first variant set is encoded
+		{value: testUnion{Int: ptr(1), String: ptr("1")}, expected: `1`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expected, func(t *testing.T) {
+			data, err := json.Marshal(tc.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, data)
+			}
+
+			var decoded testUnion
+			if err = json.Unmarshal(data, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			if data, _ = json.Marshal(decoded); string(data) != tc.expected {
+				t.Errorf("expected the decoded value to be encoded as %s, got %s", tc.expected, data)
+			}
+		})
+	}
+}
@@ -90,7 +90,62 @@ type GetSchemaResultAdditionalPropertiesSchemaValueAnyOfItem struct {
 type GetSchemaResultAdditionalPropertiesSchemaValueAnyOf []*GetSchemaResultAdditionalPropertiesSchemaValueAnyOfItem
 
 // any of: *bool, *string, *float64, *int, *GetSchemaResultAdditionalPropertiesSchemaValueDefault4, *GetSchemaResultAdditionalPropertiesSchemaValueDefault5
-type GetSchemaResultAdditionalPropertiesSchemaValueDefault any
+type GetSchemaResultAdditionalPropertiesSchemaValueDefault struct {
+	Bool    *bool
+	String  *string
+	Int     *int
+	Float64 *float64
+	Array   *GetSchemaResultAdditionalPropertiesSchemaValueDefault4
+	Object  *GetSchemaResultAdditionalPropertiesSchemaValueDefault5
+}
+
+func NewGetSchemaResultAdditionalPropertiesSchemaValueDefaultBool(value bool) GetSchemaResultAdditionalPropertiesSchemaValueDefault {
+	return GetSchemaResultAdditionalPropertiesSchemaValueDefault{Bool: &value}
+}
+
+func NewGetSchemaResultAdditionalPropertiesSchemaValueDefaultString(value string) GetSchemaResultAdditionalPropertiesSchemaValueDefault {
+	return GetSchemaResultAdditionalPropertiesSchemaValueDefault{String: &value}
+}
+
+func NewGetSchemaResultAdditionalPropertiesSchemaValueDefaultInt(value int) GetSchemaResultAdditionalPropertiesSchemaValueDefault {
+	return GetSchemaResultAdditionalPropertiesSchemaValueDefault{Int: &value}
+}
+
+func NewGetSchemaResultAdditionalPropertiesSchemaValueDefaultFloat64(value float64) GetSchemaResultAdditionalPropertiesSchemaValueDefault {
+	return GetSchemaResultAdditionalPropertiesSchemaValueDefault{Float64: &value}
+}
+
+func NewGetSchemaResultAdditionalPropertiesSchemaValueDefaultArray(value GetSchemaResultAdditionalPropertiesSchemaValueDefault4) GetSchemaResultAdditionalPropertiesSchemaValueDefault {
+	return GetSchemaResultAdditionalPropertiesSchemaValueDefault{Array: &value}
+}
+
+func NewGetSchemaResultAdditionalPropertiesSchemaValueDefaultObject(value GetSchemaResultAdditionalPropertiesSchemaValueDefault5) GetSchemaResultAdditionalPropertiesSchemaValueDefault {
+	return GetSchemaResultAdditionalPropertiesSchemaValueDefault{Object: &value}
+}
+
+func (u GetSchemaResultAdditionalPropertiesSchemaValueDefault) MarshalJSON() ([]byte, error) {
+	return mgcHelpers.MarshalUnion(
+		u.Bool,
+		u.String,
+		u.Int,
+		u.Float64,
+		u.Array,
+		u.Object,
+	)
+}
+
+func (u *GetSchemaResultAdditionalPropertiesSchemaValueDefault) UnmarshalJSON(data []byte) error {
+	*u = GetSchemaResultAdditionalPropertiesSchemaValueDefault{}
+	return mgcHelpers.UnmarshalUnion(
+		data,
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"boolean\"}", Target: &u.Bool},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"string\"}", Target: &u.String},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"integer\"}", Target: &u.Int},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"number\"}", Target: &u.Float64},
+		mgcHelpers.UnionVariant{Schema: "{\"items\":{},\"type\":\"array\"}", Target: &u.Array},
+		mgcHelpers.UnionVariant{Schema: "{\"additionalProperties\":true,\"type\":\"object\"}", Target: &u.Object},
+	)
+}
 
 type GetSchemaResultAdditionalPropertiesSchemaValueDefault4 []any
 
@@ -110,7 +165,62 @@ type GetSchemaResultAdditionalPropertiesSchemaValueDiscriminatorMapping struct {
 }
 
 // any of: *bool, *string, *float64, *int, *GetSchemaResultAdditionalPropertiesSchemaValueEnumItem4, *GetSchemaResultAdditionalPropertiesSchemaValueEnumItem5
-type GetSchemaResultAdditionalPropertiesSchemaValueEnumItem any
+type GetSchemaResultAdditionalPropertiesSchemaValueEnumItem struct {
+	Bool    *bool
+	String  *string
+	Int     *int
+	Float64 *float64
+	Array   *GetSchemaResultAdditionalPropertiesSchemaValueEnumItem4
+	Object  *GetSchemaResultAdditionalPropertiesSchemaValueEnumItem5
+}
+
+func NewGetSchemaResultAdditionalPropertiesSchemaValueEnumItemBool(value bool) GetSchemaResultAdditionalPropertiesSchemaValueEnumItem {
+	return GetSchemaResultAdditionalPropertiesSchemaValueEnumItem{Bool: &value}
+}
+
+func NewGetSchemaResultAdditionalPropertiesSchemaValueEnumItemString(value string) GetSchemaResultAdditionalPropertiesSchemaValueEnumItem {
+	return GetSchemaResultAdditionalPropertiesSchemaValueEnumItem{String: &value}
+}
+
+func NewGetSchemaResultAdditionalPropertiesSchemaValueEnumItemInt(value int) GetSchemaResultAdditionalPropertiesSchemaValueEnumItem {
+	return GetSchemaResultAdditionalPropertiesSchemaValueEnumItem{Int: &value}
+}
+
+func NewGetSchemaResultAdditionalPropertiesSchemaValueEnumItemFloat64(value float64) GetSchemaResultAdditionalPropertiesSchemaValueEnumItem {
+	return GetSchemaResultAdditionalPropertiesSchemaValueEnumItem{Float64: &value}
+}
+
+func NewGetSchemaResultAdditionalPropertiesSchemaValueEnumItemArray(value GetSchemaResultAdditionalPropertiesSchemaValueEnumItem4) GetSchemaResultAdditionalPropertiesSchemaValueEnumItem {
+	return GetSchemaResultAdditionalPropertiesSchemaValueEnumItem{Array: &value}
+}
+
+func NewGetSchemaResultAdditionalPropertiesSchemaValueEnumItemObject(value GetSchemaResultAdditionalPropertiesSchemaValueEnumItem5) GetSchemaResultAdditionalPropertiesSchemaValueEnumItem {
+	return GetSchemaResultAdditionalPropertiesSchemaValueEnumItem{Object: &value}
+}
+
+func (u GetSchemaResultAdditionalPropertiesSchemaValueEnumItem) MarshalJSON() ([]byte, error) {
+	return mgcHelpers.MarshalUnion(
+		u.Bool,
+		u.String,
+		u.Int,
+		u.Float64,
+		u.Array,
+		u.Object,
+	)
+}
+
+func (u *GetSchemaResultAdditionalPropertiesSchemaValueEnumItem) UnmarshalJSON(data []byte) error {
+	*u = GetSchemaResultAdditionalPropertiesSchemaValueEnumItem{}
+	return mgcHelpers.UnmarshalUnion(
+		data,
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"boolean\"}", Target: &u.Bool},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"string\"}", Target: &u.String},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"integer\"}", Target: &u.Int},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"number\"}", Target: &u.Float64},
+		mgcHelpers.UnionVariant{Schema: "{\"items\":{},\"type\":\"array\"}", Target: &u.Array},
+		mgcHelpers.UnionVariant{Schema: "{\"additionalProperties\":true,\"type\":\"object\"}", Target: &u.Object},
+	)
+}
 
 type GetSchemaResultAdditionalPropertiesSchemaValueEnumItem4 []any
 
@@ -120,7 +230,62 @@ type GetSchemaResultAdditionalPropertiesSchemaValueEnumItem5 struct {
 type GetSchemaResultAdditionalPropertiesSchemaValueEnum []*GetSchemaResultAdditionalPropertiesSchemaValueEnumItem
 
 // any of: *bool, *string, *float64, *int, *GetSchemaResultAdditionalPropertiesSchemaValueExample4, *GetSchemaResultAdditionalPropertiesSchemaValueExample5
-type GetSchemaResultAdditionalPropertiesSchemaValueExample any
+type GetSchemaResultAdditionalPropertiesSchemaValueExample struct {
+	Bool    *bool
+	String  *string
+	Int     *int
+	Float64 *float64
+	Array   *GetSchemaResultAdditionalPropertiesSchemaValueExample4
+	Object  *GetSchemaResultAdditionalPropertiesSchemaValueExample5
+}
+
+func NewGetSchemaResultAdditionalPropertiesSchemaValueExampleBool(value bool) GetSchemaResultAdditionalPropertiesSchemaValueExample {
+	return GetSchemaResultAdditionalPropertiesSchemaValueExample{Bool: &value}
+}
+
+func NewGetSchemaResultAdditionalPropertiesSchemaValueExampleString(value string) GetSchemaResultAdditionalPropertiesSchemaValueExample {
+	return GetSchemaResultAdditionalPropertiesSchemaValueExample{String: &value}
+}
+
+func NewGetSchemaResultAdditionalPropertiesSchemaValueExampleInt(value int) GetSchemaResultAdditionalPropertiesSchemaValueExample {
+	return GetSchemaResultAdditionalPropertiesSchemaValueExample{Int: &value}
+}
+
+func NewGetSchemaResultAdditionalPropertiesSchemaValueExampleFloat64(value float64) GetSchemaResultAdditionalPropertiesSchemaValueExample {
+	return GetSchemaResultAdditionalPropertiesSchemaValueExample{Float64: &value}
+}
+
+func NewGetSchemaResultAdditionalPropertiesSchemaValueExampleArray(value GetSchemaResultAdditionalPropertiesSchemaValueExample4) GetSchemaResultAdditionalPropertiesSchemaValueExample {
+	return GetSchemaResultAdditionalPropertiesSchemaValueExample{Array: &value}
+}
+
+func NewGetSchemaResultAdditionalPropertiesSchemaValueExampleObject(value GetSchemaResultAdditionalPropertiesSchemaValueExample5) GetSchemaResultAdditionalPropertiesSchemaValueExample {
+	return GetSchemaResultAdditionalPropertiesSchemaValueExample{Object: &value}
+}
+
+func (u GetSchemaResultAdditionalPropertiesSchemaValueExample) MarshalJSON() ([]byte, error) {
+	return mgcHelpers.MarshalUnion(
+		u.Bool,
+		u.String,
+		u.Int,
+		u.Float64,
+		u.Array,
+		u.Object,
+	)
+}
+
+func (u *GetSchemaResultAdditionalPropertiesSchemaValueExample) UnmarshalJSON(data []byte) error {
+	*u = GetSchemaResultAdditionalPropertiesSchemaValueExample{}
+	return mgcHelpers.UnmarshalUnion(
+		data,
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"boolean\"}", Target: &u.Bool},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"string\"}", Target: &u.String},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"integer\"}", Target: &u.Int},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"number\"}", Target: &u.Float64},
+		mgcHelpers.UnionVariant{Schema: "{\"items\":{},\"type\":\"array\"}", Target: &u.Array},
+		mgcHelpers.UnionVariant{Schema: "{\"additionalProperties\":true,\"type\":\"object\"}", Target: &u.Object},
+	)
+}
 
 type GetSchemaResultAdditionalPropertiesSchemaValueExample4 []any
 
@@ -37,12 +37,53 @@ type CreateParameters struct {
 }
 
 type CreateParametersParametersItem struct {
-	Name  string                              `json:"name"`
-	Value CreateParametersParametersItemValue `json:"value"`
+	Name  string                               `json:"name"`
+	Value *CreateParametersParametersItemValue `json:"value"`
 }
 
 // any of: *float64, *int, *bool, *string
-type CreateParametersParametersItemValue any
+type CreateParametersParametersItemValue struct {
+	Int     *int
+	Float64 *float64
+	Bool    *bool
+	String  *string
+}
+
+func NewCreateParametersParametersItemValueInt(value int) CreateParametersParametersItemValue {
+	return CreateParametersParametersItemValue{Int: &value}
+}
+
+func NewCreateParametersParametersItemValueFloat64(value float64) CreateParametersParametersItemValue {
+	return CreateParametersParametersItemValue{Float64: &value}
+}
+
+func NewCreateParametersParametersItemValueBool(value bool) CreateParametersParametersItemValue {
+	return CreateParametersParametersItemValue{Bool: &value}
+}
+
+func NewCreateParametersParametersItemValueString(value string) CreateParametersParametersItemValue {
+	return CreateParametersParametersItemValue{String: &value}
+}
+
+func (u CreateParametersParametersItemValue) MarshalJSON() ([]byte, error) {
+	return mgcHelpers.MarshalUnion(
+		u.Int,
+		u.Float64,
+		u.Bool,
+		u.String,
+	)
+}
+
+func (u *CreateParametersParametersItemValue) UnmarshalJSON(data []byte) error {
+	*u = CreateParametersParametersItemValue{}
+	return mgcHelpers.UnmarshalUnion(
+		data,
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"integer\"}", Target: &u.Int},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"number\"}", Target: &u.Float64},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"boolean\"}", Target: &u.Bool},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"string\"}", Target: &u.String},
+	)
+}
 
 type CreateParametersParameters []CreateParametersParametersItem
 
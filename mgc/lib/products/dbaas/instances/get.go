@@ -63,12 +63,53 @@ type GetResultAddressesItem struct {
 type GetResultAddresses []GetResultAddressesItem
 
 type GetResultParametersItem struct {
-	Name  string                       `json:"name"`
-	Value GetResultParametersItemValue `json:"value"`
+	Name  string                        `json:"name"`
+	Value *GetResultParametersItemValue `json:"value"`
 }
 
 // any of: *float64, *int, *bool, *string
-type GetResultParametersItemValue any
+type GetResultParametersItemValue struct {
+	Int     *int
+	Float64 *float64
+	Bool    *bool
+	String  *string
+}
+
+func NewGetResultParametersItemValueInt(value int) GetResultParametersItemValue {
+	return GetResultParametersItemValue{Int: &value}
+}
+
+func NewGetResultParametersItemValueFloat64(value float64) GetResultParametersItemValue {
+	return GetResultParametersItemValue{Float64: &value}
+}
+
+func NewGetResultParametersItemValueBool(value bool) GetResultParametersItemValue {
+	return GetResultParametersItemValue{Bool: &value}
+}
+
+func NewGetResultParametersItemValueString(value string) GetResultParametersItemValue {
+	return GetResultParametersItemValue{String: &value}
+}
+
+func (u GetResultParametersItemValue) MarshalJSON() ([]byte, error) {
+	return mgcHelpers.MarshalUnion(
+		u.Int,
+		u.Float64,
+		u.Bool,
+		u.String,
+	)
+}
+
+func (u *GetResultParametersItemValue) UnmarshalJSON(data []byte) error {
+	*u = GetResultParametersItemValue{}
+	return mgcHelpers.UnmarshalUnion(
+		data,
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"integer\"}", Target: &u.Int},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"number\"}", Target: &u.Float64},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"boolean\"}", Target: &u.Bool},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"string\"}", Target: &u.String},
+	)
+}
 
 type GetResultParameters []GetResultParametersItem
 
@@ -100,8 +141,8 @@ type GetResultReplicasItemAddressesItem struct {
 type GetResultReplicasItemAddresses []GetResultReplicasItemAddressesItem
 
 type GetResultReplicasItemParametersItem struct {
-	Name  string                       `json:"name"`
-	Value GetResultParametersItemValue `json:"value"`
+	Name  string                        `json:"name"`
+	Value *GetResultParametersItemValue `json:"value"`
 }
 
 type GetResultReplicasItemParameters []GetResultReplicasItemParametersItem
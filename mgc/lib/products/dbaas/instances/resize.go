@@ -70,12 +70,53 @@ type ResizeResultAddressesItem struct {
 type ResizeResultAddresses []ResizeResultAddressesItem
 
 type ResizeResultParametersItem struct {
-	Name  string                          `json:"name"`
-	Value ResizeResultParametersItemValue `json:"value"`
+	Name  string                           `json:"name"`
+	Value *ResizeResultParametersItemValue `json:"value"`
 }
 
 // any of: *float64, *int, *bool, *string
-type ResizeResultParametersItemValue any
+type ResizeResultParametersItemValue struct {
+	Int     *int
+	Float64 *float64
+	Bool    *bool
+	String  *string
+}
+
+func NewResizeResultParametersItemValueInt(value int) ResizeResultParametersItemValue {
+	return ResizeResultParametersItemValue{Int: &value}
+}
+
+func NewResizeResultParametersItemValueFloat64(value float64) ResizeResultParametersItemValue {
+	return ResizeResultParametersItemValue{Float64: &value}
+}
+
+func NewResizeResultParametersItemValueBool(value bool) ResizeResultParametersItemValue {
+	return ResizeResultParametersItemValue{Bool: &value}
+}
+
+func NewResizeResultParametersItemValueString(value string) ResizeResultParametersItemValue {
+	return ResizeResultParametersItemValue{String: &value}
+}
+
+func (u ResizeResultParametersItemValue) MarshalJSON() ([]byte, error) {
+	return mgcHelpers.MarshalUnion(
+		u.Int,
+		u.Float64,
+		u.Bool,
+		u.String,
+	)
+}
+
+func (u *ResizeResultParametersItemValue) UnmarshalJSON(data []byte) error {
+	*u = ResizeResultParametersItemValue{}
+	return mgcHelpers.UnmarshalUnion(
+		data,
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"integer\"}", Target: &u.Int},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"number\"}", Target: &u.Float64},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"boolean\"}", Target: &u.Bool},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"string\"}", Target: &u.String},
+	)
+}
 
 type ResizeResultParameters []ResizeResultParametersItem
 
@@ -107,8 +148,8 @@ type ResizeResultReplicasItemAddressesItem struct {
 type ResizeResultReplicasItemAddresses []ResizeResultReplicasItemAddressesItem
 
 type ResizeResultReplicasItemParametersItem struct {
-	Name  string                          `json:"name"`
-	Value ResizeResultParametersItemValue `json:"value"`
+	Name  string                           `json:"name"`
+	Value *ResizeResultParametersItemValue `json:"value"`
 }
 
 type ResizeResultReplicasItemParameters []ResizeResultReplicasItemParametersItem
@@ -65,12 +65,53 @@ type UpdateResultAddressesItem struct {
 type UpdateResultAddresses []UpdateResultAddressesItem
 
 type UpdateResultParametersItem struct {
-	Name  string                          `json:"name"`
-	Value UpdateResultParametersItemValue `json:"value"`
+	Name  string                           `json:"name"`
+	Value *UpdateResultParametersItemValue `json:"value"`
 }
 
 // any of: *float64, *int, *bool, *string
-type UpdateResultParametersItemValue any
+type UpdateResultParametersItemValue struct {
+	Int     *int
+	Float64 *float64
+	Bool    *bool
+	String  *string
+}
+
+func NewUpdateResultParametersItemValueInt(value int) UpdateResultParametersItemValue {
+	return UpdateResultParametersItemValue{Int: &value}
+}
+
+func NewUpdateResultParametersItemValueFloat64(value float64) UpdateResultParametersItemValue {
+	return UpdateResultParametersItemValue{Float64: &value}
+}
+
+func NewUpdateResultParametersItemValueBool(value bool) UpdateResultParametersItemValue {
+	return UpdateResultParametersItemValue{Bool: &value}
+}
+
+func NewUpdateResultParametersItemValueString(value string) UpdateResultParametersItemValue {
+	return UpdateResultParametersItemValue{String: &value}
+}
+
+func (u UpdateResultParametersItemValue) MarshalJSON() ([]byte, error) {
+	return mgcHelpers.MarshalUnion(
+		u.Int,
+		u.Float64,
+		u.Bool,
+		u.String,
+	)
+}
+
+func (u *UpdateResultParametersItemValue) UnmarshalJSON(data []byte) error {
+	*u = UpdateResultParametersItemValue{}
+	return mgcHelpers.UnmarshalUnion(
+		data,
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"integer\"}", Target: &u.Int},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"number\"}", Target: &u.Float64},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"boolean\"}", Target: &u.Bool},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"string\"}", Target: &u.String},
+	)
+}
 
 type UpdateResultParameters []UpdateResultParametersItem
 
@@ -102,8 +143,8 @@ type UpdateResultReplicasItemAddressesItem struct {
 type UpdateResultReplicasItemAddresses []UpdateResultReplicasItemAddressesItem
 
 type UpdateResultReplicasItemParametersItem struct {
-	Name  string                          `json:"name"`
-	Value UpdateResultParametersItemValue `json:"value"`
+	Name  string                           `json:"name"`
+	Value *UpdateResultParametersItemValue `json:"value"`
 }
 
 type UpdateResultReplicasItemParameters []UpdateResultReplicasItemParametersItem
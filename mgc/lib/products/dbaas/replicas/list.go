@@ -88,12 +88,53 @@ type ListResultResultsItemAddressesItem struct {
 type ListResultResultsItemAddresses []ListResultResultsItemAddressesItem
 
 type ListResultResultsItemParametersItem struct {
-	Name  string                                   `json:"name"`
-	Value ListResultResultsItemParametersItemValue `json:"value"`
+	Name  string                                    `json:"name"`
+	Value *ListResultResultsItemParametersItemValue `json:"value"`
 }
 
 // any of: *float64, *int, *bool, *string
-type ListResultResultsItemParametersItemValue any
+type ListResultResultsItemParametersItemValue struct {
+	Int     *int
+	Float64 *float64
+	Bool    *bool
+	String  *string
+}
+
+func NewListResultResultsItemParametersItemValueInt(value int) ListResultResultsItemParametersItemValue {
+	return ListResultResultsItemParametersItemValue{Int: &value}
+}
+
+func NewListResultResultsItemParametersItemValueFloat64(value float64) ListResultResultsItemParametersItemValue {
+	return ListResultResultsItemParametersItemValue{Float64: &value}
+}
+
+func NewListResultResultsItemParametersItemValueBool(value bool) ListResultResultsItemParametersItemValue {
+	return ListResultResultsItemParametersItemValue{Bool: &value}
+}
+
+func NewListResultResultsItemParametersItemValueString(value string) ListResultResultsItemParametersItemValue {
+	return ListResultResultsItemParametersItemValue{String: &value}
+}
+
+func (u ListResultResultsItemParametersItemValue) MarshalJSON() ([]byte, error) {
+	return mgcHelpers.MarshalUnion(
+		u.Int,
+		u.Float64,
+		u.Bool,
+		u.String,
+	)
+}
+
+func (u *ListResultResultsItemParametersItemValue) UnmarshalJSON(data []byte) error {
+	*u = ListResultResultsItemParametersItemValue{}
+	return mgcHelpers.UnmarshalUnion(
+		data,
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"integer\"}", Target: &u.Int},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"number\"}", Target: &u.Float64},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"boolean\"}", Target: &u.Bool},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"string\"}", Target: &u.String},
+	)
+}
 
 type ListResultResultsItemParameters []ListResultResultsItemParametersItem
 
@@ -60,12 +60,53 @@ type StartResultAddressesItem struct {
 type StartResultAddresses []StartResultAddressesItem
 
 type StartResultParametersItem struct {
-	Name  string                         `json:"name"`
-	Value StartResultParametersItemValue `json:"value"`
+	Name  string                          `json:"name"`
+	Value *StartResultParametersItemValue `json:"value"`
 }
 
 // any of: *float64, *int, *bool, *string
-type StartResultParametersItemValue any
+type StartResultParametersItemValue struct {
+	Int     *int
+	Float64 *float64
+	Bool    *bool
+	String  *string
+}
+
+func NewStartResultParametersItemValueInt(value int) StartResultParametersItemValue {
+	return StartResultParametersItemValue{Int: &value}
+}
+
+func NewStartResultParametersItemValueFloat64(value float64) StartResultParametersItemValue {
+	return StartResultParametersItemValue{Float64: &value}
+}
+
+func NewStartResultParametersItemValueBool(value bool) StartResultParametersItemValue {
+	return StartResultParametersItemValue{Bool: &value}
+}
+
+func NewStartResultParametersItemValueString(value string) StartResultParametersItemValue {
+	return StartResultParametersItemValue{String: &value}
+}
+
+func (u StartResultParametersItemValue) MarshalJSON() ([]byte, error) {
+	return mgcHelpers.MarshalUnion(
+		u.Int,
+		u.Float64,
+		u.Bool,
+		u.String,
+	)
+}
+
+func (u *StartResultParametersItemValue) UnmarshalJSON(data []byte) error {
+	*u = StartResultParametersItemValue{}
+	return mgcHelpers.UnmarshalUnion(
+		data,
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"integer\"}", Target: &u.Int},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"number\"}", Target: &u.Float64},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"boolean\"}", Target: &u.Bool},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"string\"}", Target: &u.String},
+	)
+}
 
 type StartResultParameters []StartResultParametersItem
 
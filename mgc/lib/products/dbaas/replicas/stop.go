@@ -60,12 +60,53 @@ type StopResultAddressesItem struct {
 type StopResultAddresses []StopResultAddressesItem
 
 type StopResultParametersItem struct {
-	Name  string                        `json:"name"`
-	Value StopResultParametersItemValue `json:"value"`
+	Name  string                         `json:"name"`
+	Value *StopResultParametersItemValue `json:"value"`
 }
 
 // any of: *float64, *int, *bool, *string
-type StopResultParametersItemValue any
+type StopResultParametersItemValue struct {
+	Int     *int
+	Float64 *float64
+	Bool    *bool
+	String  *string
+}
+
+func NewStopResultParametersItemValueInt(value int) StopResultParametersItemValue {
+	return StopResultParametersItemValue{Int: &value}
+}
+
+func NewStopResultParametersItemValueFloat64(value float64) StopResultParametersItemValue {
+	return StopResultParametersItemValue{Float64: &value}
+}
+
+func NewStopResultParametersItemValueBool(value bool) StopResultParametersItemValue {
+	return StopResultParametersItemValue{Bool: &value}
+}
+
+func NewStopResultParametersItemValueString(value string) StopResultParametersItemValue {
+	return StopResultParametersItemValue{String: &value}
+}
+
+func (u StopResultParametersItemValue) MarshalJSON() ([]byte, error) {
+	return mgcHelpers.MarshalUnion(
+		u.Int,
+		u.Float64,
+		u.Bool,
+		u.String,
+	)
+}
+
+func (u *StopResultParametersItemValue) UnmarshalJSON(data []byte) error {
+	*u = StopResultParametersItemValue{}
+	return mgcHelpers.UnmarshalUnion(
+		data,
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"integer\"}", Target: &u.Int},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"number\"}", Target: &u.Float64},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"boolean\"}", Target: &u.Bool},
+		mgcHelpers.UnionVariant{Schema: "{\"type\":\"string\"}", Target: &u.String},
+	)
+}
 
 type StopResultParameters []StopResultParametersItem
 
@@ -0,0 +1,91 @@
+package blueprint
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"go.uber.org/zap"
+	"magalu.cloud/core"
+	schemaPkg "magalu.cloud/core/schema"
+)
+
+var dryRunResultSchema = schemaPkg.NewObjectSchema(
+	map[string]*schemaPkg.Schema{
+		"steps": schemaPkg.NewArraySchema((*schemaPkg.Schema)(openapi3.NewObjectSchema().WithAnyAdditionalProperties())),
+	},
+	[]string{"steps"},
+)
+
+// dryRunValues prepares the parameters and configs the step target would be given
+func (e *executor) dryRunValues(step *executeStep, jsonPathDocument map[string]any, plan map[string]any) (p core.Parameters, c core.Configs, err error) {
+	if p, err = step.prepareParameters(jsonPathDocument, e.ParametersSchema()); err != nil {
+		plan["error"] = err.Error()
+		return
+	}
+	if c, err = step.prepareConfigs(jsonPathDocument, e.ConfigsSchema()); err != nil {
+		plan["error"] = err.Error()
+		return
+	}
+	plan["parameters"] = p
+	plan["configs"] = c
+	return
+}
+
+// dryRunStep evaluates the step "if", "forEach", parameters and configs without executing its target.
+// Steps that would run are reported without result, so the next ones see them as successful.
+func (e *executor) dryRunStep(step *executeStep, result *executorResult, logger *zap.SugaredLogger) map[string]any {
+	jsonPathDocument := result.jsonPathDocument()
+	plan := map[string]any{
+		"id":     step.Id,
+		"target": step.Target,
+		"run":    false,
+	}
+
+	shouldExecute, err := step.shouldExecute(jsonPathDocument)
+	if err != nil || !shouldExecute {
+		logger.Debugw("dry-run: would skip step", "if", step.IfCondition, "error", err)
+		if err != nil {
+			plan["error"] = err.Error()
+		}
+		result.skip(step)
+		return plan
+	}
+	plan["run"] = true
+
+	if step.forEachJSONPath == nil {
+		p, c, err := e.dryRunValues(step, jsonPathDocument, plan)
+		logger.Debugw("dry-run: would execute step", "parameters", p, "configs", c, "error", err)
+		result.reportResult(step, p, c, nil)
+		return plan
+	}
+
+	items, err := step.forEachItems(jsonPathDocument)
+	if err != nil {
+		logger.Debugw("dry-run: failed to evaluate 'forEach'", "forEach", step.ForEach, "error", err)
+		plan["error"] = err.Error()
+		result.reportResult(step, nil, nil, nil)
+		return plan
+	}
+
+	plans := make([]any, len(items))
+	iterations := make([]*executorIterationResult, len(items))
+	for i, item := range items {
+		itemPlan := map[string]any{"index": i, "item": item}
+		p, c, _ := e.dryRunValues(step, jsonPathDocumentWithItem(jsonPathDocument, i, item), itemPlan)
+		plans[i] = itemPlan
+		iterations[i] = &executorIterationResult{i, item, p, c, nil, nil}
+	}
+	logger.Debugw("dry-run: would execute step for each item", "items", len(items))
+	plan["iterations"] = plans
+	result.reportIterations(step, iterations)
+	return plan
+}
+
+// dryRun reports which steps would run, and with which parameters and configs, without executing any of them.
+// Values that depend on the results of previous steps are unknown, so their queries may fail.
+func (e *executor) dryRun(result *executorResult) core.Result {
+	plans := make([]any, len(e.spec.Steps))
+	for i, step := range e.spec.Steps {
+		logger := e.logger.With("step", step.Id, "target", step.Target)
+		plans[i] = e.dryRunStep(step, result, logger)
+	}
+	return core.NewSimpleResult(result.ResultSource, dryRunResultSchema, map[string]any{"steps": plans})
+}
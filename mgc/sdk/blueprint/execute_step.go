@@ -20,12 +20,17 @@ type executeStep struct {
 	WaitTermination bool              `json:"waitTermination,omitempty"`
 	RetryUntil      *retryUntil       `json:"retryUntil,omitempty"`
 	Check           *checkSpec        `json:"check,omitempty"`
+	OnRollback      *rollbackSpec     `json:"onRollback,omitempty"`
 	Parameters      map[string]string `json:"parameters,omitempty"`
 	Configs         map[string]string `json:"configs,omitempty"`
+	ForEach         string            `json:"forEach,omitempty"`
+	Parallel        bool              `json:"parallel,omitempty"`
+	MaxConcurrency  int               `json:"maxConcurrency,omitempty"`
 
 	// Materialized/Parsed values based on the JSON fields. They are populated in validate():
 
 	ifJSONPath         gval.Evaluable
+	forEachJSONPath    gval.Evaluable
 	executor           core.Executor
 	parametersJSONPath map[string]gval.Evaluable
 	configsJSONPath    map[string]gval.Evaluable
@@ -56,6 +61,19 @@ func (e *executeStep) validate() (err error) {
 		return errors.New("missing target")
 	}
 
+	if e.ForEach != "" {
+		e.forEachJSONPath, err = utils.NewJsonPath(e.ForEach)
+		if err != nil {
+			return &core.ChainedError{Name: "forEach", Err: err}
+		}
+	} else if e.Parallel || e.MaxConcurrency != 0 {
+		return errors.New("parallel and maxConcurrency require forEach")
+	}
+
+	if e.MaxConcurrency < 0 {
+		return &core.ChainedError{Name: "maxConcurrency", Err: fmt.Errorf("must be positive, got %d", e.MaxConcurrency)}
+	}
+
 	if e.parametersJSONPath == nil {
 		m := make(map[string]gval.Evaluable)
 		for k, v := range e.Parameters {
@@ -88,11 +106,22 @@ func (e *executeStep) validate() (err error) {
 		return &core.ChainedError{Name: "check", Err: err}
 	}
 
+	if err = e.OnRollback.validate(); err != nil {
+		return &core.ChainedError{Name: "onRollback", Err: err}
+	}
+
 	return nil
 }
 
 func (e *executeStep) resolve(refResolver *core.BoundRefPathResolver) (err error) {
 	e.executor, err = core.ResolveExecutorPath(refResolver, e.Target)
+	if err != nil {
+		return
+	}
+
+	if err = e.OnRollback.resolve(refResolver); err != nil {
+		return fmt.Errorf("onRollback: %w", err)
+	}
 	return
 }
 
@@ -115,6 +144,38 @@ func (e *executeStep) shouldExecute(jsonPathDocument map[string]any) (bool, erro
 	}
 }
 
+// forEachItems returns the list to iterate, nil if the step doesn't use forEach
+func (e *executeStep) forEachItems(jsonPathDocument map[string]any) ([]any, error) {
+	if e.forEachJSONPath == nil {
+		return nil, nil
+	}
+
+	v, err := e.forEachJSONPath(context.Background(), jsonPathDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	switch items := v.(type) {
+	case nil:
+		return []any{}, nil
+	case []any:
+		return items, nil
+	default:
+		return nil, fmt.Errorf("forEach must evaluate to a list. Got %#v", v)
+	}
+}
+
+// forEachConcurrency is the number of items executed at the same time
+func (e *executeStep) forEachConcurrency(nItems int) int {
+	if !e.Parallel {
+		return 1
+	}
+	if e.MaxConcurrency > 0 && e.MaxConcurrency < nItems {
+		return e.MaxConcurrency
+	}
+	return nItems
+}
+
 func (e *executeStep) check(jsonPathDocument map[string]any) error {
 	return e.Check.check(jsonPathDocument)
 }
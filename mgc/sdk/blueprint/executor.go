@@ -2,6 +2,10 @@ package blueprint
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
 
 	"go.uber.org/zap"
 	"magalu.cloud/core"
@@ -99,27 +103,16 @@ func (e *executor) HiddenFlags() []string {
 	}
 	return e.spec.HiddenFlags
 }
-func (e *executor) executeStep(
-	step *executeStep,
-	result *executorResult,
-) (err error) {
-	jsonPathDocument := result.jsonPathDocument()
-	logger := e.logger.With(
-		"step", step.Id,
-		"target", step.Target,
-		"jsonPathDocument", jsonPathDocument,
-	)
 
-	if shouldExecute, err := step.shouldExecute(jsonPathDocument); err != nil {
-		logger.Warnw("failed to evaluate 'if' condition", "if", step.IfCondition)
-		return err
-	} else if !shouldExecute {
-		logger.Debugw("skipping execution", "if", step.IfCondition)
-		result.skip(step)
-		return nil
-	}
-
-	p, err := step.prepareParameters(jsonPathDocument, e.ParametersSchema())
+// runStep prepares the parameters and configs from the document, then executes the step target and checks its result.
+// Failures to prepare are returned as err, while execution failures are returned as execErr to be reported
+func (e *executor) runStep(
+	ctx context.Context,
+	step *executeStep,
+	jsonPathDocument map[string]any,
+	logger *zap.SugaredLogger,
+) (p core.Parameters, c core.Configs, execResult core.Result, execErr error, err error) {
+	p, err = step.prepareParameters(jsonPathDocument, e.ParametersSchema())
 	if err != nil {
 		logger.Warnw(
 			"failed to get step parameters",
@@ -127,10 +120,10 @@ func (e *executor) executeStep(
 			"stepSchema", step.executor.ParametersSchema(),
 			"outerSchema", e.ParametersSchema(),
 		)
-		return err
+		return
 	}
 
-	c, err := step.prepareConfigs(jsonPathDocument, e.ConfigsSchema())
+	c, err = step.prepareConfigs(jsonPathDocument, e.ConfigsSchema())
 	if err != nil {
 		logger.Warnw(
 			"failed to get step configs",
@@ -138,7 +131,7 @@ func (e *executor) executeStep(
 			"stepSchema", step.executor.ConfigsSchema(),
 			"outerSchema", e.ConfigsSchema(),
 		)
-		return err
+		return
 	}
 
 	logger = logger.With("parameters", p, "configs", c)
@@ -147,7 +140,6 @@ func (e *executor) executeStep(
 	}
 
 	var cb core.RetryUntilCb
-	ctx := result.Context
 	if tExec, ok := core.ExecutorAs[core.TerminatorExecutor](step.executor); ok && step.WaitTermination {
 		cb = func() (result core.Result, err error) {
 			logger.Debugw("execute step (waitTermination)")
@@ -161,43 +153,203 @@ func (e *executor) executeStep(
 	}
 
 	// retryUntil.run() is a safe nil pointer receiver, will execute only once without checks in that case
-	execResult, execErr := step.RetryUntil.run(ctx, cb, func(value core.Value) map[string]any {
-		return result.jsonPathDocumentWithCurrent(step, p, c, value)
+	execResult, execErr = step.RetryUntil.run(ctx, cb, func(value core.Value) map[string]any {
+		return jsonPathDocumentWithCurrent(jsonPathDocument, step, p, c, value)
 	})
 
 	if execErr != nil {
 		logger.Debugw("failed step", "error", execErr)
-		result.reportError(step, p, c, execErr)
-	} else {
-		logger.Debugw("finished step", "result", execResult)
+		return
+	}
 
-		var v any
-		if vResult, ok := core.ResultAs[core.ResultWithValue](execResult); ok {
-			v = vResult.Value()
+	logger.Debugw("finished step", "result", execResult)
+
+	var v any
+	if vResult, ok := core.ResultAs[core.ResultWithValue](execResult); ok {
+		v = vResult.Value()
+	}
+
+	execErr = step.check(jsonPathDocumentWithCurrent(jsonPathDocument, step, p, c, v))
+	if execErr != nil {
+		logger.Debugw("failed step check", "error", execErr)
+		execResult = nil
+	}
+	return
+}
+
+// executeStepForEach runs the step target once per item, at most step.forEachConcurrency() at a time
+func (e *executor) executeStepForEach(
+	step *executeStep,
+	items []any,
+	result *executorResult,
+	jsonPathDocument map[string]any,
+	logger *zap.SugaredLogger,
+) error {
+	iterations := make([]*executorIterationResult, len(items))
+	errs := make([]error, len(items))
+
+	runItem := func(i int) {
+		doc := jsonPathDocumentWithItem(jsonPathDocument, i, items[i])
+		p, c, execResult, execErr, err := e.runStep(result.Context, step, doc, logger.With("index", i))
+		if err != nil {
+			errs[i] = fmt.Errorf("item %d: %w", i, err)
+			return
 		}
+		iterations[i] = &executorIterationResult{i, items[i], p, c, execResult, execErr}
+	}
+
+	semaphore := make(chan struct{}, max(step.forEachConcurrency(len(items)), 1))
+	var wg sync.WaitGroup
+	for i := range items {
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			runItem(i)
+		}()
+	}
+	wg.Wait()
+
+	// report the finished iterations even if others couldn't start, so they are rolled back
+	result.reportIterations(step, slices.DeleteFunc(iterations, func(it *executorIterationResult) bool { return it == nil }))
+	return errors.Join(errs...)
+}
+
+func (e *executor) executeStep(
+	step *executeStep,
+	result *executorResult,
+) (err error) {
+	jsonPathDocument := result.jsonPathDocument()
+	logger := e.logger.With(
+		"step", step.Id,
+		"target", step.Target,
+		"jsonPathDocument", jsonPathDocument,
+	)
+
+	if shouldExecute, err := step.shouldExecute(jsonPathDocument); err != nil {
+		logger.Warnw("failed to evaluate 'if' condition", "if", step.IfCondition)
+		return err
+	} else if !shouldExecute {
+		logger.Debugw("skipping execution", "if", step.IfCondition)
+		result.skip(step)
+		return nil
+	}
 
-		execErr = step.check(result.jsonPathDocumentWithCurrent(step, p, c, v))
-		if execErr != nil {
-			logger.Debugw("failed step check", "error", execErr)
-			result.reportError(step, p, c, execErr)
-		} else {
-			result.reportResult(step, p, c, execResult)
+	if step.forEachJSONPath != nil {
+		items, err := step.forEachItems(jsonPathDocument)
+		if err != nil {
+			logger.Warnw("failed to evaluate 'forEach'", "forEach", step.ForEach)
+			return err
 		}
+		logger.Debugw("execute step for each item", "items", len(items), "concurrency", step.forEachConcurrency(len(items)))
+		return e.executeStepForEach(step, items, result, jsonPathDocument, logger)
+	}
+
+	p, c, execResult, execErr, err := e.runStep(result.Context, step, jsonPathDocument, logger)
+	if err != nil {
+		return err
+	}
+
+	if execErr != nil {
+		result.reportError(step, p, c, execErr)
+	} else {
+		result.reportResult(step, p, c, execResult)
 	}
 	return nil // regardless of execErr as we want to register errors and let further steps to handle them
 }
 
-func (e *executor) Execute(
+func (e *executor) rollbackStep(
 	ctx context.Context,
+	step *executeStep,
+	index *int,
+	jsonPathDocument map[string]any,
 	parameters core.Parameters,
 	configs core.Configs,
-) (r core.Result, err error) {
-	err = e.resolve()
-	if err != nil {
-		return
+	stepResult core.Result,
+) *executorRollbackResult {
+	rollback := &executorRollbackResult{Step: step, Index: index}
+	logger := e.logger.With("step", step.Id, "rollbackTarget", step.OnRollback.Target)
+	if index != nil {
+		logger = logger.With("index", *index)
 	}
 
-	result := &executorResult{
+	// the step being rolled back is the current one, as in retryUntil and check
+	doc := jsonPathDocumentWithCurrent(jsonPathDocument, step, parameters, configs, getResultValueJsonPathDocument(stepResult))
+
+	rollback.Parameters, rollback.Err = prepareMapFromRules(doc, step.OnRollback.parametersJSONPath)
+	if rollback.Err != nil {
+		logger.Warnw("failed to get rollback parameters", "parameters", step.OnRollback.Parameters, "error", rollback.Err)
+		return rollback
+	}
+
+	// by default, undo with the same configs, such as the region
+	rollback.Configs = configs
+	if len(step.OnRollback.configsJSONPath) > 0 {
+		rollback.Configs, rollback.Err = prepareMapFromRules(doc, step.OnRollback.configsJSONPath)
+		if rollback.Err != nil {
+			logger.Warnw("failed to get rollback configs", "configs", step.OnRollback.Configs, "error", rollback.Err)
+			return rollback
+		}
+	}
+
+	logger.Debugw("rollback step", "parameters", rollback.Parameters, "configs", rollback.Configs)
+	rollback.Result, rollback.Err = step.OnRollback.executor.Execute(ctx, rollback.Parameters, rollback.Configs)
+	if rollback.Err != nil {
+		logger.Warnw("failed to rollback step", "error", rollback.Err)
+	}
+	return rollback
+}
+
+// rollback undoes the steps that succeeded, in reverse order, if the executor enables it.
+// The original error is returned wrapped with the rollback outcomes, if any.
+func (e *executor) rollback(result *executorResult, err error) error {
+	if !e.spec.Rollback {
+		return err
+	}
+
+	// rollback even if the execution was canceled or timed out
+	ctx := context.WithoutCancel(result.Context)
+
+	for i := len(result.Steps) - 1; i >= 0; i-- {
+		stepResult := result.Steps[i]
+		step := stepResult.Step
+		if step.OnRollback == nil || stepResult.Skipped {
+			continue
+		}
+
+		if stepResult.Iterations == nil {
+			if stepResult.Err == nil {
+				result.reportRollback(e.rollbackStep(ctx, step, nil, result.jsonPathDocument(), stepResult.Parameters, stepResult.Configs, stepResult.Result))
+			}
+			continue
+		}
+
+		// forEach steps may have failed only some items, undo the others
+		for j := len(stepResult.Iterations) - 1; j >= 0; j-- {
+			iteration := stepResult.Iterations[j]
+			if iteration.Err != nil {
+				continue
+			}
+			doc := jsonPathDocumentWithItem(result.jsonPathDocument(), iteration.Index, iteration.Item)
+			result.reportRollback(e.rollbackStep(ctx, step, &iteration.Index, doc, iteration.Parameters, iteration.Configs, iteration.Result))
+		}
+	}
+
+	if len(result.Rollbacks) == 0 {
+		return err
+	}
+	return &rollbackError{Err: err, Rollbacks: result.Rollbacks}
+}
+
+func (e *executor) newResult(
+	ctx context.Context,
+	parameters core.Parameters,
+	configs core.Configs,
+) *executorResult {
+	return &executorResult{
 		ResultSource: core.ResultSource{
 			Executor:   e,
 			Context:    ctx,
@@ -208,15 +360,44 @@ func (e *executor) Execute(
 		Logger:         e.logger,
 		ResultJsonPath: e.spec.Result,
 	}
+}
+
+// steps are planned instead of executed in dry-run mode
+func (e *executor) SupportsDryRun() bool {
+	return true
+}
+
+func (e *executor) Execute(
+	ctx context.Context,
+	parameters core.Parameters,
+	configs core.Configs,
+) (r core.Result, err error) {
+	err = e.resolve()
+	if err != nil {
+		return
+	}
+
+	result := e.newResult(ctx, parameters, configs)
+	if core.IsDryRun(ctx) {
+		return e.dryRun(result), nil
+	}
+
+	return e.execute(result)
+}
 
+// execute runs the steps, result keeps their outcome even on failures
+func (e *executor) execute(result *executorResult) (r core.Result, err error) {
 	for _, step := range e.spec.Steps {
 		err = e.executeStep(step, result)
 		if err != nil {
-			return nil, err
+			return nil, e.rollback(result, err)
 		}
 	}
 
 	r, err = result.finalize()
+	if err != nil {
+		err = e.rollback(result, err)
+	}
 	if e.spec.OutputFlag != "" {
 		if resultWithValue, ok := core.ResultAs[core.ResultWithValue](result); ok {
 			r = core.NewResultWithOriginalSource(r.Source(), core.NewResultWithDefaultOutputOptions(resultWithValue, e.spec.OutputFlag))
@@ -241,3 +422,4 @@ func (e *executor) EmptyResult() core.Result {
 }
 
 var _ core.Executor = (*executor)(nil)
+var _ core.DryRunExecutor = (*executor)(nil)
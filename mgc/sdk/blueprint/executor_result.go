@@ -20,6 +20,16 @@ type executorStepResult struct {
 	Result     core.Result
 	Err        error
 	Skipped    bool
+	Iterations []*executorIterationResult // only for forEach steps
+}
+
+type executorIterationResult struct {
+	Index      int
+	Item       any
+	Parameters core.Parameters
+	Configs    core.Configs
+	Result     core.Result
+	Err        error
 }
 
 type executorResult struct {
@@ -28,6 +38,7 @@ type executorResult struct {
 	Logger         *zap.SugaredLogger
 	ResultJsonPath string
 	ResultValue    core.Value
+	Rollbacks      []*executorRollbackResult
 
 	// these are populated by jsonPathDocument:
 
@@ -193,15 +204,41 @@ func (r *executorResult) finalize() (result core.ResultWithValue, err error) {
 }
 
 func (r *executorResult) reportResult(step *executeStep, parameters core.Parameters, configs core.Configs, result core.Result) {
-	r.Steps = append(r.Steps, &executorStepResult{step, parameters, configs, result, nil, false})
+	r.Steps = append(r.Steps, &executorStepResult{step, parameters, configs, result, nil, false, nil})
 }
 
 func (r *executorResult) reportError(step *executeStep, parameters core.Parameters, configs core.Configs, err error) {
-	r.Steps = append(r.Steps, &executorStepResult{step, parameters, configs, nil, err, false})
+	r.Steps = append(r.Steps, &executorStepResult{step, parameters, configs, nil, err, false, nil})
+}
+
+// reportIterations registers a forEach step, it fails if any of its iterations failed
+func (r *executorResult) reportIterations(step *executeStep, iterations []*executorIterationResult) {
+	var errs utils.MultiError
+	for _, iteration := range iterations {
+		if iteration.Err != nil {
+			errs = append(errs, fmt.Errorf("item %d: %w", iteration.Index, iteration.Err))
+		}
+	}
+
+	var err error
+	if len(errs) > 0 {
+		err = errs
+	}
+	r.Steps = append(r.Steps, &executorStepResult{step, nil, nil, nil, err, false, iterations})
+}
+
+func (r *executorResult) reportRollback(rollback *executorRollbackResult) {
+	r.Rollbacks = append(r.Rollbacks, rollback)
+
+	docs := make([]any, len(r.Rollbacks))
+	for i, rb := range r.Rollbacks {
+		docs[i] = createRollbackResultJsonDocument(rb)
+	}
+	r.jsonPathDocument()["rollback"] = docs
 }
 
 func (r *executorResult) skip(step *executeStep) {
-	r.Steps = append(r.Steps, &executorStepResult{step, nil, nil, nil, nil, true})
+	r.Steps = append(r.Steps, &executorStepResult{step, nil, nil, nil, nil, true, nil})
 }
 
 func getResultValueJsonPathDocument(result core.Result) any {
@@ -224,7 +261,7 @@ func (r *executorResult) initJsonPathDocument() {
 }
 
 func createStepResultJsonDocument(stepResult *executorStepResult) map[string]any {
-	return map[string]any{
+	doc := map[string]any{
 		"id":         stepResult.Step.Id,
 		"parameters": stepResult.Parameters,
 		"configs":    stepResult.Configs,
@@ -232,6 +269,26 @@ func createStepResultJsonDocument(stepResult *executorStepResult) map[string]any
 		"error":      stepResult.Err,
 		"skipped":    stepResult.Skipped,
 	}
+
+	if stepResult.Iterations != nil {
+		results := make([]any, len(stepResult.Iterations))
+		iterations := make([]any, len(stepResult.Iterations))
+		for i, iteration := range stepResult.Iterations {
+			results[i] = getResultValueJsonPathDocument(iteration.Result)
+			iterations[i] = map[string]any{
+				"index":      iteration.Index,
+				"item":       iteration.Item,
+				"parameters": iteration.Parameters,
+				"configs":    iteration.Configs,
+				"result":     results[i],
+				"error":      iteration.Err,
+			}
+		}
+		doc["result"] = results
+		doc["iterations"] = iterations
+	}
+
+	return doc
 }
 
 func (r *executorResult) fillMissingSteps() {
@@ -269,8 +326,15 @@ func (r *executorResult) jsonPathDocumentWithResult() map[string]any {
 	return doc
 }
 
-func (r *executorResult) jsonPathDocumentWithCurrent(step *executeStep, parameters core.Parameters, configs core.Configs, value core.Value) map[string]any {
-	doc := maps.Clone(r.jsonPathDocument())
+func jsonPathDocumentWithItem(jsonPathDocument map[string]any, index int, item any) map[string]any {
+	doc := maps.Clone(jsonPathDocument)
+	doc["index"] = index
+	doc["item"] = item
+	return doc
+}
+
+func jsonPathDocumentWithCurrent(jsonPathDocument map[string]any, step *executeStep, parameters core.Parameters, configs core.Configs, value core.Value) map[string]any {
+	doc := maps.Clone(jsonPathDocument)
 	// mimic the final json document, gives checkers the full context of other steps
 	current := map[string]any{
 		"id":         step.Id,
@@ -41,6 +41,9 @@ type executorSpec struct {
 	// Execution steps, processed in order
 	Steps []*executeStep `json:"steps"`
 
+	// If the execution fails, run the onRollback of the steps that succeeded, in reverse order
+	Rollback bool `json:"rollback,omitempty"`
+
 	// core.Executor extensions:
 
 	Confirm         string                      `json:"confirm,omitempty"`
@@ -172,13 +175,14 @@ func (e *executorSpec) resolve(refResolver *core.BoundRefPathResolver, exec core
 		}
 	}
 
+	// keep resolving the other references, so all problems are reported together
+	var errs utils.MultiError
 	for i, step := range e.Steps {
 		if step.Id == "" {
 			step.Id = fmt.Sprint(i)
 		}
-		err := step.resolve(refResolver)
-		if err != nil {
-			return fmt.Errorf("invalid step %d(id=%q): %w", i, step.Id, err)
+		if err := step.resolve(refResolver); err != nil {
+			errs = append(errs, fmt.Errorf("invalid step %d(id=%q): %w", i, step.Id, err))
 		}
 	}
 
@@ -187,7 +191,7 @@ func (e *executorSpec) resolve(refResolver *core.BoundRefPathResolver, exec core
 		for k, p := range e.Related {
 			e.relatedExecutors[k], err = core.ResolveExecutorPath(refResolver, p)
 			if err != nil {
-				return fmt.Errorf("related %q: %w", k, err)
+				errs = append(errs, fmt.Errorf("related %q: %w", k, err))
 			}
 		}
 	}
@@ -197,12 +201,16 @@ func (e *executorSpec) resolve(refResolver *core.BoundRefPathResolver, exec core
 		for k, v := range e.Links {
 			err = v.resolve(refResolver)
 			if err != nil {
-				return fmt.Errorf("invalid link %q: %w", k, err)
+				errs = append(errs, fmt.Errorf("invalid link %q: %w", k, err))
+				continue
 			}
 			e.linkers[k] = &linker{spec: v, owner: exec}
 		}
 	}
 
+	if len(errs) > 0 {
+		return errs
+	}
 	return nil
 }
 
@@ -0,0 +1,374 @@
+package blueprint
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"magalu.cloud/core"
+	schemaPkg "magalu.cloud/core/schema"
+)
+
+// testLoader loads the blueprint files from memory
+type testLoader map[string]string
+
+func (l testLoader) Load(name string) ([]byte, error) {
+	if data, ok := l[name]; ok {
+		return []byte(data), nil
+	}
+	return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+}
+
+// testRefResolver resolves the targets of the blueprints, unknown paths fail
+type testRefResolver map[core.RefPath]any
+
+func (r testRefResolver) Resolve(path string) (any, error) {
+	return r.ResolvePath(core.RefPath(path))
+}
+
+func (r testRefResolver) ResolvePath(path core.RefPath) (any, error) {
+	if v, ok := r[path]; ok {
+		return v, nil
+	}
+	return nil, fmt.Errorf("unknown path %q", path)
+}
+
+const testIndex = `
+version: 1.0.0
+modules:
+  - name: test
+    description: test blueprints
+    version: 1.0.0
+    url: http://magalu.cloud/test
+    path: test.blueprint.yaml
+`
+
+func newTestLoader(children string) testLoader {
+	return testLoader{
+		indexFileName: testIndex,
+		"test.blueprint.yaml": `
+blueprint: 1.0.0
+name: test
+url: http://magalu.cloud/test
+version: 1.0.0
+description: test blueprints
+children:
+` + children,
+	}
+}
+
+const testForEachChildren = `
+  - name: create-each
+    description: create one resource per id
+    parameters:
+      ids:
+        type: array
+        items: {}
+    configsSchema:
+      type: object
+    resultSchema:
+      type: array
+      items:
+        type: object
+        additionalProperties: true
+    steps:
+      - id: create
+        target: /resource/create
+        forEach: $.parameters.ids
+        parameters:
+          id: $.item.id
+`
+
+func TestExecutorForEach(t *testing.T) {
+	loader := newTestLoader(testForEachChildren)
+
+	tests := []*TestCase{
+		{
+			Name:       "results in item order",
+			Parameters: core.Parameters{"ids": []any{map[string]any{"id": "a"}, map[string]any{"id": "b"}}},
+			Stubs: []*TestStub{
+				{Target: "/resource/create", Parameters: core.Parameters{"id": "a"}, Result: map[string]any{"name": "A"}},
+				{Target: "/resource/create", Parameters: core.Parameters{"id": "b"}, Result: map[string]any{"name": "B"}},
+			},
+			Expect: TestExpectation{Steps: []string{"create"}, Result: []any{map[string]any{"name": "A"}, map[string]any{"name": "B"}}},
+		},
+		{
+			Name:       "no items",
+			Parameters: core.Parameters{"ids": []any{}},
+			Expect:     TestExpectation{Steps: []string{"create"}, Result: []any{}},
+		},
+		{
+			Name:       "fails if any item fails",
+			Parameters: core.Parameters{"ids": []any{map[string]any{"id": "a"}, map[string]any{"id": "b"}, map[string]any{"id": "c"}}},
+			Stubs: []*TestStub{
+				{Target: "/resource/create", Parameters: core.Parameters{"id": "a"}},
+				{Target: "/resource/create", Parameters: core.Parameters{"id": "b"}, Error: "quota exceeded"},
+				{Target: "/resource/create", Parameters: core.Parameters{"id": "c"}},
+			},
+			Expect: TestExpectation{Steps: []string{"create"}, Error: "item 1: quota exceeded"},
+		},
+		{
+			Name:       "finished items are reported if others can't prepare their parameters",
+			Parameters: core.Parameters{"ids": []any{map[string]any{"id": "a"}, map[string]any{}}},
+			Stubs: []*TestStub{
+				{Target: "/resource/create", Parameters: core.Parameters{"id": "a"}},
+			},
+			Expect: TestExpectation{Steps: []string{"create"}, Error: "item 1"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			tc.Executor = "/test/create-each"
+			if err := runTest(context.Background(), loader, nil, tc); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// newTestConcurrencyExecutor counts the calls running at the same time, keeping the maximum
+func newTestConcurrencyExecutor(maxRunning *int) core.Executor {
+	var mu sync.Mutex
+	running := 0
+	return core.NewSimpleExecutor(core.ExecutorSpec{
+		DescriptorSpec:   core.DescriptorSpec{Name: "create", Description: "create"},
+		ParametersSchema: schemaPkg.NewObjectSchema(nil, nil),
+		ConfigsSchema:    schemaPkg.NewObjectSchema(nil, nil),
+		ResultSchema:     schemaPkg.NewAnySchema(),
+		Execute: func(exec core.Executor, ctx context.Context, parameters core.Parameters, configs core.Configs) (core.Result, error) {
+			mu.Lock()
+			running++
+			*maxRunning = max(*maxRunning, running)
+			mu.Unlock()
+
+			time.Sleep(20 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+			source := core.ResultSource{Executor: exec, Context: ctx, Parameters: parameters, Configs: configs}
+			return core.NewSimpleResult(source, exec.ResultSchema(), parameters), nil
+		},
+	})
+}
+
+func TestExecutorForEachConcurrency(t *testing.T) {
+	tests := []struct {
+		name           string
+		parallel       bool
+		maxConcurrency int
+		items          int
+		expected       int
+	}{
+		{name: "sequential by default", items: 3, expected: 1},
+		{name: "parallel", parallel: true, items: 3, expected: 3},
+		{name: "parallel limited", parallel: true, maxConcurrency: 2, items: 5, expected: 2},
+		{name: "limit above the items", parallel: true, maxConcurrency: 10, items: 2, expected: 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			loader := newTestLoader(fmt.Sprintf(`
+  - name: create-each
+    description: create one resource per item
+    parameters:
+      ids:
+        type: array
+        items: {}
+    configsSchema:
+      type: object
+    resultSchema:
+      type: array
+      items:
+        type: object
+        additionalProperties: true
+    steps:
+      - id: create
+        target: /resource/create
+        forEach: $.parameters.ids
+        parallel: %v
+        maxConcurrency: %d
+        parameters:
+          id: $.item
+`, tc.parallel, tc.maxConcurrency))
+			maxRunning := 0
+			resolver := testRefResolver{"/resource/create": newTestConcurrencyExecutor(&maxRunning)}
+			source := NewSource(loader, resolver)
+			exec, err := core.ResolveExecutorPath(core.NewDocumentRefPathResolver(func() (any, error) { return source, nil }), "/test/create-each")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := make([]any, tc.items)
+			expected := make([]any, tc.items)
+			for i := range ids {
+				ids[i] = i
+				expected[i] = map[string]any{"id": i}
+			}
+			result, err := exec.Execute(context.Background(), core.Parameters{"ids": ids}, core.Configs{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if value := result.(core.ResultWithValue).Value(); !equalValues(expected, value) {
+				t.Errorf("expected the results in item order %v, got %v", expected, value)
+			}
+			if maxRunning != tc.expected {
+				t.Errorf("expected %d items running at the same time, got %d", tc.expected, maxRunning)
+			}
+		})
+	}
+}
+
+const testRollbackChildren = `
+  - name: create-network
+    description: create a VPC with its subnets
+    parameters:
+      subnets:
+        type: array
+        items: {}
+    configsSchema:
+      type: object
+    resultSchema:
+      type: object
+      additionalProperties: true
+    rollback: true
+    steps:
+      - id: vpc
+        target: /vpc/create
+        onRollback:
+          target: /vpc/delete
+          parameters:
+            id: $.current.result.id
+      - id: subnets
+        target: /subnet/create
+        forEach: $.parameters.subnets
+        parameters:
+          name: $.item.name
+          vpc_id: $.steps.vpc.result.id
+        onRollback:
+          target: /subnet/delete
+          parameters:
+            id: $.current.result.id
+      - id: attach
+        target: /vpc/attach
+        parameters:
+          id: $.steps.vpc.result.id
+`
+
+func TestExecutorRollback(t *testing.T) {
+	loader := newTestLoader(testRollbackChildren)
+
+	vpc := &TestStub{Target: "/vpc/create", Result: map[string]any{"id": "vpc"}}
+	subnet := func(name string) *TestStub {
+		return &TestStub{Target: "/subnet/create", Parameters: core.Parameters{"name": name, "vpc_id": "vpc"}, Result: map[string]any{"id": name}}
+	}
+	failedSubnet := func(name string) *TestStub {
+		return &TestStub{Target: "/subnet/create", Parameters: core.Parameters{"name": name, "vpc_id": "vpc"}, Error: "no addresses left"}
+	}
+	deleteSubnet := func(name string) *TestStub {
+		return &TestStub{Target: "/subnet/delete", Parameters: core.Parameters{"id": name}}
+	}
+	deleteVpc := &TestStub{Target: "/vpc/delete", Parameters: core.Parameters{"id": "vpc"}}
+	subnets := func(names ...string) core.Parameters {
+		items := make([]any, len(names))
+		for i, name := range names {
+			items[i] = map[string]any{"name": name}
+		}
+		return core.Parameters{"subnets": items}
+	}
+
+	tests := []*TestCase{
+		{
+			Name:       "nothing is rolled back on success",
+			Parameters: subnets("a", "b"),
+			Stubs: []*TestStub{
+				vpc, subnet("a"), subnet("b"),
+				{Target: "/vpc/attach", Result: map[string]any{"status": "attached"}},
+			},
+			Expect: TestExpectation{Steps: []string{"vpc", "subnets", "attach"}, Result: map[string]any{"status": "attached"}},
+		},
+		{
+			Name:       "steps are rolled back in reverse order",
+			Parameters: subnets("a", "b"),
+			Stubs: []*TestStub{
+				vpc, subnet("a"), subnet("b"),
+				{Target: "/vpc/attach", Error: "attach failed"},
+				deleteSubnet("b"), deleteSubnet("a"), deleteVpc,
+			},
+			Expect: TestExpectation{
+				Steps: []string{"vpc", "subnets", "attach"},
+				Error: `attach failed; rolled back "subnets" (item 1); rolled back "subnets" (item 0); rolled back "vpc"`,
+			},
+		},
+		{
+			Name:       "only the items that succeeded are rolled back",
+			Parameters: subnets("a", "b", "c"),
+			Stubs: []*TestStub{
+				vpc, subnet("a"), failedSubnet("b"), subnet("c"),
+				deleteSubnet("c"), deleteSubnet("a"), deleteVpc,
+			},
+			Expect: TestExpectation{
+				Steps: []string{"vpc", "subnets"},
+				Error: `item 1: no addresses left; rolled back "subnets" (item 2); rolled back "subnets" (item 0); rolled back "vpc"`,
+			},
+		},
+		{
+			Name:       "finished items are rolled back if others can't prepare their parameters",
+			Parameters: core.Parameters{"subnets": []any{map[string]any{"name": "a"}, map[string]any{}}},
+			Stubs: []*TestStub{
+				vpc, subnet("a"),
+				deleteSubnet("a"), deleteVpc,
+			},
+			Expect: TestExpectation{
+				Steps: []string{"vpc", "subnets"},
+				Error: `rolled back "subnets" (item 0); rolled back "vpc"`,
+			},
+		},
+		{
+			Name:       "rollback failures don't stop the others",
+			Parameters: subnets("a"),
+			Stubs: []*TestStub{
+				vpc, subnet("a"),
+				{Target: "/vpc/attach", Error: "attach failed"},
+				{Target: "/subnet/delete", Parameters: core.Parameters{"id": "a"}, Error: "subnet in use"},
+				deleteVpc,
+			},
+			Expect: TestExpectation{
+				Steps: []string{"vpc", "subnets", "attach"},
+				Error: `attach failed; rollback of "subnets" (item 0) failed: subnet in use; rolled back "vpc"`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			tc.Executor = "/test/create-network"
+			if err := runTest(context.Background(), loader, nil, tc); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestExecutorDryRun(t *testing.T) {
+	loader := newTestLoader(testForEachChildren)
+	resolver := testRefResolver{"/resource/create": newTestSchemaExecutor("create", schemaPkg.NewObjectSchema(nil, nil), schemaPkg.NewObjectSchema(nil, nil))}
+	source := NewSource(loader, resolver)
+	exec, err := core.ResolveExecutorPath(core.NewDocumentRefPathResolver(func() (any, error) { return source, nil }), "/test/create-each")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := core.NewDryRunContext(context.Background(), true)
+	result, err := exec.Execute(ctx, core.Parameters{"ids": []any{map[string]any{"id": "a"}}}, core.Configs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := result.(core.ResultWithValue).ValidateSchema(); err != nil {
+		t.Errorf("expected the dry-run result to match its schema, got: %v", err)
+	}
+}
@@ -50,17 +50,31 @@ func newDocumentLoader(
 	})
 }
 
-func newModule(
+// addModuleDocument registers the module document in refResolver, so other modules can refer to it
+func addModuleDocument(
 	indexModule *indexModuleSpec,
 	loader dataloader.Loader,
 	logger *zap.SugaredLogger,
 	refResolver *core.MultiRefPathResolver,
-) (m core.Grouper, err error) {
-	logger = logger.Named(indexModule.Name)
-	loadDoc := newDocumentLoader(indexModule, loader, logger)
+) (loadDoc utils.LoadWithError[*document], err error) {
+	loadDoc = newDocumentLoader(indexModule, loader, logger)
 
 	docResolver := core.NewDocumentRefPathResolver(func() (any, error) { return loadDoc() })
 	err = refResolver.Add(indexModule.Url, docResolver)
+	if err != nil {
+		return nil, err
+	}
+	return loadDoc, nil
+}
+
+func newModule(
+	indexModule *indexModuleSpec,
+	loader dataloader.Loader,
+	logger *zap.SugaredLogger,
+	refResolver *core.MultiRefPathResolver,
+) (m core.Grouper, err error) {
+	logger = logger.Named(indexModule.Name)
+	loadDoc, err := addModuleDocument(indexModule, loader, logger, refResolver)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,123 @@
+package blueprint
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/PaesslerAG/gval"
+	"magalu.cloud/core"
+	"magalu.cloud/core/utils"
+)
+
+// rollbackSpec declares how to undo a step that succeeded, such as deleting
+// what it created, if a later step fails and the executor sets "rollback".
+type rollbackSpec struct {
+	Target     core.RefPath      `json:"target"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+	Configs    map[string]string `json:"configs,omitempty"`
+
+	// Materialized/Parsed values based on the JSON fields. They are populated in validate() and resolve():
+
+	executor           core.Executor
+	parametersJSONPath map[string]gval.Evaluable
+	configsJSONPath    map[string]gval.Evaluable
+}
+
+func compileJSONPathRules(rules map[string]string) (m map[string]gval.Evaluable, err error) {
+	m = make(map[string]gval.Evaluable, len(rules))
+	for k, v := range rules {
+		m[k], err = utils.NewJsonPath(v)
+		if err != nil {
+			return nil, &core.ChainedError{Name: k, Err: err}
+		}
+	}
+	return m, nil
+}
+
+// validate is a safe nil pointer receiver, steps without rollback are valid
+func (r *rollbackSpec) validate() (err error) {
+	if r == nil {
+		return nil
+	}
+
+	if r.Target == "" {
+		return errors.New("missing target")
+	}
+
+	if r.parametersJSONPath, err = compileJSONPathRules(r.Parameters); err != nil {
+		return &core.ChainedError{Name: "parameters", Err: err}
+	}
+
+	if r.configsJSONPath, err = compileJSONPathRules(r.Configs); err != nil {
+		return &core.ChainedError{Name: "configs", Err: err}
+	}
+
+	return nil
+}
+
+func (r *rollbackSpec) resolve(refResolver *core.BoundRefPathResolver) (err error) {
+	if r == nil {
+		return nil
+	}
+	r.executor, err = core.ResolveExecutorPath(refResolver, r.Target)
+	return
+}
+
+// executorRollbackResult is the outcome of undoing a step, or one of its forEach items
+type executorRollbackResult struct {
+	Step       *executeStep
+	Index      *int // forEach item, if any
+	Parameters core.Parameters
+	Configs    core.Configs
+	Result     core.Result
+	Err        error
+}
+
+func (r *executorRollbackResult) name() string {
+	if r.Index != nil {
+		return fmt.Sprintf("%q (item %d)", r.Step.Id, *r.Index)
+	}
+	return fmt.Sprintf("%q", r.Step.Id)
+}
+
+func createRollbackResultJsonDocument(r *executorRollbackResult) map[string]any {
+	doc := map[string]any{
+		"id":         r.Step.Id,
+		"target":     r.Step.OnRollback.Target,
+		"parameters": r.Parameters,
+		"configs":    r.Configs,
+		"result":     getResultValueJsonPathDocument(r.Result),
+		"error":      r.Err,
+	}
+	if r.Index != nil {
+		doc["index"] = *r.Index
+	}
+	return doc
+}
+
+// rollbackError is returned when the executor failed and the steps that
+// succeeded before were rolled back. Err is the original failure.
+type rollbackError struct {
+	Err       error
+	Rollbacks []*executorRollbackResult
+}
+
+func (e *rollbackError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.Err.Error())
+	for _, r := range e.Rollbacks {
+		if r.Err != nil {
+			fmt.Fprintf(&sb, "; rollback of %s failed: %s", r.name(), r.Err)
+		} else {
+			fmt.Fprintf(&sb, "; rolled back %s", r.name())
+		}
+	}
+	return sb.String()
+}
+
+func (e *rollbackError) Unwrap() error {
+	return e.Err
+}
+
+var _ error = (*rollbackError)(nil)
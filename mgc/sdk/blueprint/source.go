@@ -22,6 +22,7 @@ type indexFileSpec struct {
 
 const indexFileName = "index.blueprint.yaml"
 const indexVersion = "1.0.0"
+const moduleFilePattern = "*.blueprint.yaml"
 
 // Source -> Module -> Group -> Executor
 
@@ -32,22 +33,82 @@ const (
 	currentUrlPlaceholder = "blueprint"               // replaced with the given CurrentUrl
 )
 
-func NewSource(loader dataloader.Loader, rootRefResolver core.RefPathResolver) core.Grouper {
+func newRefResolver(rootRefResolver core.RefPathResolver) (refResolver *core.MultiRefPathResolver, err error) {
+	refResolver = core.NewMultiRefPathResolver()
+	refResolver.EmptyDocumentUrl = mgcSdkDocumentUrl
+	refResolver.CurrentUrlPlaceholder = currentUrlPlaceholder
+	err = refResolver.Add(mgcSdkDocumentUrl, rootRefResolver)
+	if err != nil {
+		return nil, err
+	}
+	return refResolver, nil
+}
+
+// discoverIndex creates the index from the module files found by globber, as
+// scripts/blueprint_index_gen.py would, so the index doesn't need to be maintained by hand
+func discoverIndex(loader dataloader.Loader, globber dataloader.Globber) (index *indexFileSpec, err error) {
+	names, err := globber.Glob(moduleFilePattern)
+	if err != nil {
+		return
+	}
+
+	index = &indexFileSpec{Version: indexVersion}
+	for _, name := range names {
+		if name == indexFileName {
+			continue
+		}
+
+		data, err := loader.Load(name)
+		if err != nil {
+			return nil, err
+		}
+
+		doc, err := newDocumentFromData(data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load %q: %w", name, err)
+		}
+
+		index.Modules = append(index.Modules, indexModuleSpec{
+			DescriptorSpec: doc.DescriptorSpec,
+			Url:            doc.Url,
+			Path:           name,
+		})
+	}
+	return index, nil
+}
+
+// loadIndex loads the index file. If it doesn't exist and the loader can list
+// its files, the index is discovered from the module files instead
+func loadIndex(loader dataloader.Loader) (index *indexFileSpec, err error) {
+	data, err := loader.Load(indexFileName)
+	if err != nil {
+		if globber, ok := loader.(dataloader.Globber); ok && os.IsNotExist(err) {
+			return discoverIndex(loader, globber)
+		}
+		return
+	}
+
+	index = &indexFileSpec{}
+	err = yaml.Unmarshal(data, index)
+	if err != nil {
+		return nil, err
+	}
+	if index.Version != indexVersion {
+		return nil, fmt.Errorf("unsupported %q version %q, expected %q", indexFileName, index.Version, indexVersion)
+	}
+	return index, nil
+}
+
+func newSource(desc core.DescriptorSpec, loader dataloader.Loader, rootRefResolver core.RefPathResolver) core.Grouper {
 	return core.NewSimpleGrouper(
-		core.DescriptorSpec{
-			Name:        "Blueprints",
-			Description: fmt.Sprintf("Blueprints loaded using %v", loader),
-		},
+		desc,
 		func() (modules []core.Grouper, err error) {
-			refResolver := core.NewMultiRefPathResolver()
-			refResolver.EmptyDocumentUrl = mgcSdkDocumentUrl
-			refResolver.CurrentUrlPlaceholder = currentUrlPlaceholder
-			err = refResolver.Add(mgcSdkDocumentUrl, rootRefResolver)
+			refResolver, err := newRefResolver(rootRefResolver)
 			if err != nil {
 				return
 			}
 
-			data, err := loader.Load(indexFileName)
+			index, err := loadIndex(loader)
 			if err != nil {
 				if os.IsNotExist(err) {
 					// blueprint is not mandatory
@@ -56,15 +117,6 @@ func NewSource(loader dataloader.Loader, rootRefResolver core.RefPathResolver) c
 				return
 			}
 
-			var index indexFileSpec
-			err = yaml.Unmarshal(data, &index)
-			if err != nil {
-				return
-			}
-			if index.Version != indexVersion {
-				return nil, fmt.Errorf("unsupported %q version %q, expected %q", indexFileName, index.Version, indexVersion)
-			}
-
 			modules = make([]core.Grouper, len(index.Modules))
 
 			for i := range index.Modules {
@@ -85,3 +137,29 @@ func NewSource(loader dataloader.Loader, rootRefResolver core.RefPathResolver) c
 		},
 	)
 }
+
+func NewSource(loader dataloader.Loader, rootRefResolver core.RefPathResolver) core.Grouper {
+	return newSource(
+		core.DescriptorSpec{
+			Name:        "Blueprints",
+			Description: fmt.Sprintf("Blueprints loaded using %v", loader),
+		},
+		loader,
+		rootRefResolver,
+	)
+}
+
+// NewPluginSource creates a group named after the plugin, with the blueprint modules
+// found in dir. The index.blueprint.yaml is optional, modules are discovered if it's missing.
+// Plugins have their own document urls, they can't be referred by the other blueprints.
+func NewPluginSource(name string, dir string, rootRefResolver core.RefPathResolver) core.Grouper {
+	return newSource(
+		core.DescriptorSpec{
+			Name:        name,
+			Summary:     fmt.Sprintf("Blueprints of the plugin %q", name),
+			Description: fmt.Sprintf("Blueprints of the plugin %q, loaded from %q", name, dir),
+		},
+		&dataloader.FileLoader{Dir: dir},
+		rootRefResolver,
+	)
+}
@@ -0,0 +1,128 @@
+package blueprint
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"magalu.cloud/core"
+)
+
+// testGlobLoader is a testLoader that can list its files, as the directories of plugins
+type testGlobLoader struct {
+	testLoader
+}
+
+func (l testGlobLoader) Glob(pattern string) (names []string, err error) {
+	for name := range l.testLoader {
+		matched, err := path.Match(pattern, name)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+func newTestModuleFile(name string) string {
+	return `
+blueprint: 1.0.0
+name: ` + name + `
+url: http://magalu.cloud/` + name + `
+version: 1.0.0
+description: ` + name + ` blueprints
+children: []
+`
+}
+
+func TestLoadIndexDiscovery(t *testing.T) {
+	modules := testLoader{
+		"vm.blueprint.yaml":      newTestModuleFile("vm"),
+		"network.blueprint.yaml": newTestModuleFile("network"),
+		"README.md":              "not a module",
+	}
+
+	t.Run("discovered without index", func(t *testing.T) {
+		index, err := loadIndex(testGlobLoader{modules})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if index.Version != indexVersion {
+			t.Errorf("expected version %q, got %q", indexVersion, index.Version)
+		}
+		var got []string
+		for _, m := range index.Modules {
+			got = append(got, m.Name+" "+m.Url+" "+m.Path)
+		}
+		expected := []string{
+			"network http://magalu.cloud/network network.blueprint.yaml",
+			"vm http://magalu.cloud/vm vm.blueprint.yaml",
+		}
+		if !slices.Equal(expected, got) {
+			t.Errorf("expected modules %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("index is used if present", func(t *testing.T) {
+		loader := testGlobLoader{newTestLoader("[]")}
+		loader.testLoader["vm.blueprint.yaml"] = newTestModuleFile("vm")
+		index, err := loadIndex(loader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(index.Modules) != 1 || index.Modules[0].Name != "test" {
+			t.Errorf("expected only the module of the index, got %v", index.Modules)
+		}
+	})
+
+	t.Run("missing index without listing", func(t *testing.T) {
+		if _, err := loadIndex(modules); !os.IsNotExist(err) {
+			t.Errorf("expected not exist error, got %v", err)
+		}
+	})
+
+	t.Run("invalid module", func(t *testing.T) {
+		loader := testGlobLoader{testLoader{"vm.blueprint.yaml": "blueprint: ["}}
+		if _, err := loadIndex(loader); err == nil {
+			t.Error("expected error for invalid module, got none")
+		}
+	})
+}
+
+func TestNewPluginSource(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "vm.blueprint.yaml"), []byte(newTestModuleFile("vm")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	source := NewPluginSource("infra", dir, testRefResolver{})
+	if name := source.Name(); name != "infra" {
+		t.Errorf("expected group named after the plugin, got %q", name)
+	}
+
+	var names []string
+	_, err := source.VisitChildren(func(child core.Descriptor) (bool, error) {
+		names = append(names, child.Name())
+		return true, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal([]string{"vm"}, names) {
+		t.Errorf("expected the discovered modules [vm], got %v", names)
+	}
+
+	empty := NewPluginSource("empty", t.TempDir(), testRefResolver{})
+	_, err = empty.VisitChildren(func(child core.Descriptor) (bool, error) {
+		t.Errorf("unexpected module %q", child.Name())
+		return true, nil
+	})
+	if err != nil {
+		t.Errorf("expected no error for a directory without modules, got %v", err)
+	}
+}
@@ -0,0 +1,304 @@
+package blueprint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/invopop/yaml"
+	"magalu.cloud/core"
+	"magalu.cloud/core/dataloader"
+	schemaPkg "magalu.cloud/core/schema"
+	"magalu.cloud/core/utils"
+)
+
+const TestFileVersion = "1.0.0"
+
+// TestStub replaces one call to the target executor. Calls to the same target
+// consume its stubs in the order they are declared.
+type TestStub struct {
+	Target core.RefPath `json:"target"`
+	// If given, the call must have exactly these parameters
+	Parameters core.Parameters `json:"parameters,omitempty"`
+	Result     core.Value      `json:"result,omitempty"`
+	// If given, the call fails with this message instead of returning Result
+	Error string `json:"error,omitempty"`
+}
+
+type TestExpectation struct {
+	// Identifiers of the steps that must be executed, in order. Skipped steps are not listed
+	Steps []string `json:"steps,omitempty"`
+	// If given, the blueprint result value must be equal to it
+	Result core.Value `json:"result,omitempty"`
+	// If given, the blueprint must fail with an error containing it. Otherwise it must succeed
+	Error string `json:"error,omitempty"`
+}
+
+type TestCase struct {
+	Name string `json:"name"`
+	// JSON Pointer to the blueprint executor, from the modules, ex: /module/group/executor
+	Executor   core.RefPath    `json:"executor"`
+	Parameters core.Parameters `json:"parameters,omitempty"`
+	Configs    core.Configs    `json:"configs,omitempty"`
+	Stubs      []*TestStub     `json:"stubs,omitempty"`
+	Expect     TestExpectation `json:"expect"`
+}
+
+type TestFile struct {
+	Version string      `json:"version"`
+	Tests   []*TestCase `json:"tests"`
+}
+
+type TestResult struct {
+	Name string
+	Err  error
+}
+
+func (f *TestFile) validate() error {
+	if f.Version != TestFileVersion {
+		return fmt.Errorf("expected version %q, got %q", TestFileVersion, f.Version)
+	}
+	if len(f.Tests) == 0 {
+		return errors.New("missing tests")
+	}
+	for i, tc := range f.Tests {
+		if tc.Name == "" {
+			return &core.ChainedError{Name: fmt.Sprint(i), Err: errors.New("missing name")}
+		}
+		if err := tc.Executor.Validate(); err != nil || tc.Executor == "" {
+			return &core.ChainedError{Name: tc.Name, Err: fmt.Errorf("invalid executor %q", tc.Executor)}
+		}
+		for j, stub := range tc.Stubs {
+			if err := stub.Target.Validate(); err != nil || stub.Target == "" {
+				return &core.ChainedError{Name: tc.Name, Err: fmt.Errorf("stub %d: invalid target %q", j, stub.Target)}
+			}
+		}
+	}
+	return nil
+}
+
+// ParseTestFile parses the YAML or JSON test fixtures
+func ParseTestFile(data []byte) (f *TestFile, err error) {
+	f = &TestFile{}
+	err = yaml.Unmarshal(data, f)
+	if err != nil {
+		return nil, err
+	}
+	if err = f.validate(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// normalizeValue converts the value to the types produced by JSON, so values from different sources can be compared
+func normalizeValue(value any) (normalized any, err error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, &normalized)
+	return
+}
+
+func equalValues(a, b any) bool {
+	na, err := normalizeValue(a)
+	if err != nil {
+		return false
+	}
+	nb, err := normalizeValue(b)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(na, nb)
+}
+
+// testStubs is the root reference resolver of the tests. Executors are replaced by stubs,
+// keeping the original schemas if fallback can resolve them, other values come from fallback.
+type testStubs struct {
+	mu        sync.Mutex
+	pending   map[core.RefPath][]*TestStub
+	executors map[core.RefPath]core.Executor
+	failures  utils.MultiError
+	fallback  core.RefPathResolver
+}
+
+var _ core.RefPathResolver = (*testStubs)(nil)
+
+func newTestStubs(stubs []*TestStub, fallback core.RefPathResolver) *testStubs {
+	s := &testStubs{
+		pending:   map[core.RefPath][]*TestStub{},
+		executors: map[core.RefPath]core.Executor{},
+		fallback:  fallback,
+	}
+	for _, stub := range stubs {
+		s.pending[stub.Target] = append(s.pending[stub.Target], stub)
+	}
+	return s
+}
+
+func (s *testStubs) Resolve(path string) (any, error) {
+	return s.ResolvePath(core.RefPath(path))
+}
+
+func (s *testStubs) ResolvePath(path core.RefPath) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if exec, ok := s.executors[path]; ok {
+		return exec, nil
+	}
+
+	var original any
+	var err error
+	if s.fallback != nil {
+		original, err = s.fallback.ResolvePath(path)
+		if _, ok := original.(core.Executor); err == nil && !ok {
+			return original, nil // schemas and other values are kept
+		}
+	}
+	if _, ok := s.pending[path]; !ok && err != nil {
+		return nil, err
+	}
+
+	// executors are always replaced, so the tests never execute them for real
+	spec := core.ExecutorSpec{
+		DescriptorSpec: core.DescriptorSpec{
+			Name:        string(path),
+			Description: fmt.Sprintf("stub of %q", path),
+		},
+		ParametersSchema: schemaPkg.NewObjectSchema(nil, nil),
+		ConfigsSchema:    schemaPkg.NewObjectSchema(nil, nil),
+		ResultSchema:     schemaPkg.NewAnySchema(),
+		Execute: func(exec core.Executor, ctx context.Context, parameters core.Parameters, configs core.Configs) (core.Result, error) {
+			return s.call(exec, ctx, path, parameters, configs)
+		},
+	}
+	// keep the schemas, so the parameters are mapped as they would be
+	if original, ok := original.(core.Executor); ok {
+		spec.ParametersSchema = original.ParametersSchema()
+		spec.ConfigsSchema = original.ConfigsSchema()
+		spec.ResultSchema = original.ResultSchema()
+	}
+
+	exec := core.NewSimpleExecutor(spec)
+	s.executors[path] = exec
+	return exec, nil
+}
+
+func (s *testStubs) call(exec core.Executor, ctx context.Context, path core.RefPath, parameters core.Parameters, configs core.Configs) (core.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stubs := s.pending[path]
+	if len(stubs) == 0 {
+		err := fmt.Errorf("unexpected call to %q with parameters %v", path, parameters)
+		s.failures = append(s.failures, err)
+		return nil, err
+	}
+	stub := stubs[0]
+	s.pending[path] = stubs[1:]
+
+	if stub.Parameters != nil && !equalValues(stub.Parameters, parameters) {
+		err := fmt.Errorf("call to %q: expected parameters %v, got %v", path, stub.Parameters, parameters)
+		s.failures = append(s.failures, err)
+		return nil, err
+	}
+
+	if stub.Error != "" {
+		return nil, errors.New(stub.Error)
+	}
+
+	source := core.ResultSource{Executor: exec, Context: ctx, Parameters: parameters, Configs: configs}
+	return core.NewSimpleResult(source, exec.ResultSchema(), stub.Result), nil
+}
+
+func (s *testStubs) checkAllCalled() {
+	for _, path := range slices.Sorted(maps.Keys(s.pending)) {
+		if stubs := s.pending[path]; len(stubs) > 0 {
+			s.failures = append(s.failures, fmt.Errorf("expected %d more calls to %q", len(stubs), path))
+		}
+	}
+}
+
+func runTest(ctx context.Context, loader dataloader.Loader, rootRefResolver core.RefPathResolver, tc *TestCase) error {
+	stubs := newTestStubs(tc.Stubs, rootRefResolver)
+	source := NewSource(loader, stubs)
+	exec, err := core.ResolveExecutorPath(core.NewDocumentRefPathResolver(func() (any, error) { return source, nil }), tc.Executor)
+	if err != nil {
+		return err
+	}
+
+	e, ok := core.ExecutorAs[*executor](exec)
+	if !ok {
+		return fmt.Errorf("%q is not a blueprint executor", tc.Executor)
+	}
+	if err = e.resolve(); err != nil {
+		return err
+	}
+
+	parameters, configs := tc.Parameters, tc.Configs
+	if parameters == nil {
+		parameters = core.Parameters{}
+	}
+	if configs == nil {
+		configs = core.Configs{}
+	}
+
+	result := e.newResult(ctx, parameters, configs)
+	r, err := e.execute(result)
+
+	stubs.checkAllCalled()
+	failures := stubs.failures
+
+	if tc.Expect.Error == "" && err != nil {
+		failures = append(failures, fmt.Errorf("unexpected error: %w", err))
+	} else if tc.Expect.Error != "" && err == nil {
+		failures = append(failures, fmt.Errorf("expected error containing %q, but it succeeded", tc.Expect.Error))
+	} else if err != nil && !strings.Contains(err.Error(), tc.Expect.Error) {
+		failures = append(failures, fmt.Errorf("expected error containing %q, got: %w", tc.Expect.Error, err))
+	}
+
+	if tc.Expect.Steps != nil {
+		executed := []string{}
+		for _, step := range result.Steps {
+			if !step.Skipped {
+				executed = append(executed, step.Step.Id)
+			}
+		}
+		if !reflect.DeepEqual(tc.Expect.Steps, executed) {
+			failures = append(failures, fmt.Errorf("expected steps %v, got %v", tc.Expect.Steps, executed))
+		}
+	}
+
+	if tc.Expect.Result != nil {
+		var value core.Value
+		if rVal, ok := core.ResultAs[core.ResultWithValue](r); ok {
+			value = rVal.Value()
+		}
+		if !equalValues(tc.Expect.Result, value) {
+			failures = append(failures, fmt.Errorf("expected result %v, got %v", tc.Expect.Result, value))
+		}
+	}
+
+	if len(failures) > 0 {
+		return failures
+	}
+	return nil
+}
+
+// RunTests executes the blueprints from loader, replacing the executors they target with the test stubs.
+// The rootRefResolver provides the schemas of the targets and may be nil, then targets accept any parameters.
+// Calls to targets without stubs fail the test, they are never executed for real.
+func RunTests(ctx context.Context, loader dataloader.Loader, rootRefResolver core.RefPathResolver, f *TestFile) []*TestResult {
+	results := make([]*TestResult, len(f.Tests))
+	for i, tc := range f.Tests {
+		results[i] = &TestResult{Name: tc.Name, Err: runTest(ctx, loader, rootRefResolver, tc)}
+	}
+	return results
+}
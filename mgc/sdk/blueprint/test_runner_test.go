@@ -0,0 +1,103 @@
+package blueprint
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"magalu.cloud/core"
+)
+
+func TestRunTestFailures(t *testing.T) {
+	loader := newTestLoader(testForEachChildren)
+	twoIds := core.Parameters{"ids": []any{map[string]any{"id": "a"}, map[string]any{"id": "b"}}}
+
+	tests := []struct {
+		tc       *TestCase
+		expected []string
+	}{
+		{
+			tc: &TestCase{
+				Name:       "stubs consumed in order",
+				Parameters: twoIds,
+				Stubs: []*TestStub{
+					{Target: "/resource/create", Parameters: core.Parameters{"id": "a"}},
+					{Target: "/resource/create", Parameters: core.Parameters{"id": "b"}},
+				},
+				Expect: TestExpectation{Steps: []string{"create"}},
+			},
+		},
+		{
+			tc: &TestCase{
+				Name:       "stubs out of order",
+				Parameters: twoIds,
+				Stubs: []*TestStub{
+					{Target: "/resource/create", Parameters: core.Parameters{"id": "b"}},
+					{Target: "/resource/create", Parameters: core.Parameters{"id": "a"}},
+				},
+				Expect: TestExpectation{Steps: []string{"create"}, Error: "item 0"},
+			},
+			expected: []string{
+				`call to "/resource/create": expected parameters map[id:b], got map[id:a]`,
+				`call to "/resource/create": expected parameters map[id:a], got map[id:b]`,
+			},
+		},
+		{
+			tc: &TestCase{
+				Name:       "parameters mismatch",
+				Parameters: core.Parameters{"ids": []any{map[string]any{"id": "a"}}},
+				Stubs: []*TestStub{
+					{Target: "/resource/create", Parameters: core.Parameters{"id": "a", "size": 1}},
+				},
+			},
+			expected: []string{
+				`call to "/resource/create": expected parameters map[id:a size:1], got map[id:a]`,
+				"unexpected error",
+			},
+		},
+		{
+			tc: &TestCase{
+				Name:       "unconsumed stubs",
+				Parameters: core.Parameters{"ids": []any{map[string]any{"id": "a"}}},
+				Stubs: []*TestStub{
+					{Target: "/resource/create", Parameters: core.Parameters{"id": "a"}},
+					{Target: "/resource/create", Parameters: core.Parameters{"id": "b"}},
+					{Target: "/resource/delete"},
+				},
+			},
+			expected: []string{
+				`expected 1 more calls to "/resource/create"`,
+				`expected 1 more calls to "/resource/delete"`,
+			},
+		},
+		{
+			tc: &TestCase{
+				Name:       "call without stub",
+				Parameters: core.Parameters{"ids": []any{map[string]any{"id": "a"}}},
+				Expect:     TestExpectation{Error: "unexpected call"},
+			},
+			expected: []string{`unexpected call to "/resource/create" with parameters map[id:a]`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tc.Name, func(t *testing.T) {
+			tt.tc.Executor = "/test/create-each"
+			err := runTest(context.Background(), loader, nil, tt.tc)
+			if len(tt.expected) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected failures %v, got none", tt.expected)
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected failure containing %q, got: %v", expected, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package blueprint
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	"go.uber.org/zap"
+	"magalu.cloud/core"
+	"magalu.cloud/core/dataloader"
+	schemaPkg "magalu.cloud/core/schema"
+	"magalu.cloud/core/utils"
+)
+
+// matches references to the document properties, ex: $.parameters.id, $.steps["create"].result
+var jsonPathDocumentRefRe = regexp.MustCompile(`\$\.(parameters|configs|steps)(?:\.(\w+)|\["([^"]*)"\])`)
+
+// Validate loads the blueprint index and every module, then creates all of their
+// children and resolves all references, such as step targets, links and schemas.
+// Step parameters and configs are checked against the target schemas, as well as
+// the parameters, configs and steps referenced by their JSON Paths.
+//
+// All problems are returned as a utils.MultiError, or nil if there are none.
+func Validate(loader dataloader.Loader, rootRefResolver core.RefPathResolver) error {
+	index, err := loadIndex(loader)
+	if err != nil {
+		return err
+	}
+
+	refResolver, err := newRefResolver(rootRefResolver)
+	if err != nil {
+		return err
+	}
+
+	// problems are returned, do not log them as well
+	logger := zap.NewNop().Sugar()
+
+	// all modules must be known before resolving, as they may refer to each other
+	loadDocs := make([]utils.LoadWithError[*document], len(index.Modules))
+	for i := range index.Modules {
+		loadDocs[i], err = addModuleDocument(&index.Modules[i], loader, logger, refResolver)
+		if err != nil {
+			return err
+		}
+	}
+
+	var errs utils.MultiError
+	for i, indexModule := range index.Modules {
+		doc, err := loadDocs[i]()
+		if err != nil {
+			errs = append(errs, &core.ChainedError{Name: indexModule.Name, Err: err})
+			continue
+		}
+
+		boundRefResolver := core.NewBoundRefResolver(indexModule.Url, refResolver)
+		for _, err := range validateChildren(doc.Children, logger, boundRefResolver) {
+			errs = append(errs, &core.ChainedError{Name: indexModule.Name, Err: err})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateChildren creates each child, unlike createGroupChildren that ignores the failures
+func validateChildren(specs []*childSpec, logger *zap.SugaredLogger, refResolver *core.BoundRefPathResolver) (errs []error) {
+	for _, spec := range specs {
+		name := spec.Name
+		if name == "" {
+			name = spec.Ref
+		}
+
+		child, err := newChild(spec, logger, refResolver)
+		if err != nil {
+			errs = append(errs, &core.ChainedError{Name: name, Err: err})
+			continue
+		}
+
+		var childErrs []error
+		switch child := child.(type) {
+		case core.Grouper:
+			childErrs = validateChildren(spec.Children, logger.Named(spec.Name), refResolver)
+		case core.Executor:
+			if exec, ok := core.ExecutorAs[*executor](child); ok {
+				childErrs = exec.lint()
+			}
+		}
+
+		for _, err := range childErrs {
+			errs = append(errs, &core.ChainedError{Name: spec.Name, Err: err})
+		}
+	}
+	return
+}
+
+func hasSchemaProperty(schema *core.Schema, name string) bool {
+	if _, ok := schema.Properties[name]; ok || len(schema.Properties) == 0 {
+		return true
+	}
+	additional := schema.AdditionalProperties
+	return (additional.Has != nil && *additional.Has) || additional.Schema != nil
+}
+
+// lintJSONPathRefs checks the parameters, configs and steps referenced by the expression exist.
+// Steps must be in knownSteps, the ones executed before.
+func (e *executor) lintJSONPathRefs(expression string, knownSteps []string) error {
+	for _, m := range jsonPathDocumentRefRe.FindAllStringSubmatch(expression, -1) {
+		kind, name := m[1], m[2]
+		if name == "" {
+			name = m[3]
+		}
+
+		switch kind {
+		case "parameters":
+			if !hasSchemaProperty(e.ParametersSchema(), name) {
+				return fmt.Errorf("unknown parameter %q", name)
+			}
+		case "configs":
+			if !hasSchemaProperty(e.ConfigsSchema(), name) {
+				return fmt.Errorf("unknown config %q", name)
+			}
+		case "steps":
+			if !slices.Contains(knownSteps, name) {
+				return fmt.Errorf("unknown step %q, expected one of the previous steps %v", name, knownSteps)
+			}
+		}
+	}
+	return nil
+}
+
+func (e *executor) lintRules(rules map[string]string, knownSteps []string) (errs []error) {
+	for _, k := range slices.Sorted(maps.Keys(rules)) {
+		if err := e.lintJSONPathRefs(rules[k], knownSteps); err != nil {
+			errs = append(errs, &core.ChainedError{Name: k, Err: err})
+		}
+	}
+	return
+}
+
+// lintMapping checks that the rules, or the blueprint schema if there are no rules, provide the target properties
+func lintMapping(rules map[string]string, targetSchema *core.Schema, outerSchema *core.Schema) (errs []error) {
+	if len(rules) == 0 {
+		for _, k := range slices.Sorted(maps.Keys(targetSchema.Properties)) {
+			wantRef := targetSchema.Properties[k]
+			providesRef, ok := outerSchema.Properties[k]
+			if !ok {
+				if slices.Contains(targetSchema.Required, k) && wantRef.Value.Default == nil {
+					errs = append(errs, fmt.Errorf("required property %q missing in blueprint schema. Specify a manual JSON Path mapping", k))
+				}
+				continue
+			}
+			if !schemaPkg.CheckSimilarJsonSchemas((*core.Schema)(wantRef.Value), (*core.Schema)(providesRef.Value)) {
+				errs = append(errs, fmt.Errorf("required property %q has different schemas. Specify a manual JSON Path mapping", k))
+			}
+		}
+		return
+	}
+
+	return lintRulesMapping(rules, targetSchema)
+}
+
+// lintRulesMapping checks the rules map known properties and all the required ones
+func lintRulesMapping(rules map[string]string, targetSchema *core.Schema) (errs []error) {
+	for _, k := range slices.Sorted(maps.Keys(rules)) {
+		if !hasSchemaProperty(targetSchema, k) {
+			errs = append(errs, &core.ChainedError{Name: k, Err: fmt.Errorf("unknown property, expected one of %v", slices.Sorted(maps.Keys(targetSchema.Properties)))})
+		}
+	}
+	for _, k := range targetSchema.Required {
+		if _, ok := rules[k]; !ok && targetSchema.Properties[k] != nil && targetSchema.Properties[k].Value.Default == nil {
+			errs = append(errs, fmt.Errorf("required property %q is not mapped", k))
+		}
+	}
+	return
+}
+
+func chainErrors(name string, errs []error) []error {
+	for i, err := range errs {
+		errs[i] = &core.ChainedError{Name: name, Err: err}
+	}
+	return errs
+}
+
+func (e *executor) lintStep(step *executeStep, knownSteps []string) (errs []error) {
+	if step.IfCondition != defaultIfJSONPathText {
+		if err := e.lintJSONPathRefs(step.IfCondition, knownSteps); err != nil {
+			errs = append(errs, &core.ChainedError{Name: "if", Err: err})
+		}
+	}
+
+	if err := e.lintJSONPathRefs(step.ForEach, knownSteps); err != nil {
+		errs = append(errs, &core.ChainedError{Name: "forEach", Err: err})
+	}
+
+	errs = append(errs, chainErrors("parameters", e.lintRules(step.Parameters, knownSteps))...)
+	errs = append(errs, chainErrors("configs", e.lintRules(step.Configs, knownSteps))...)
+	// unresolved targets were already reported
+	if step.executor != nil {
+		errs = append(errs, chainErrors("parameters", lintMapping(step.Parameters, step.executor.ParametersSchema(), e.ParametersSchema()))...)
+		errs = append(errs, chainErrors("configs", lintMapping(step.Configs, step.executor.ConfigsSchema(), e.ConfigsSchema()))...)
+	}
+
+	if rollback := step.OnRollback; rollback != nil {
+		// the step itself was executed when it's rolled back
+		knownSteps = append(slices.Clone(knownSteps), step.Id)
+		var rollbackErrs []error
+		rollbackErrs = append(rollbackErrs, chainErrors("parameters", e.lintRules(rollback.Parameters, knownSteps))...)
+		rollbackErrs = append(rollbackErrs, chainErrors("configs", e.lintRules(rollback.Configs, knownSteps))...)
+		// rollback parameters are never taken from the blueprint schema
+		if rollback.executor != nil {
+			rollbackErrs = append(rollbackErrs, chainErrors("parameters", lintRulesMapping(rollback.Parameters, rollback.executor.ParametersSchema()))...)
+		}
+		errs = append(errs, chainErrors("onRollback", rollbackErrs)...)
+	}
+
+	return
+}
+
+// lint resolves all references of the executor and checks its steps, returning all problems found.
+// Steps are checked even if other references failed to resolve, only invalid schemas stop it
+func (e *executor) lint() (errs []error) {
+	if err := e.resolve(); err != nil {
+		resolveErrs, ok := err.(utils.MultiError)
+		if !ok {
+			return []error{err}
+		}
+		errs = append(errs, resolveErrs...)
+	}
+
+	knownSteps := make([]string, 0, len(e.spec.Steps))
+	for i, step := range e.spec.Steps {
+		errs = append(errs, chainErrors("steps", chainErrors(fmt.Sprintf("%d(id=%q)", i, step.Id), e.lintStep(step, knownSteps)))...)
+		knownSteps = append(knownSteps, step.Id)
+	}
+
+	if err := e.lintJSONPathRefs(e.spec.Result, knownSteps); err != nil {
+		errs = append(errs, &core.ChainedError{Name: "result", Err: err})
+	}
+
+	return
+}
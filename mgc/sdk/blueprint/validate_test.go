@@ -0,0 +1,194 @@
+package blueprint
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"magalu.cloud/core"
+	schemaPkg "magalu.cloud/core/schema"
+)
+
+func newTestSchemaExecutor(name string, parameters *core.Schema, configs *core.Schema) core.Executor {
+	return core.NewSimpleExecutor(core.ExecutorSpec{
+		DescriptorSpec:   core.DescriptorSpec{Name: name, Description: name},
+		ParametersSchema: parameters,
+		ConfigsSchema:    configs,
+		ResultSchema:     schemaPkg.NewAnySchema(),
+		Execute: func(core.Executor, context.Context, core.Parameters, core.Configs) (core.Result, error) {
+			panic("validate must not execute the targets")
+		},
+	})
+}
+
+func TestValidate(t *testing.T) {
+	region := schemaPkg.NewObjectSchema(map[string]*core.Schema{"region": schemaPkg.NewStringSchema()}, nil)
+	targets := testRefResolver{
+		"/vm/create": newTestSchemaExecutor(
+			"create",
+			schemaPkg.NewObjectSchema(map[string]*core.Schema{"name": schemaPkg.NewStringSchema()}, []string{"name"}),
+			region,
+		),
+		"/vm/delete": newTestSchemaExecutor(
+			"delete",
+			schemaPkg.NewObjectSchema(map[string]*core.Schema{"id": schemaPkg.NewStringSchema()}, []string{"id"}),
+			region,
+		),
+	}
+
+	tests := []struct {
+		name     string
+		steps    string
+		expected []string
+	}{
+		{
+			name: "valid",
+			steps: `
+      - id: create
+        target: /vm/create
+        parameters:
+          name: $.parameters.name
+        configs:
+          region: $.configs.region
+        onRollback:
+          target: /vm/delete
+          parameters:
+            id: $.steps.create.result.id
+      - id: delete
+        target: /vm/delete
+        parameters:
+          id: $.steps.create.result.id
+`,
+		},
+		{
+			name: "mapped from the blueprint schema",
+			steps: `
+      - target: /vm/create
+`,
+		},
+		{
+			name: "unknown target",
+			steps: `
+      - target: /vm/missing
+`,
+			expected: []string{`unknown path "/vm/missing"`},
+		},
+		{
+			name: "unknown target and forEach parameter",
+			steps: `
+      - id: missing
+        target: /vm/missing
+        forEach: $.parameters.names
+      - id: create
+        target: /vm/create
+        forEach: $.parameters.names
+        parameters:
+          name: $.parameters.name
+`,
+			expected: []string{
+				`invalid step 0(id="missing"): unknown path "/vm/missing"`,
+				`steps/0(id=\"missing\")/forEach": unknown parameter "names"`,
+				`steps/1(id=\"create\")/forEach": unknown parameter "names"`,
+			},
+		},
+		{
+			name: "unknown step",
+			steps: `
+      - id: create
+        target: /vm/create
+        parameters:
+          name: $.steps.missing.result.name
+`,
+			expected: []string{`/parameters/name": unknown step "missing"`},
+		},
+		{
+			name: "step executed later",
+			steps: `
+      - id: delete
+        target: /vm/delete
+        parameters:
+          id: $.steps.create.result.id
+      - id: create
+        target: /vm/create
+`,
+			expected: []string{`/parameters/id": unknown step "create", expected one of the previous steps []`},
+		},
+		{
+			name: "unknown parameter and config",
+			steps: `
+      - id: create
+        target: /vm/create
+        parameters:
+          name: $.parameters.missing
+        configs:
+          region: $.configs.missing
+`,
+			expected: []string{
+				`/parameters/name": unknown parameter "missing"`,
+				`/configs/region": unknown config "missing"`,
+			},
+		},
+		{
+			name: "unknown and missing target properties",
+			steps: `
+      - id: create
+        target: /vm/create
+        parameters:
+          size: $.parameters.name
+`,
+			expected: []string{
+				`/parameters/size": unknown property, expected one of [name]`,
+				`/parameters": required property "name" is not mapped`,
+			},
+		},
+		{
+			name: "rollback referring to a later step",
+			steps: `
+      - id: create
+        target: /vm/create
+        onRollback:
+          target: /vm/delete
+          parameters:
+            id: $.steps.delete.result.id
+      - id: delete
+        target: /vm/delete
+        parameters:
+          id: $.steps.create.result.id
+`,
+			expected: []string{`/onRollback/parameters/id": unknown step "delete", expected one of the previous steps [create]`},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			loader := newTestLoader(`
+  - name: create-vm
+    description: create a VM
+    parameters:
+      name:
+        type: string
+    configs:
+      region:
+        type: string
+    resultSchema:
+      type: object
+    steps:` + tc.steps)
+
+			err := Validate(loader, newTestStubs(nil, targets))
+			if len(tc.expected) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected errors %v, got none", tc.expected)
+			}
+			for _, expected := range tc.expected {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected error containing %q, got: %v", expected, err)
+				}
+			}
+		})
+	}
+}
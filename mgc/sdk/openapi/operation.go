@@ -559,9 +559,14 @@ func (o *operation) setSecurityHeader(ctx context.Context, paramValues core.Para
 	if isAuthForced(paramValues) || o.needsAuth() {
 		// TODO: review needsAuth() usage if more security schemes are used. Assuming oauth2 + bearer
 		// If others are to be used, loop using forEachSecurityRequirement()
+		dryRun := core.IsDryRun(ctx)
 		switch auth.CurrentSecurityMethod() {
 
 		case apiKeyAuthMethod:
+			if dryRun {
+				req.Header.Set("x-api-key", mgcHttpPkg.DryRunCredentials)
+				break
+			}
 			apiKey, err := auth.ApiKey(ctx)
 			if err != nil {
 				return err
@@ -570,6 +575,10 @@ func (o *operation) setSecurityHeader(ctx context.Context, paramValues core.Para
 			req.Header.Set("x-api-key", apiKey)
 
 		case xaasAuthMethod:
+			if dryRun {
+				req.Header.Set("x-tenant-id", mgcHttpPkg.DryRunCredentials)
+				break
+			}
 			xTenantID, err := auth.XTenantID(ctx)
 			if err != nil {
 				return err
@@ -578,6 +587,11 @@ func (o *operation) setSecurityHeader(ctx context.Context, paramValues core.Para
 			req.Header.Set("x-tenant-id", xTenantID)
 
 		default:
+			// the token may be missing or expired, don't read or refresh it just to show the request
+			if dryRun {
+				req.Header.Set("Authorization", "Bearer "+mgcHttpPkg.DryRunCredentials)
+				break
+			}
 			accessToken, err := auth.AccessToken(ctx)
 			if err != nil {
 				return err
@@ -656,6 +670,11 @@ func isVisitJSONErrFatal(err error) bool {
 	return true
 }
 
+// HTTP requests are printed instead of sent in dry-run mode
+func (o *operation) SupportsDryRun() bool {
+	return true
+}
+
 var m sync.Mutex
 
 func (o *operation) Execute(
@@ -747,6 +766,12 @@ func (o *operation) Execute(
 		logger.Warnw("failed to create HTTP request", "error", err)
 		return nil, err
 	}
+	if core.IsDryRun(ctx) {
+		m.Unlock()
+		closeIfCloser(req.Body)
+		logger.Debug("created HTTP request, dry-run will not execute it")
+		return mgcHttpPkg.NewDryRunResult(source, req, requestBody), nil
+	}
 	logger.Debug("created HTTP request, now execute it...")
 	m.Unlock()
 	resp, err := client.Do(req)
@@ -817,3 +842,4 @@ func (o *operation) EmptyResult() core.Result {
 
 // implemented by embedded SimpleDescriptor
 var _ core.Executor = (*operation)(nil)
+var _ core.DryRunExecutor = (*operation)(nil)
@@ -1,7 +1,9 @@
 package openapi
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 	"testing"
 
@@ -31,6 +33,36 @@ func checkOperationTable(t *testing.T, operations []*operationDesc, expected []s
 	}
 }
 
+// The same cases pin the command names of spec_manipulator's "diff" to these ones
+func Test_operationTree_testdata(t *testing.T) {
+	data, err := os.ReadFile("testdata/operation_table.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cases []struct {
+		Name       string
+		Operations []struct {
+			Method string
+			Path   string
+		}
+		Expected []string
+	}
+	if err := json.Unmarshal(data, &cases); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			operations := make([]*operationDesc, 0, len(tc.Operations))
+			for _, op := range tc.Operations {
+				operations = append(operations, &operationDesc{pathKey: op.Path, method: op.Method})
+			}
+			checkOperationTable(t, operations, tc.Expected)
+		})
+	}
+}
+
 // BEGIN: Test block-storage.openapi.yaml resources
 
 func Test_operationTree_block_storage_block_storage(t *testing.T) {
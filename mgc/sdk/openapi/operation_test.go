@@ -0,0 +1,30 @@
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"magalu.cloud/core"
+	mgcAuthPkg "magalu.cloud/core/auth"
+	mgcHttpPkg "magalu.cloud/core/http"
+)
+
+func TestSetSecurityHeaderDryRun(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://api.magalu.cloud/vpcs", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// logged out: there is neither an access nor a refresh token
+	ctx := core.NewDryRunContext(context.Background(), true)
+	o := &operation{}
+	if err := o.setSecurityHeader(ctx, core.Parameters{forceAuthParameter: true}, req, &mgcAuthPkg.Auth{}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	expected := "Bearer " + mgcHttpPkg.DryRunCredentials
+	if got := req.Header.Get("Authorization"); got != expected {
+		t.Errorf("expected Authorization %q, got %q", expected, got)
+	}
+}
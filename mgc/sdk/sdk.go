@@ -15,6 +15,7 @@ import (
 	"magalu.cloud/sdk/blueprint"
 	"magalu.cloud/sdk/openapi"
 	"magalu.cloud/sdk/static"
+	"magalu.cloud/sdk/static/plugins"
 )
 
 // Re-exports from Core
@@ -52,6 +53,30 @@ func NewSdk() *Sdk {
 	return &Sdk{}
 }
 
+// NewSdkForWorkspace creates an Sdk bound to the given workspace instead of the
+// current one. The persisted current workspace is left untouched.
+func NewSdkForWorkspace(name string) (*Sdk, error) {
+	o := &Sdk{}
+	if err := o.UseWorkspace(name); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// UseWorkspace binds the Sdk to the given workspace for this process only, as
+// done with the MGC_WORKSPACE environment variable. The persisted current
+// workspace is left untouched.
+func (o *Sdk) UseWorkspace(name string) error {
+	if err := o.ProfileManager().Pin(name); err != nil {
+		return err
+	}
+	// these load their values from the workspace, reload them on demand
+	o.config = nil
+	o.auth = nil
+	o.httpClient = nil
+	return nil
+}
+
 // The Context is created with the following values:
 // - use GrouperFromContext() to retrieve Sdk.Group() (root group)
 // - use AuthFromContext() to retrieve Sdk.Auth()
@@ -110,9 +135,9 @@ func (o *Sdk) newOpenApiSource() core.Grouper {
 	return openapi.NewSource(loader, &extensionPrefix)
 }
 
-func (o *Sdk) newBlueprintSource(rootRefResolver core.RefPathResolver) core.Grouper {
-	embedLoader := blueprint.GetEmbedLoader()
-
+// BlueprintsDir is where blueprints are loaded from, besides the embedded ones.
+// Defaults to "blueprints" in the current directory, use MGC_SDK_BLUEPRINTS_DIR to change it.
+func BlueprintsDir() string {
 	blueprintsDir := os.Getenv("MGC_SDK_BLUEPRINTS_DIR")
 	if blueprintsDir == "" {
 		cwd, err := os.Getwd()
@@ -120,8 +145,14 @@ func (o *Sdk) newBlueprintSource(rootRefResolver core.RefPathResolver) core.Grou
 			blueprintsDir = filepath.Join(cwd, "blueprints")
 		}
 	}
+	return blueprintsDir
+}
+
+func (o *Sdk) newBlueprintSource(rootRefResolver core.RefPathResolver) core.Grouper {
+	embedLoader := blueprint.GetEmbedLoader()
+
 	fileLoader := &dataloader.FileLoader{
-		Dir: blueprintsDir,
+		Dir: BlueprintsDir(),
 	}
 
 	var loader dataloader.Loader
@@ -134,6 +165,33 @@ func (o *Sdk) newBlueprintSource(rootRefResolver core.RefPathResolver) core.Grou
 	return blueprint.NewSource(loader, rootRefResolver)
 }
 
+// newPluginsSource provides the plugins of the current workspace, each one as a child of the
+// "plugins" group. It's merged with the static group of the same name, so user blueprints
+// never shadow the built-in commands.
+func (o *Sdk) newPluginsSource(rootRefResolver core.RefPathResolver) core.Grouper {
+	return core.NewSimpleGrouper(
+		core.DescriptorSpec{Name: "Plugins"},
+		func() ([]core.Grouper, error) {
+			pluginsGroup := core.NewSimpleGrouper(
+				core.DescriptorSpec{Name: plugins.GroupName},
+				func() (children []core.Grouper, err error) {
+					registered, err := plugins.Load(o.ProfileManager().Current())
+					if err != nil {
+						return
+					}
+
+					children = make([]core.Grouper, len(registered))
+					for i, plugin := range registered {
+						children[i] = blueprint.NewPluginSource(plugin.Name, plugin.Dir, rootRefResolver)
+					}
+					return
+				},
+			)
+			return []core.Grouper{pluginsGroup}, nil
+		},
+	)
+}
+
 func (o *Sdk) RefResolver() core.RefPathResolver {
 	if o.refResolver == nil {
 		o.refResolver = core.NewDocumentRefPathResolver(func() (any, error) { return o.Group(), nil })
@@ -154,6 +212,7 @@ func (o *Sdk) Group() core.Grouper {
 					static.GetGroup(),
 					o.newOpenApiSource(),
 					o.newBlueprintSource(o.RefResolver()),
+					o.newPluginsSource(o.RefResolver()),
 				}
 			},
 		)
@@ -7,6 +7,7 @@ import (
 	"magalu.cloud/sdk/static/config"
 	"magalu.cloud/sdk/static/http"
 	"magalu.cloud/sdk/static/object_storage"
+	"magalu.cloud/sdk/static/plugins"
 	"magalu.cloud/sdk/static/profile"
 	"magalu.cloud/sdk/static/workspace"
 )
@@ -22,6 +23,7 @@ var GetGroup = utils.NewLazyLoader(func() core.Grouper {
 				workspace.GetGroup(),
 				http.GetGroup(),
 				profile.GetGroup(),
+				plugins.GetGroup(),
 			}
 		},
 	)
@@ -0,0 +1,79 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"magalu.cloud/core"
+	"magalu.cloud/core/profile_manager"
+	"magalu.cloud/core/utils"
+)
+
+type addParams struct {
+	Dir  string `json:"dir" jsonschema_description:"Directory with the blueprints" mgc:"positional"`
+	Name string `json:"name,omitempty" jsonschema_description:"Plugin name, used as the command group. Defaults to the directory name" mgc:"positional"`
+}
+
+var getAdd = utils.NewLazyLoader[core.Executor](func() core.Executor {
+	exec := core.NewStaticExecute(
+		core.DescriptorSpec{
+			Name:        "add",
+			Description: "Registers a directory with blueprints as a plugin of the current workspace",
+		},
+		add,
+	)
+
+	return core.NewExecuteResultOutputOptions(exec, func(exec core.Executor, result core.Result) string {
+		return "template=Added plugin {{.name}} from {{.dir}}\n"
+	})
+})
+
+func add(ctx context.Context, params addParams, _ struct{}) (*Plugin, error) {
+	m := profile_manager.FromContext(ctx)
+	if m == nil {
+		return nil, PluginError{Name: "", Err: errors.New("couldn't get ProfileManager from context")}
+	}
+
+	dir, err := filepath.Abs(params.Dir)
+	if err != nil {
+		return nil, PluginError{Name: params.Name, Err: err}
+	}
+
+	name := params.Name
+	if name == "" {
+		name = filepath.Base(dir)
+	}
+	if err = checkPluginName(name); err != nil {
+		return nil, PluginError{Name: name, Err: err}
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, PluginError{Name: name, Err: err}
+	}
+	if !info.IsDir() {
+		return nil, PluginError{Name: name, Err: fmt.Errorf("%q is not a directory", dir)}
+	}
+
+	p := m.Current()
+	plugins, err := Load(p)
+	if err != nil {
+		return nil, PluginError{Name: name, Err: err}
+	}
+
+	for _, plugin := range plugins {
+		if plugin.Name == name {
+			return nil, PluginError{Name: name, Err: fmt.Errorf("already registered from %q", plugin.Dir)}
+		}
+	}
+
+	plugin := &Plugin{Name: name, Dir: dir}
+	if err = save(p, append(plugins, plugin)); err != nil {
+		return nil, PluginError{Name: name, Err: err}
+	}
+
+	return plugin, nil
+}
@@ -0,0 +1,16 @@
+package plugins
+
+import "fmt"
+
+type PluginError struct {
+	Name string
+	Err  error
+}
+
+func (e PluginError) Unwrap() error {
+	return e.Err
+}
+
+func (e PluginError) Error() string {
+	return fmt.Sprintf("plugin %s: %s", e.Name, e.Err.Error())
+}
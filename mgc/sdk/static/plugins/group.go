@@ -0,0 +1,28 @@
+package plugins
+
+import (
+	"magalu.cloud/core"
+	"magalu.cloud/core/utils"
+)
+
+const GroupName = "plugins"
+
+var GetGroup = utils.NewLazyLoader(func() core.Grouper {
+	return core.NewStaticGroup(
+		core.DescriptorSpec{
+			Name: GroupName,
+			Description: `Plugins are directories with blueprints, registered in the current workspace.
+Their modules are available under "plugins <name>", so they never shadow the built-in commands.
+The index.blueprint.yaml is optional, if missing every *.blueprint.yaml in the directory is loaded`,
+			Summary: "Manage blueprint plugins of the current workspace",
+			GroupID: "settings",
+		},
+		func() []core.Descriptor {
+			return []core.Descriptor{
+				getAdd(),
+				getList(),
+				getRemove(),
+			}
+		},
+	)
+})
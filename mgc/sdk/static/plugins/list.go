@@ -0,0 +1,31 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+
+	"magalu.cloud/core"
+	"magalu.cloud/core/profile_manager"
+	"magalu.cloud/core/utils"
+)
+
+var getList = utils.NewLazyLoader[core.Executor](func() core.Executor {
+	exec := core.NewStaticExecuteSimple(
+		core.DescriptorSpec{
+			Name:        "list",
+			Description: "List the plugins registered in the current workspace",
+		},
+		list,
+	)
+
+	return exec
+})
+
+func list(ctx context.Context) ([]*Plugin, error) {
+	m := profile_manager.FromContext(ctx)
+	if m == nil {
+		return nil, PluginError{Name: "", Err: errors.New("couldn't get ProfileManager from context")}
+	}
+
+	return Load(m.Current())
+}
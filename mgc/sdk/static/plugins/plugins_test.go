@@ -0,0 +1,140 @@
+package plugins
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"magalu.cloud/core/profile_manager"
+)
+
+func newTestContext() (context.Context, *profile_manager.Profile) {
+	m, _ := profile_manager.NewInMemoryProfileManager()
+	return profile_manager.NewContext(context.Background(), m), m.Current()
+}
+
+func TestCheckPluginName(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{name: "infra", valid: true},
+		{name: "infra-2", valid: true},
+		{name: "0infra", valid: true},
+		{name: "", valid: false},
+		{name: "-infra", valid: false},
+		{name: "Infra", valid: false},
+		{name: "in_fra", valid: false},
+		{name: "../infra", valid: false},
+		{name: "add", valid: false},
+		{name: "list", valid: false},
+		{name: "remove", valid: false},
+	}
+	for _, tc := range tests {
+		if err := checkPluginName(tc.name); (err == nil) != tc.valid {
+			t.Errorf("%q: expected valid=%v, got error %v", tc.name, tc.valid, err)
+		}
+	}
+}
+
+func TestLoadSave(t *testing.T) {
+	_, p := newTestContext()
+
+	plugins, err := Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plugins == nil || len(plugins) != 0 {
+		t.Errorf("expected no plugins without registry, got %v", plugins)
+	}
+
+	expected := []*Plugin{{Name: "infra", Dir: "/infra"}, {Name: "apps", Dir: "/apps"}}
+	if err = save(p, expected); err != nil {
+		t.Fatal(err)
+	}
+	plugins, err = Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plugins) != len(expected) {
+		t.Fatalf("expected %d plugins, got %d", len(expected), len(plugins))
+	}
+	for i, plugin := range plugins {
+		if *plugin != *expected[i] {
+			t.Errorf("expected plugin %d to be %v, got %v", i, expected[i], plugin)
+		}
+	}
+
+	if err = p.Write(registryFile, []byte("name: [")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = Load(p); err == nil {
+		t.Error("expected error for invalid registry, got none")
+	}
+}
+
+func TestAddRemove(t *testing.T) {
+	ctx, p := newTestContext()
+	dir := filepath.Join(t.TempDir(), "infra")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	plugin, err := add(ctx, addParams{Dir: dir}, struct{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plugin.Name != "infra" || plugin.Dir != dir {
+		t.Errorf("expected plugin named after the directory, got %v", plugin)
+	}
+
+	if _, err = add(ctx, addParams{Dir: dir, Name: "other"}, struct{}{}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = add(ctx, addParams{Dir: dir}, struct{}{})
+	if err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Errorf("expected duplicate error, got %v", err)
+	}
+
+	if _, err = add(ctx, addParams{Dir: dir, Name: "list"}, struct{}{}); err == nil {
+		t.Error("expected error for reserved name, got none")
+	}
+
+	if _, err = add(ctx, addParams{Dir: filepath.Join(dir, "missing"), Name: "missing"}, struct{}{}); err == nil {
+		t.Error("expected error for missing directory, got none")
+	}
+
+	removed, err := remove(ctx, removeParams{Name: "infra"}, struct{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed.Name != "infra" {
+		t.Errorf("expected removed plugin infra, got %v", removed)
+	}
+
+	if _, err = remove(ctx, removeParams{Name: "infra"}, struct{}{}); err == nil {
+		t.Error("expected error removing an unregistered plugin, got none")
+	}
+
+	plugins, err := list(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plugins) != 1 || plugins[0].Name != "other" {
+		t.Errorf("expected only plugin other left, got %v", plugins)
+	}
+	if _, err = os.Stat(dir); err != nil {
+		t.Errorf("expected the directory to be left untouched, got %v", err)
+	}
+
+	loaded, err := Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != 1 || loaded[0].Name != "other" {
+		t.Errorf("expected the registry to be saved, got %v", loaded)
+	}
+}
@@ -0,0 +1,63 @@
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+
+	"github.com/invopop/yaml"
+	"magalu.cloud/core/profile_manager"
+)
+
+// Registry file, kept in each workspace directory
+const registryFile = "plugins.yaml"
+
+var isPluginNameValid = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`).MatchString
+
+// these are the executors of the plugins group, plugins with these names would be hidden by them
+var reservedNames = []string{"add", "list", "remove"}
+
+type Plugin struct {
+	Name string `json:"name"`
+	Dir  string `json:"dir"`
+}
+
+func checkPluginName(name string) error {
+	if !isPluginNameValid(name) {
+		return errors.New("invalid name, use only lowercase letters, digits and dashes")
+	}
+	if slices.Contains(reservedNames, name) {
+		return fmt.Errorf("name not allowed, %v are reserved", reservedNames)
+	}
+	return nil
+}
+
+// Load returns the plugins registered in the workspace, in the order they were added
+func Load(p *profile_manager.Profile) (plugins []*Plugin, err error) {
+	data, err := p.Read(registryFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Plugin{}, nil
+		}
+		return nil, err
+	}
+
+	err = yaml.Unmarshal(data, &plugins)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %q: %w", registryFile, err)
+	}
+	if plugins == nil {
+		plugins = []*Plugin{}
+	}
+	return plugins, nil
+}
+
+func save(p *profile_manager.Profile, plugins []*Plugin) error {
+	data, err := yaml.Marshal(plugins)
+	if err != nil {
+		return err
+	}
+	return p.Write(registryFile, data)
+}
@@ -0,0 +1,54 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"magalu.cloud/core"
+	"magalu.cloud/core/profile_manager"
+	"magalu.cloud/core/utils"
+)
+
+type removeParams struct {
+	Name string `json:"name" jsonschema_description:"Plugin name" mgc:"positional"`
+}
+
+var getRemove = utils.NewLazyLoader[core.Executor](func() core.Executor {
+	exec := core.NewStaticExecute(
+		core.DescriptorSpec{
+			Name:        "remove",
+			Description: "Unregisters the plugin from the current workspace. The directory is left untouched",
+		},
+		remove,
+	)
+
+	return core.NewExecuteResultOutputOptions(exec, func(exec core.Executor, result core.Result) string {
+		return "template=Removed plugin {{.name}}\n"
+	})
+})
+
+func remove(ctx context.Context, params removeParams, _ struct{}) (*Plugin, error) {
+	m := profile_manager.FromContext(ctx)
+	if m == nil {
+		return nil, PluginError{Name: "", Err: errors.New("couldn't get ProfileManager from context")}
+	}
+
+	p := m.Current()
+	plugins, err := Load(p)
+	if err != nil {
+		return nil, PluginError{Name: params.Name, Err: err}
+	}
+
+	i := slices.IndexFunc(plugins, func(plugin *Plugin) bool { return plugin.Name == params.Name })
+	if i < 0 {
+		return nil, PluginError{Name: params.Name, Err: errors.New("not registered")}
+	}
+
+	plugin := plugins[i]
+	if err = save(p, slices.Delete(plugins, i, i+1)); err != nil {
+		return nil, PluginError{Name: params.Name, Err: err}
+	}
+
+	return plugin, nil
+}
@@ -0,0 +1,542 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/spf13/cobra"
+)
+
+type specOperation struct {
+	// Method and path, with the path variables unnamed, so renaming them is not seen as a new operation
+	Key      string
+	Method   string
+	Path     string
+	PathItem *v3.PathItem
+	Op       *v3.Operation
+}
+
+type specChange struct {
+	Breaking bool
+	// Operation key, or "cli" for the generated commands and flags
+	Where   string
+	Message string
+}
+
+type specDiff struct {
+	changes []specChange
+}
+
+// schemaDirection tells whether the client sends (request) or receives (response) the values,
+// which defines if narrowing or widening a schema breaks it
+type schemaDirection int
+
+const (
+	requestDirection schemaDirection = iota
+	responseDirection
+)
+
+func (d *specDiff) add(breaking bool, where string, format string, args ...any) {
+	d.changes = append(d.changes, specChange{Breaking: breaking, Where: where, Message: fmt.Sprintf(format, args...)})
+}
+
+func (d *specDiff) countBreaking() (n int) {
+	for _, c := range d.changes {
+		if c.Breaking {
+			n++
+		}
+	}
+	return
+}
+
+func loadV3Document(file string) (*v3.Document, error) {
+	fileBytes, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := libopenapi.NewDocument(fileBytes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read document %s: %w", file, err)
+	}
+
+	docModel, errs := document.BuildV3Model()
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("cannot create v3 model from document %s: %w", file, errs[0])
+	}
+	return &docModel.Model, nil
+}
+
+func collectSpecOperations(doc *v3.Document) (ops []*specOperation) {
+	if doc.Paths == nil {
+		return
+	}
+	for path := doc.Paths.PathItems.Oldest(); path != nil; path = path.Next() {
+		operations := path.Value.GetOperations()
+		if operations == nil {
+			continue
+		}
+		for op := operations.Oldest(); op != nil; op = op.Next() {
+			ops = append(ops, &specOperation{
+				Key:      strings.ToUpper(op.Key) + " " + openAPIPathArgRegex.ReplaceAllString(path.Key, "{}"),
+				Method:   op.Key,
+				Path:     path.Key,
+				PathItem: path.Value,
+				Op:       op.Value,
+			})
+		}
+	}
+	return
+}
+
+func byOperationKey(ops []*specOperation) map[string]*specOperation {
+	m := make(map[string]*specOperation, len(ops))
+	for _, op := range ops {
+		m[op.Key] = op
+	}
+	return m
+}
+
+// getMediaTypeSchema returns the schema the SDK uses for the request body properties
+func getMediaTypeSchema(content *orderedmap.Map[string, *v3.MediaType]) *base.Schema {
+	if content == nil {
+		return nil
+	}
+	for _, contentType := range []string{"application/json", "multipart/form-data", "application/x-www-form-urlencoded"} {
+		if mt := content.GetOrZero(contentType); mt != nil && mt.Schema != nil {
+			return mt.Schema.Schema()
+		}
+	}
+	return nil
+}
+
+type schemaProperty struct {
+	name     string
+	schema   *base.Schema
+	required bool
+}
+
+// collectProperties returns the properties of the schema, including the ones from allOf
+func collectProperties(schema *base.Schema, visited map[*base.Schema]bool) (props []*schemaProperty) {
+	if schema == nil || visited[schema] {
+		return
+	}
+	visited[schema] = true
+
+	if schema.Properties != nil {
+		for prop := schema.Properties.Oldest(); prop != nil; prop = prop.Next() {
+			if propSchema := prop.Value.Schema(); propSchema != nil {
+				props = append(props, &schemaProperty{prop.Key, propSchema, slices.Contains(schema.Required, prop.Key)})
+			}
+		}
+	}
+	for _, proxy := range schema.AllOf {
+		props = append(props, collectProperties(proxy.Schema(), visited)...)
+	}
+	return
+}
+
+func propertiesByName(schema *base.Schema) map[string]*schemaProperty {
+	m := map[string]*schemaProperty{}
+	for _, prop := range collectProperties(schema, map[*base.Schema]bool{}) {
+		if existing, ok := m[prop.name]; ok {
+			existing.required = existing.required || prop.required
+			continue
+		}
+		m[prop.name] = prop
+	}
+	return m
+}
+
+func schemaType(schema *base.Schema) string {
+	var types []string
+	for _, t := range schema.Type {
+		if t != "null" {
+			types = append(types, t)
+		}
+	}
+	slices.Sort(types)
+	return strings.Join(types, "|")
+}
+
+func isNullable(schema *base.Schema) bool {
+	return (schema.Nullable != nil && *schema.Nullable) || slices.Contains(schema.Type, "null")
+}
+
+func enumValues(schema *base.Schema) []string {
+	values := make([]string, 0, len(schema.Enum))
+	for _, node := range schema.Enum {
+		values = append(values, node.Value)
+	}
+	return values
+}
+
+// isWidening tells whether all values of the old type are accepted by the new one
+func isWidening(oldType, newType string) bool {
+	return oldType == "integer" && newType == "number"
+}
+
+func (d *specDiff) compareSchemas(where, location string, oldSchema, newSchema *base.Schema, dir schemaDirection, visited map[[2]*base.Schema]bool) {
+	if oldSchema == nil || newSchema == nil {
+		return
+	}
+	pair := [2]*base.Schema{oldSchema, newSchema}
+	if visited[pair] {
+		return
+	}
+	visited[pair] = true
+
+	oldType, newType := schemaType(oldSchema), schemaType(newSchema)
+	if oldType != "" && newType != "" && oldType != newType {
+		compatible := isWidening(oldType, newType)
+		if dir == responseDirection {
+			compatible = isWidening(newType, oldType)
+		}
+		d.add(!compatible, where, "%s: type changed from %s to %s", location, oldType, newType)
+	}
+
+	if oldNullable, newNullable := isNullable(oldSchema), isNullable(newSchema); oldNullable != newNullable {
+		// clients may not send null anymore, or may receive it now
+		breaking := (dir == requestDirection) == oldNullable
+		d.add(breaking, where, "%s: nullable changed from %v to %v", location, oldNullable, newNullable)
+	}
+
+	if oldEnum, newEnum := enumValues(oldSchema), enumValues(newSchema); len(oldEnum) == 0 && len(newEnum) > 0 {
+		// values were restricted
+		d.add(dir == requestDirection, where, "%s: enum %v added", location, newEnum)
+	} else if len(oldEnum) > 0 && len(newEnum) == 0 {
+		d.add(dir == responseDirection, where, "%s: enum removed", location)
+	} else {
+		for _, v := range oldEnum {
+			if !slices.Contains(newEnum, v) {
+				d.add(dir == requestDirection, where, "%s: enum value %q removed", location, v)
+			}
+		}
+		for _, v := range newEnum {
+			if !slices.Contains(oldEnum, v) {
+				d.add(dir == responseDirection, where, "%s: enum value %q added", location, v)
+			}
+		}
+	}
+
+	oldProps, newProps := propertiesByName(oldSchema), propertiesByName(newSchema)
+	for _, name := range slices.Sorted(maps.Keys(oldProps)) {
+		oldProp := oldProps[name]
+		propLocation := location + "." + name
+		newProp, ok := newProps[name]
+		if !ok {
+			d.add(true, where, "%s: property removed", propLocation)
+			continue
+		}
+
+		if oldProp.required != newProp.required {
+			// required request properties must be sent, optional response properties may be missing
+			breaking := newProp.required
+			if dir == responseDirection {
+				breaking = !newProp.required
+			}
+			d.add(breaking, where, "%s: required changed from %v to %v", propLocation, oldProp.required, newProp.required)
+		}
+
+		d.compareSchemas(where, propLocation, oldProp.schema, newProp.schema, dir, visited)
+	}
+	for _, name := range slices.Sorted(maps.Keys(newProps)) {
+		if _, ok := oldProps[name]; !ok {
+			newProp := newProps[name]
+			d.add(dir == requestDirection && newProp.required, where, "%s.%s: property added (required: %v)", location, name, newProp.required)
+		}
+	}
+
+	if oldSchema.Items != nil && newSchema.Items != nil && oldSchema.Items.A != nil && newSchema.Items.A != nil {
+		d.compareSchemas(where, location+"[]", oldSchema.Items.A.Schema(), newSchema.Items.A.Schema(), dir, visited)
+	}
+}
+
+func (d *specDiff) compareContent(where, location string, oldContent, newContent *orderedmap.Map[string, *v3.MediaType], dir schemaDirection) {
+	if oldContent == nil || newContent == nil {
+		return
+	}
+	for mt := oldContent.Oldest(); mt != nil; mt = mt.Next() {
+		newMt := newContent.GetOrZero(mt.Key)
+		if newMt == nil {
+			d.add(true, where, "%s: content type %s removed", location, mt.Key)
+			continue
+		}
+		if mt.Value.Schema != nil && newMt.Schema != nil {
+			d.compareSchemas(where, location, mt.Value.Schema.Schema(), newMt.Schema.Schema(), dir, map[[2]*base.Schema]bool{})
+		}
+	}
+	for mt := newContent.Oldest(); mt != nil; mt = mt.Next() {
+		if oldContent.GetOrZero(mt.Key) == nil {
+			d.add(false, where, "%s: content type %s added", location, mt.Key)
+		}
+	}
+}
+
+func mergedParameters(op *specOperation) map[string]*v3.Parameter {
+	// operation parameters override the path ones with the same name and location
+	params := map[string]*v3.Parameter{}
+	for _, list := range [][]*v3.Parameter{op.PathItem.Parameters, op.Op.Parameters} {
+		for _, param := range list {
+			params[param.In+":"+param.Name] = param
+		}
+	}
+	return params
+}
+
+func isRequiredParameter(param *v3.Parameter) bool {
+	return param.Required != nil && *param.Required
+}
+
+func (d *specDiff) compareParameters(oldOp, newOp *specOperation) {
+	oldParams, newParams := mergedParameters(oldOp), mergedParameters(newOp)
+	// path variables are matched by position, as the operation key ignores their names
+	oldPathVars := openAPIPathArgRegex.FindAllStringSubmatch(oldOp.Path, -1)
+	newPathVars := openAPIPathArgRegex.FindAllStringSubmatch(newOp.Path, -1)
+	renamed := map[string]string{}
+	for i := range min(len(oldPathVars), len(newPathVars)) {
+		if oldName, newName := oldPathVars[i][1], newPathVars[i][1]; oldName != newName {
+			renamed["path:"+oldName] = "path:" + newName
+			d.add(false, newOp.Key, "path parameter %s renamed to %s", oldName, newName)
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(oldParams)) {
+		oldParam := oldParams[key]
+		newKey := key
+		if k, ok := renamed[key]; ok {
+			newKey = k
+		}
+		newParam, ok := newParams[newKey]
+		if !ok {
+			d.add(true, newOp.Key, "parameter %s removed", key)
+			continue
+		}
+
+		if oldRequired, newRequired := isRequiredParameter(oldParam), isRequiredParameter(newParam); oldRequired != newRequired {
+			d.add(newRequired, newOp.Key, "parameter %s: required changed from %v to %v", newKey, oldRequired, newRequired)
+		}
+
+		if oldParam.Schema != nil && newParam.Schema != nil {
+			d.compareSchemas(newOp.Key, "parameter "+newKey, oldParam.Schema.Schema(), newParam.Schema.Schema(), requestDirection, map[[2]*base.Schema]bool{})
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(newParams)) {
+		if _, ok := oldParams[key]; ok || slices.Contains(slices.Collect(maps.Values(renamed)), key) {
+			continue
+		}
+		required := isRequiredParameter(newParams[key])
+		d.add(required, newOp.Key, "parameter %s added (required: %v)", key, required)
+	}
+}
+
+func (d *specDiff) compareRequestBodies(oldOp, newOp *specOperation) {
+	oldBody, newBody := oldOp.Op.RequestBody, newOp.Op.RequestBody
+	switch {
+	case oldBody == nil && newBody == nil:
+		return
+	case newBody == nil:
+		d.add(true, newOp.Key, "request body removed")
+		return
+	case oldBody == nil:
+		required := newBody.Required != nil && *newBody.Required
+		d.add(required, newOp.Key, "request body added (required: %v)", required)
+		return
+	}
+
+	oldRequired := oldBody.Required != nil && *oldBody.Required
+	newRequired := newBody.Required != nil && *newBody.Required
+	if oldRequired != newRequired {
+		d.add(newRequired, newOp.Key, "request body: required changed from %v to %v", oldRequired, newRequired)
+	}
+
+	d.compareContent(newOp.Key, "request body", oldBody.Content, newBody.Content, requestDirection)
+}
+
+func responsesByCode(responses *v3.Responses) map[string]*v3.Response {
+	m := map[string]*v3.Response{}
+	if responses == nil {
+		return m
+	}
+	if responses.Codes != nil {
+		for code := responses.Codes.Oldest(); code != nil; code = code.Next() {
+			m[code.Key] = code.Value
+		}
+	}
+	if responses.Default != nil {
+		m["default"] = responses.Default
+	}
+	return m
+}
+
+func (d *specDiff) compareResponses(oldOp, newOp *specOperation) {
+	oldResponses, newResponses := responsesByCode(oldOp.Op.Responses), responsesByCode(newOp.Op.Responses)
+	for _, code := range slices.Sorted(maps.Keys(oldResponses)) {
+		newResponse, ok := newResponses[code]
+		if !ok {
+			d.add(strings.HasPrefix(code, "2"), newOp.Key, "response %s removed", code)
+			continue
+		}
+		d.compareContent(newOp.Key, "response "+code, oldResponses[code].Content, newResponse.Content, responseDirection)
+	}
+	for _, code := range slices.Sorted(maps.Keys(newResponses)) {
+		if _, ok := oldResponses[code]; !ok {
+			d.add(false, newOp.Key, "response %s added", code)
+		}
+	}
+}
+
+func (d *specDiff) compareCli(oldDoc, newDoc *v3.Document, oldOps, newOps []*specOperation) {
+	oldCommands, newCommands := cliCommands(oldDoc, oldOps), cliCommands(newDoc, newOps)
+	oldAll := slices.Concat(slices.Collect(maps.Values(oldCommands))...)
+	newAll := slices.Concat(slices.Collect(maps.Values(newCommands))...)
+
+	reported := map[string]bool{}
+	for _, key := range slices.Sorted(maps.Keys(oldCommands)) {
+		var removed, added []string
+		for _, c := range oldCommands[key] {
+			if !slices.Contains(newAll, c) {
+				removed = append(removed, c)
+			}
+		}
+		for _, c := range newCommands[key] {
+			if !slices.Contains(oldAll, c) {
+				added = append(added, c)
+			}
+		}
+		if len(removed) == 1 && len(added) == 1 {
+			d.add(true, "cli", "command %q renamed to %q (%s)", removed[0], added[0], key)
+			reported[removed[0]] = true
+			reported[added[0]] = true
+		}
+	}
+
+	slices.Sort(oldAll)
+	slices.Sort(newAll)
+	for _, c := range slices.Compact(oldAll) {
+		if !reported[c] && !slices.Contains(newAll, c) {
+			d.add(true, "cli", "command %q removed", c)
+		}
+	}
+	for _, c := range slices.Compact(newAll) {
+		if !reported[c] && !slices.Contains(oldAll, c) {
+			d.add(false, "cli", "command %q added", c)
+		}
+	}
+
+	newByKey := byOperationKey(newOps)
+	for _, oldOp := range oldOps {
+		newOp, ok := newByKey[oldOp.Key]
+		if !ok || len(newCommands[newOp.Key]) == 0 {
+			continue
+		}
+		command := newCommands[newOp.Key][0]
+		oldFlags, newFlags := cliFlags(oldOp), cliFlags(newOp)
+		for _, f := range slices.Sorted(maps.Keys(oldFlags)) {
+			if _, ok := newFlags[f]; !ok {
+				d.add(true, "cli", "%s: flag --%s removed", command, f)
+			}
+		}
+		for _, f := range slices.Sorted(maps.Keys(newFlags)) {
+			if _, ok := oldFlags[f]; ok {
+				continue
+			}
+			// Existing invocations don't have the new flag, it breaks them if it must be given
+			if required := newFlags[f]; required {
+				d.add(true, "cli", "%s: required flag --%s added", command, f)
+			} else {
+				d.add(false, "cli", "%s: flag --%s added", command, f)
+			}
+		}
+	}
+}
+
+func isDeprecated(op *v3.Operation) bool {
+	return op.Deprecated != nil && *op.Deprecated
+}
+
+func diffSpecs(oldDoc, newDoc *v3.Document) *specDiff {
+	d := &specDiff{}
+	oldOps, newOps := collectSpecOperations(oldDoc), collectSpecOperations(newDoc)
+	oldByKey, newByKey := byOperationKey(oldOps), byOperationKey(newOps)
+
+	for _, oldOp := range oldOps {
+		newOp, ok := newByKey[oldOp.Key]
+		if !ok {
+			d.add(true, oldOp.Key, "operation removed")
+			continue
+		}
+		if !isDeprecated(oldOp.Op) && isDeprecated(newOp.Op) {
+			d.add(false, newOp.Key, "operation deprecated")
+		}
+		d.compareParameters(oldOp, newOp)
+		d.compareRequestBodies(oldOp, newOp)
+		d.compareResponses(oldOp, newOp)
+	}
+	for _, newOp := range newOps {
+		if _, ok := oldByKey[newOp.Key]; !ok {
+			d.add(false, newOp.Key, "operation added")
+		}
+	}
+
+	d.compareCli(oldDoc, newDoc, oldOps, newOps)
+	return d
+}
+
+func printSpecDiff(d *specDiff) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, c := range d.changes {
+		level := "non-breaking"
+		if c.Breaking {
+			level = "BREAKING"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", level, c.Where, c.Message)
+	}
+	_ = w.Flush()
+
+	fmt.Printf("\n%d changes, %d breaking\n", len(d.changes), d.countBreaking())
+}
+
+var diffSpecsCmd = &cobra.Command{
+	Use:   "diff [old] [new]",
+	Short: "Compare two specs and report breaking changes",
+	Long: `Compare two OpenAPI documents at the operation, parameter, request body and
+response schema level, classifying each change as breaking or non-breaking.
+
+The commands and flags the CLI generates from the specs are compared as well,
+so renamed tags, paths or x-mgc-name extensions are reported.`,
+	Example: "  specs diff cli_specs/conv.compute.jaxyendy.openapi.yaml new.compute.openapi.yaml",
+	Args:    cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		oldDoc, err := loadV3Document(args[0])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		newDoc, err := loadV3Document(args[1])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		d := diffSpecs(oldDoc, newDoc)
+		printSpecDiff(d)
+
+		if failOnBreaking, _ := cmd.Flags().GetBool("fail-on-breaking"); failOnBreaking && d.countBreaking() > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	diffSpecsCmd.Flags().Bool("fail-on-breaking", false, "Exit with status 1 if there are breaking changes")
+}
@@ -0,0 +1,305 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stoewer/go-strcase"
+	"gopkg.in/yaml.v3"
+)
+
+// The naming below mirrors sdk/openapi (operation_table.go, parameters.go and request_body.go)
+// and the CLI kebab-case normalization, so the diff reports the commands and flags users see.
+// Keep them in sync, both are tested against sdk/openapi/testdata/operation_table.json.
+
+const (
+	mgcExtensionPrefix = "x-mgc-"
+	// the CLI moves properties like "_limit" to "--control.limit"
+	originalControlPrefix = "_"
+	targetControlPrefix   = "control."
+)
+
+var openAPIPathArgRegex = regexp.MustCompile("[{](?P<name>[^}]+)[}]")
+
+func getMgcExtension(extensions *orderedmap.Map[string, *yaml.Node], name string) string {
+	if extensions == nil {
+		return ""
+	}
+	if node := extensions.GetOrZero(mgcExtensionPrefix + name); node != nil && node.Kind == yaml.ScalarNode {
+		return node.Value
+	}
+	return ""
+}
+
+func cliFlagName(name string) string {
+	if baseName, isControl := strings.CutPrefix(name, originalControlPrefix); isControl {
+		name = targetControlPrefix + baseName
+	}
+	return strcase.KebabCase(name)
+}
+
+func isMgcHidden(extensions *orderedmap.Map[string, *yaml.Node]) bool {
+	return getMgcExtension(extensions, "hidden") == "true"
+}
+
+type cliTableEntry struct {
+	name      []string
+	variables []string
+	op        *specOperation
+	key       string
+}
+
+func (e *cliTableEntry) simpleNameKey() string {
+	if len(e.name) > 1 {
+		return e.name[len(e.name)-2]
+	}
+	return e.name[0]
+}
+
+func (e *cliTableEntry) fullNameKey() string {
+	switch length := len(e.name); length {
+	case 1:
+		return e.name[0]
+	case 2:
+		return e.name[1] + "-" + e.name[0]
+	default:
+		return e.name[length-1] + "-" + e.name[0] + "-" + e.name[length-2]
+	}
+}
+
+func (e *cliTableEntry) needsFullNameKey() bool {
+	return slices.Contains([]string{"all", "default"}, e.simpleNameKey()) || e.name[len(e.name)-1] == "delete"
+}
+
+// cliTable groups the operations of a tag by their path entries, as the SDK does
+type cliTable struct {
+	name    string
+	tables  []*cliTable
+	entries []*cliTableEntry
+}
+
+func (t *cliTable) add(name, variables []string, op *specOperation) {
+	if len(name) == 0 {
+		return
+	}
+
+	for _, child := range t.tables {
+		if child.name == name[0] {
+			child.add(name[1:], variables, op)
+			return
+		}
+	}
+
+	for i, sibling := range t.entries {
+		if sibling.name[0] == name[0] && len(sibling.name) > 1 {
+			child := &cliTable{name: name[0]}
+			child.add(sibling.name[1:], sibling.variables, sibling.op)
+			child.add(name[1:], variables, op)
+			t.tables = append(t.tables, child)
+			t.entries = slices.Delete(t.entries, i, i+1)
+			return
+		}
+	}
+
+	t.entries = append(t.entries, &cliTableEntry{name: name, variables: variables, op: op})
+}
+
+func (t *cliTable) simplify() {
+	for _, child := range t.tables {
+		child.simplify()
+	}
+
+	if len(t.entries) == 0 && len(t.tables) == 1 {
+		child := t.tables[0]
+		t.tables = child.tables
+		t.entries = child.entries
+		t.name = t.name + "-" + child.name
+	}
+
+	if len(t.entries) == 1 {
+		entry := t.entries[0]
+		entry.name = entry.name[len(entry.name)-1:]
+	}
+}
+
+func setUniqueFullKeys(entries []*cliTableEntry) {
+	maxVarLength := math.MinInt
+	for _, entry := range entries {
+		entry.key = entry.fullNameKey()
+		maxVarLength = max(maxVarLength, len(entry.variables))
+	}
+
+	for i := 0; i < maxVarLength; i++ {
+		isCommon := true
+		for _, entry := range entries {
+			if i >= len(entry.variables) || entry.variables[i] != entries[0].variables[i] {
+				isCommon = false
+				break
+			}
+		}
+		if isCommon {
+			continue
+		}
+		for _, entry := range entries {
+			if i < len(entry.variables) {
+				entry.key += "-" + entry.variables[i]
+			}
+		}
+	}
+}
+
+func (t *cliTable) finalizeEntryKeys() {
+	bySimpleKey := map[string][]*cliTableEntry{}
+	for _, entry := range t.entries {
+		bySimpleKey[entry.simpleNameKey()] = append(bySimpleKey[entry.simpleNameKey()], entry)
+	}
+
+	for simpleKey, entries := range bySimpleKey {
+		if len(entries) > 1 {
+			setUniqueFullKeys(entries)
+		} else if entries[0].needsFullNameKey() {
+			entries[0].key = entries[0].fullNameKey()
+		} else {
+			entries[0].key = simpleKey
+		}
+	}
+
+	for _, child := range t.tables {
+		child.finalizeEntryKeys()
+	}
+}
+
+// collectCommands appends the command path of each operation, ex: "instances get"
+func (t *cliTable) collectCommands(prefix []string, commands map[string][]string) {
+	for _, entry := range t.entries {
+		name := getMgcExtension(entry.op.Op.Extensions, "name")
+		if name == "" {
+			name = entry.key
+		}
+		path := append(slices.Clone(prefix), strcase.KebabCase(name))
+		commands[entry.op.Key] = append(commands[entry.op.Key], strings.Join(path, " "))
+	}
+	for _, child := range t.tables {
+		child.collectCommands(append(slices.Clone(prefix), strcase.KebabCase(child.name)), commands)
+	}
+}
+
+func renameHttpMethod(method string, endsWithVariable bool) string {
+	switch method {
+	case "post":
+		return "create"
+	case "put":
+		return "replace"
+	case "patch":
+		return "update"
+	case "get":
+		if endsWithVariable {
+			return "get"
+		}
+		return "list"
+	}
+	return method
+}
+
+func getOperationNameAndVariables(method, path string) (name []string, variables []string) {
+	endsWithVariable := false
+	for _, pathEntry := range strings.Split(path, "/") {
+		if pathEntry == "" {
+			continue
+		}
+
+		if match := openAPIPathArgRegex.FindStringSubmatch(pathEntry); match != nil {
+			variables = append(variables, strcase.KebabCase(match[1]))
+			endsWithVariable = true
+		} else {
+			name = append(name, strings.Split(strcase.KebabCase(pathEntry), "-")...)
+			endsWithVariable = false
+		}
+	}
+
+	return append(name, renameHttpMethod(method, endsWithVariable)), variables
+}
+
+func newCliTable(tag *base.Tag, ops []*specOperation) *cliTable {
+	table := &cliTable{name: tag.Name}
+	for _, op := range ops {
+		if !slices.Contains(op.Op.Tags, tag.Name) {
+			continue
+		}
+		name, variables := getOperationNameAndVariables(op.Method, op.Path)
+		table.add(name, variables, op)
+	}
+	table.simplify()
+	table.finalizeEntryKeys()
+	return table
+}
+
+// cliCommands returns the command paths of each operation key. Operations in many tags have many commands
+func cliCommands(doc *v3.Document, ops []*specOperation) map[string][]string {
+	commands := map[string][]string{}
+	for _, tag := range doc.Tags {
+		name := getMgcExtension(tag.Extensions, "name")
+		if name == "" {
+			name = tag.Name
+		}
+		newCliTable(tag, ops).collectCommands([]string{strcase.KebabCase(name)}, commands)
+	}
+	return commands
+}
+
+// cliFlags returns the flag names of the operation parameters and request body properties,
+// mapped to whether the flag must be given: required and without a default value
+func cliFlags(op *specOperation) map[string]bool {
+	byName := map[string]map[string]*v3.Parameter{}
+	for _, params := range [][]*v3.Parameter{op.PathItem.Parameters, op.Op.Parameters} {
+		for _, param := range params {
+			if isMgcHidden(param.Extensions) {
+				continue
+			}
+			if byName[param.Name] == nil {
+				byName[param.Name] = map[string]*v3.Parameter{}
+			}
+			byName[param.Name][param.In] = param
+		}
+	}
+
+	flags := map[string]bool{}
+	for name, byLocation := range byName {
+		for location, param := range byLocation {
+			flagName := getMgcExtension(param.Extensions, "name")
+			if flagName == "" {
+				flagName = name
+				if len(byLocation) > 1 {
+					flagName = fmt.Sprintf("%s-%s", location, name)
+				}
+			}
+			var schema *base.Schema
+			if param.Schema != nil {
+				schema = param.Schema.Schema()
+			}
+			flagName = cliFlagName(flagName)
+			flags[flagName] = flags[flagName] || (param.Required != nil && *param.Required && (schema == nil || schema.Default == nil))
+		}
+	}
+
+	if body := op.Op.RequestBody; body != nil {
+		if schema := getMediaTypeSchema(body.Content); schema != nil {
+			for _, prop := range collectProperties(schema, map[*base.Schema]bool{}) {
+				flagName := getMgcExtension(prop.schema.Extensions, "name")
+				if flagName == "" {
+					flagName = prop.name
+				}
+				flagName = cliFlagName(flagName)
+				flags[flagName] = flags[flagName] || (prop.required && prop.schema.Default == nil)
+			}
+		}
+	}
+
+	return flags
+}
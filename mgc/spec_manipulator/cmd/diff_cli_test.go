@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// The cases are shared with sdk/openapi, so the commands reported by the diff are the SDK ones
+func Test_newCliTable(t *testing.T) {
+	data, err := os.ReadFile("../../sdk/openapi/testdata/operation_table.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cases []struct {
+		Name       string
+		Operations []struct {
+			Method string
+			Path   string
+		}
+		Expected []string
+	}
+	if err := json.Unmarshal(data, &cases); err != nil {
+		t.Fatal(err)
+	}
+
+	tag := &base.Tag{Name: "test"}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			ops := make([]*specOperation, 0, len(tc.Operations))
+			for _, op := range tc.Operations {
+				ops = append(ops, &specOperation{
+					Key:    op.Method + " " + op.Path,
+					Method: op.Method,
+					Path:   op.Path,
+					Op:     &v3.Operation{Tags: []string{tag.Name}},
+				})
+			}
+
+			commands := map[string][]string{}
+			newCliTable(tag, ops).collectCommands(nil, commands)
+
+			var got []string
+			for _, op := range ops {
+				for _, command := range commands[op.Key] {
+					got = append(got, fmt.Sprintf("%s - %s", command, op.Key))
+				}
+			}
+			expected := slices.Clone(tc.Expected)
+			slices.Sort(expected)
+			slices.Sort(got)
+			if !slices.Equal(expected, got) {
+				t.Errorf("diverging results:\nEXPECTED:\n%s\n\nGOT:\n%s\n", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+			}
+		})
+	}
+}
+
+func Test_cliFlags(t *testing.T) {
+	required := true
+	withDefault := &base.Schema{Default: &yaml.Node{Kind: yaml.ScalarNode, Value: "10"}}
+	body := &base.Schema{
+		Properties: orderedmap.New[string, *base.SchemaProxy](),
+		Required:   []string{"name", "size"},
+	}
+	body.Properties.Set("name", base.CreateSchemaProxy(&base.Schema{}))
+	body.Properties.Set("size", base.CreateSchemaProxy(withDefault))
+	body.Properties.Set("description", base.CreateSchemaProxy(&base.Schema{}))
+	content := orderedmap.New[string, *v3.MediaType]()
+	content.Set("application/json", &v3.MediaType{Schema: base.CreateSchemaProxy(body)})
+
+	op := &specOperation{
+		PathItem: &v3.PathItem{Parameters: []*v3.Parameter{
+			{Name: "id", In: "path", Required: &required},
+		}},
+		Op: &v3.Operation{
+			Parameters: []*v3.Parameter{
+				{Name: "_limit", In: "query", Required: &required, Schema: base.CreateSchemaProxy(withDefault)},
+				{Name: "zone", In: "query"},
+			},
+			RequestBody: &v3.RequestBody{Content: content},
+		},
+	}
+
+	expected := map[string]bool{
+		"id":            true,
+		"control.limit": false,
+		"zone":          false,
+		"name":          true,
+		"size":          false,
+		"description":   false,
+	}
+	got := cliFlags(op)
+	if len(got) != len(expected) {
+		t.Errorf("expected flags %v, got %v", expected, got)
+	}
+	for name, isRequired := range expected {
+		if r, ok := got[name]; !ok || r != isRequired {
+			t.Errorf("expected flag %q required %v, got %v (present %v)", name, isRequired, r, ok)
+		}
+	}
+}
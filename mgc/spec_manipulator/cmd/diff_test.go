@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+func parseTestSpec(t *testing.T, paths string) *v3.Document {
+	t.Helper()
+	spec := `openapi: 3.0.3
+info:
+  title: test
+  version: 1.0.0
+tags:
+  - name: vms
+paths:
+` + paths
+	document, err := libopenapi.NewDocument([]byte(spec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	model, errs := document.BuildV3Model()
+	if len(errs) > 0 {
+		t.Fatal(errs[0])
+	}
+	return &model.Model
+}
+
+// testSchemaPaths creates an operation sending the request schema and receiving the response one,
+// both in YAML flow style
+func testSchemaPaths(request, response string) string {
+	return fmt.Sprintf(`  /vms:
+    post:
+      tags: [vms]
+      requestBody:
+        content:
+          application/json:
+            schema: %s
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: %s
+`, request, response)
+}
+
+// testDiffChanges lists the API changes, the CLI ones are tested by Test_newCliTable and Test_cliFlags
+func testDiffChanges(d *specDiff) (changes []string) {
+	for _, c := range d.changes {
+		if c.Where == "cli" {
+			continue
+		}
+		level := "non-breaking"
+		if c.Breaking {
+			level = "BREAKING"
+		}
+		changes = append(changes, fmt.Sprintf("%s: %s", level, c.Message))
+	}
+	return
+}
+
+func Test_diffSpecs(t *testing.T) {
+	const empty = `{type: object}`
+
+	tests := []struct {
+		name     string
+		old      string
+		new      string
+		expected []string
+	}{
+		{
+			name:     "request property made required",
+			old:      testSchemaPaths(`{type: object, properties: {name: {type: string}}}`, empty),
+			new:      testSchemaPaths(`{type: object, required: [name], properties: {name: {type: string}}}`, empty),
+			expected: []string{"BREAKING: request body.name: required changed from false to true"},
+		},
+		{
+			name:     "response property made required",
+			old:      testSchemaPaths(empty, `{type: object, properties: {name: {type: string}}}`),
+			new:      testSchemaPaths(empty, `{type: object, required: [name], properties: {name: {type: string}}}`),
+			expected: []string{"non-breaking: response 200.name: required changed from false to true"},
+		},
+		{
+			name:     "response property made optional",
+			old:      testSchemaPaths(empty, `{type: object, required: [name], properties: {name: {type: string}}}`),
+			new:      testSchemaPaths(empty, `{type: object, properties: {name: {type: string}}}`),
+			expected: []string{"BREAKING: response 200.name: required changed from true to false"},
+		},
+		{
+			name:     "required request property added",
+			old:      testSchemaPaths(`{type: object, properties: {name: {type: string}}}`, empty),
+			new:      testSchemaPaths(`{type: object, required: [size], properties: {name: {type: string}, size: {type: integer}}}`, empty),
+			expected: []string{"BREAKING: request body.size: property added (required: true)"},
+		},
+		{
+			name:     "required response property added",
+			old:      testSchemaPaths(empty, `{type: object, properties: {name: {type: string}}}`),
+			new:      testSchemaPaths(empty, `{type: object, required: [size], properties: {name: {type: string}, size: {type: integer}}}`),
+			expected: []string{"non-breaking: response 200.size: property added (required: true)"},
+		},
+		{
+			name: "request enum values",
+			old:  testSchemaPaths(`{type: string, enum: [small, large]}`, empty),
+			new:  testSchemaPaths(`{type: string, enum: [large, huge]}`, empty),
+			expected: []string{
+				`BREAKING: request body: enum value "small" removed`,
+				`non-breaking: request body: enum value "huge" added`,
+			},
+		},
+		{
+			name: "response enum values",
+			old:  testSchemaPaths(empty, `{type: string, enum: [small, large]}`),
+			new:  testSchemaPaths(empty, `{type: string, enum: [large, huge]}`),
+			expected: []string{
+				`non-breaking: response 200: enum value "small" removed`,
+				`BREAKING: response 200: enum value "huge" added`,
+			},
+		},
+		{
+			name:     "request enum added",
+			old:      testSchemaPaths(`{type: string}`, empty),
+			new:      testSchemaPaths(`{type: string, enum: [small]}`, empty),
+			expected: []string{"BREAKING: request body: enum [small] added"},
+		},
+		{
+			name:     "response enum added",
+			old:      testSchemaPaths(empty, `{type: string}`),
+			new:      testSchemaPaths(empty, `{type: string, enum: [small]}`),
+			expected: []string{"non-breaking: response 200: enum [small] added"},
+		},
+		{
+			name:     "request made nullable",
+			old:      testSchemaPaths(`{type: string}`, empty),
+			new:      testSchemaPaths(`{type: string, nullable: true}`, empty),
+			expected: []string{"non-breaking: request body: nullable changed from false to true"},
+		},
+		{
+			name:     "request made not nullable",
+			old:      testSchemaPaths(`{type: string, nullable: true}`, empty),
+			new:      testSchemaPaths(`{type: string}`, empty),
+			expected: []string{"BREAKING: request body: nullable changed from true to false"},
+		},
+		{
+			name:     "response made nullable",
+			old:      testSchemaPaths(empty, `{type: string}`),
+			new:      testSchemaPaths(empty, `{type: string, nullable: true}`),
+			expected: []string{"BREAKING: response 200: nullable changed from false to true"},
+		},
+		{
+			name:     "response made not nullable",
+			old:      testSchemaPaths(empty, `{type: string, nullable: true}`),
+			new:      testSchemaPaths(empty, `{type: string}`),
+			expected: []string{"non-breaking: response 200: nullable changed from true to false"},
+		},
+		{
+			name:     "request widened from integer to number",
+			old:      testSchemaPaths(`{type: object, properties: {size: {type: integer}}}`, empty),
+			new:      testSchemaPaths(`{type: object, properties: {size: {type: number}}}`, empty),
+			expected: []string{"non-breaking: request body.size: type changed from integer to number"},
+		},
+		{
+			name:     "response widened from integer to number",
+			old:      testSchemaPaths(empty, `{type: object, properties: {size: {type: integer}}}`),
+			new:      testSchemaPaths(empty, `{type: object, properties: {size: {type: number}}}`),
+			expected: []string{"BREAKING: response 200.size: type changed from integer to number"},
+		},
+		{
+			name:     "request narrowed from number to integer",
+			old:      testSchemaPaths(`{type: object, properties: {size: {type: number}}}`, empty),
+			new:      testSchemaPaths(`{type: object, properties: {size: {type: integer}}}`, empty),
+			expected: []string{"BREAKING: request body.size: type changed from number to integer"},
+		},
+		{
+			name: "path variable renamed",
+			old: `  /vms/{id}:
+    get:
+      tags: [vms]
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses:
+        "200": {description: ok}
+`,
+			new: `  /vms/{vm_id}:
+    get:
+      tags: [vms]
+      parameters:
+        - {name: vm_id, in: path, required: true, schema: {type: string}}
+      responses:
+        "200": {description: ok}
+`,
+			expected: []string{"non-breaking: path parameter id renamed to vm_id"},
+		},
+		{
+			name: "responses removed",
+			old: `  /vms:
+    get:
+      tags: [vms]
+      responses:
+        "200": {description: ok}
+        "202": {description: accepted}
+        "404": {description: not found}
+`,
+			new: `  /vms:
+    get:
+      tags: [vms]
+      responses:
+        "200": {description: ok}
+`,
+			expected: []string{
+				"BREAKING: response 202 removed",
+				"non-breaking: response 404 removed",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := diffSpecs(parseTestSpec(t, tc.old), parseTestSpec(t, tc.new))
+			got := testDiffChanges(d)
+			if !slices.Equal(tc.expected, got) {
+				t.Errorf("diverging changes:\nEXPECTED:\n%s\n\nGOT:\n%s\n", strings.Join(tc.expected, "\n"), strings.Join(got, "\n"))
+			}
+		})
+	}
+}
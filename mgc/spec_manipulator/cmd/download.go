@@ -28,3 +28,7 @@ var downloadSpecsCmd = &cobra.Command{
 
 	},
 }
+
+func init() {
+	addOverlaysFlag(downloadSpecsCmd)
+}
@@ -0,0 +1,415 @@
+package cmd
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed mgc_extensions.schema.json
+var mgcExtensionsSchemaData []byte
+
+const mgcExtensionsSchemaURL = "https://magalu.cloud/schemas/openapi-extensions.json"
+
+// matches the wait-termination document references, ex: $.result.status, $.owner.parameters.id
+var waitTerminationRefRe = regexp.MustCompile(`\$\.(owner\.)?(result|parameters)((?:\.[A-Za-z_][\w-]*|\[(?:\d+|\*)\])*)`)
+
+var waitTerminationRefTokenRe = regexp.MustCompile(`\.([A-Za-z_][\w-]*)|\[(?:\d+|\*)\]`)
+
+// mgcExtensionSchemas compiles the schema of each extension in mgc_extensions.schema.json
+var mgcExtensionSchemas = sync.OnceValues(func() (map[string]*jsonschema.Schema, error) {
+	var doc struct {
+		Defs map[string]any `json:"$defs"`
+	}
+	if err := json.Unmarshal(mgcExtensionsSchemaData, &doc); err != nil {
+		return nil, err
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(mgcExtensionsSchemaURL, bytes.NewReader(mgcExtensionsSchemaData)); err != nil {
+		return nil, err
+	}
+
+	schemas := map[string]*jsonschema.Schema{}
+	for name := range doc.Defs {
+		if !strings.HasPrefix(name, mgcExtensionPrefix) {
+			continue
+		}
+		schema, err := compiler.Compile(mgcExtensionsSchemaURL + "#/$defs/" + name)
+		if err != nil {
+			return nil, err
+		}
+		schemas[name] = schema
+	}
+	return schemas, nil
+})
+
+type lintProblem struct {
+	// JSON Pointer in the spec, or "cli" for the generated commands
+	Location string
+	Message  string
+}
+
+type specLinter struct {
+	problems []lintProblem
+}
+
+func (l *specLinter) add(location string, format string, args ...any) {
+	l.problems = append(l.problems, lintProblem{Location: location, Message: fmt.Sprintf(format, args...)})
+}
+
+func jsonPointer(tokens ...string) string {
+	var sb strings.Builder
+	for _, token := range tokens {
+		sb.WriteString("/")
+		sb.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(token))
+	}
+	return sb.String()
+}
+
+func validationMessages(err *jsonschema.ValidationError) (messages []string) {
+	if len(err.Causes) == 0 {
+		location := err.InstanceLocation
+		if location == "" {
+			location = "value"
+		}
+		return []string{location + ": " + err.Message}
+	}
+	for _, cause := range err.Causes {
+		for _, msg := range validationMessages(cause) {
+			if !slices.Contains(messages, msg) {
+				messages = append(messages, msg)
+			}
+		}
+	}
+	return
+}
+
+func (l *specLinter) lintExtensionValue(location string, name string, node *yaml.Node) {
+	schemas, err := mgcExtensionSchemas()
+	if err != nil {
+		l.add(location, "cannot load extension schemas: %s", err)
+		return
+	}
+
+	schema, ok := schemas[name]
+	if !ok {
+		l.add(location, "unknown extension %s, expected one of %v", name, slices.Sorted(maps.Keys(schemas)))
+		return
+	}
+
+	var value any
+	if err := node.Decode(&value); err != nil {
+		l.add(location, "invalid value: %s", err)
+		return
+	}
+	// the validator expects the values as decoded from JSON
+	data, err := json.Marshal(value)
+	if err != nil {
+		l.add(location, "invalid value: %s", err)
+		return
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(&value); err != nil {
+		l.add(location, "invalid value: %s", err)
+		return
+	}
+
+	if err := schema.Validate(value); err != nil {
+		if verr, ok := err.(*jsonschema.ValidationError); ok {
+			// oneOf reports why each alternative failed, list all of them
+			l.add(location, "invalid %s:\n    - %s", name, strings.Join(validationMessages(verr), "\n    - "))
+		} else {
+			l.add(location, "invalid %s: %s", name, err)
+		}
+	}
+}
+
+// lintExtensions validates every x-mgc extension in the document tree against its schema
+func (l *specLinter) lintExtensions(node *yaml.Node, tokens []string) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			l.lintExtensions(child, tokens)
+		}
+	case yaml.MappingNode:
+		for i := 0; i < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			childTokens := append(slices.Clone(tokens), key)
+			if strings.HasPrefix(key, mgcExtensionPrefix) {
+				l.lintExtensionValue(jsonPointer(childTokens...), key, value)
+				continue
+			}
+			l.lintExtensions(value, childTokens)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			l.lintExtensions(child, append(slices.Clone(tokens), fmt.Sprint(i)))
+		}
+	}
+}
+
+// successSchema returns the JSON schema of the first 2xx response, the one used as the operation result
+func successSchema(op *v3.Operation) *base.Schema {
+	if op.Responses == nil || op.Responses.Codes == nil {
+		return nil
+	}
+	for code := op.Responses.Codes.Oldest(); code != nil; code = code.Next() {
+		if !strings.HasPrefix(code.Key, "2") || code.Value.Content == nil {
+			continue
+		}
+		if mt := code.Value.Content.GetOrZero("application/json"); mt != nil && mt.Schema != nil {
+			return mt.Schema.Schema()
+		}
+	}
+	return nil
+}
+
+// findSchemaProperty returns the property from the schema, allOf, anyOf or oneOf.
+// If the schema doesn't declare all of its properties, known is false as anything may exist
+func findSchemaProperty(schema *base.Schema, name string) (prop *base.Schema, known bool) {
+	candidates := []*base.Schema{schema}
+	for _, proxy := range slices.Concat(schema.AnyOf, schema.OneOf) {
+		candidates = append(candidates, proxy.Schema())
+	}
+
+	known = len(candidates) > 1 || len(propertiesByName(schema)) > 0
+	for _, s := range candidates {
+		if s == nil {
+			continue
+		}
+		if p, ok := propertiesByName(s)[name]; ok {
+			return p.schema, true
+		}
+		if additional := s.AdditionalProperties; additional != nil && (additional.A != nil || additional.B) {
+			known = false
+		}
+	}
+	return nil, known
+}
+
+// checkResultRef checks the path, ex: ".status[0].name", exists in the result schema
+func checkResultRef(schema *base.Schema, path string) error {
+	for _, m := range waitTerminationRefTokenRe.FindAllStringSubmatch(path, -1) {
+		if schema == nil {
+			return nil
+		}
+
+		name := m[1]
+		if name == "" {
+			if schema.Items == nil || schema.Items.A == nil {
+				return nil
+			}
+			schema = schema.Items.A.Schema()
+			continue
+		}
+
+		prop, known := findSchemaProperty(schema, name)
+		if prop == nil {
+			if known {
+				return fmt.Errorf("field %q does not exist", name)
+			}
+			return nil
+		}
+		schema = prop
+	}
+	return nil
+}
+
+func hasParameter(op *specOperation, name string) bool {
+	for _, param := range mergedParameters(op) {
+		if param.Name == name || getMgcExtension(param.Extensions, "name") == name {
+			return true
+		}
+	}
+	if op.Op.RequestBody != nil {
+		for _, prop := range collectProperties(getMediaTypeSchema(op.Op.RequestBody.Content), map[*base.Schema]bool{}) {
+			if prop.name == name || getMgcExtension(prop.schema.Extensions, "name") == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lintWaitTermination checks the queries reference fields of the target result and parameters.
+// Links have an owner, the operation with the link, while operations don't.
+func (l *specLinter) lintWaitTermination(location string, extension *yaml.Node, target *specOperation, owner *specOperation) {
+	var cfg map[string]any
+	if err := extension.Decode(&cfg); err != nil {
+		return // reported by lintExtensions
+	}
+
+	for _, key := range []string{"jsonPathQuery", "errorJsonPathQuery"} {
+		query, _ := cfg[key].(string)
+		for _, m := range waitTerminationRefRe.FindAllStringSubmatch(query, -1) {
+			op := target
+			if m[1] != "" {
+				if owner == nil {
+					l.add(location, "%s: %q refers to the owner, but only links have one", key, m[0])
+					continue
+				}
+				op = owner
+			}
+
+			switch m[2] {
+			case "result":
+				schema := successSchema(op.Op)
+				if schema == nil {
+					l.add(location, "%s: %q refers to the result of %s, which has no JSON response", key, m[0], op.Key)
+				} else if err := checkResultRef(schema, m[3]); err != nil {
+					l.add(location, "%s: %q does not match the response of %s: %s", key, m[0], op.Key, err)
+				}
+			case "parameters":
+				if tokens := waitTerminationRefTokenRe.FindStringSubmatch(m[3]); tokens != nil && tokens[1] != "" && !hasParameter(op, tokens[1]) {
+					l.add(location, "%s: %q refers to unknown parameter %q of %s", key, m[0], tokens[1], op.Key)
+				}
+			}
+		}
+	}
+}
+
+func (l *specLinter) lintOperations(ops []*specOperation) {
+	byOperationId := map[string]*specOperation{}
+	for _, op := range ops {
+		if op.Op.OperationId != "" {
+			byOperationId[op.Op.OperationId] = op
+		}
+	}
+
+	byPath := map[string]*specOperation{}
+	for _, op := range ops {
+		byPath[jsonPointer("paths", op.Path, op.Method)] = op
+	}
+
+	for _, op := range ops {
+		opLocation := jsonPointer("paths", op.Path, op.Method)
+		if ext := op.Op.Extensions; ext != nil {
+			if wt := ext.GetOrZero(mgcExtensionPrefix + "wait-termination"); wt != nil {
+				l.lintWaitTermination(opLocation+jsonPointer(mgcExtensionPrefix+"wait-termination"), wt, op, nil)
+			}
+		}
+
+		for code, response := range responsesByCode(op.Op.Responses) {
+			if response.Links == nil {
+				continue
+			}
+			for link := response.Links.Oldest(); link != nil; link = link.Next() {
+				location := opLocation + jsonPointer("responses", code, "links", link.Key)
+
+				var target *specOperation
+				switch {
+				case link.Value.OperationId != "":
+					target = byOperationId[link.Value.OperationId]
+					if target == nil {
+						l.add(location, "operationId %q does not exist", link.Value.OperationId)
+						continue
+					}
+				case strings.HasPrefix(link.Value.OperationRef, "#"):
+					target = byPath[strings.TrimPrefix(link.Value.OperationRef, "#")]
+					if target == nil {
+						l.add(location, "operationRef %q does not exist", link.Value.OperationRef)
+						continue
+					}
+				case link.Value.OperationRef != "":
+					continue // other modules are not known here
+				default:
+					l.add(location, "missing operationId or operationRef")
+					continue
+				}
+
+				if ext := link.Value.Extensions; ext != nil {
+					if wt := ext.GetOrZero(mgcExtensionPrefix + "wait-termination"); wt != nil {
+						l.lintWaitTermination(location+jsonPointer(mgcExtensionPrefix+"wait-termination"), wt, target, op)
+					}
+				}
+			}
+		}
+	}
+}
+
+func (l *specLinter) lintCommands(doc *v3.Document, ops []*specOperation) {
+	byCommand := map[string][]string{}
+	commands := cliCommands(doc, ops)
+	for _, key := range slices.Sorted(maps.Keys(commands)) {
+		for _, command := range commands[key] {
+			byCommand[command] = append(byCommand[command], key)
+		}
+	}
+
+	for _, command := range slices.Sorted(maps.Keys(byCommand)) {
+		if keys := byCommand[command]; len(keys) > 1 {
+			l.add("cli", "command %q is generated for %d operations: %s. Use x-mgc-name to rename them", command, len(keys), strings.Join(keys, ", "))
+		}
+	}
+}
+
+func lintSpec(file string) ([]lintProblem, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	root := &yaml.Node{}
+	if err = yaml.Unmarshal(data, root); err != nil {
+		return nil, err
+	}
+
+	doc, err := loadV3Document(file)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &specLinter{}
+	l.lintExtensions(root, nil)
+	ops := collectSpecOperations(doc)
+	l.lintOperations(ops)
+	l.lintCommands(doc, ops)
+	return l.problems, nil
+}
+
+var lintSpecsCmd = &cobra.Command{
+	Use:   "lint [spec...]",
+	Short: "Validate the x-mgc extensions of the specs",
+	Long: `Validate every x-mgc extension against its JSON Schema (mgc_extensions.schema.json),
+and check that:
+  - wait-termination JSON Paths reference existing response fields and parameters;
+  - links target operations that exist;
+  - x-mgc-name and the paths don't generate duplicate CLI command names.`,
+	Example: "  specs lint mgc/cli/openapis/block-storage.openapi.yaml",
+	Args:    cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		total := 0
+		for _, file := range args {
+			problems, err := lintSpec(file)
+			if err != nil {
+				fmt.Printf("%s: %s\n", file, err)
+				total++
+				continue
+			}
+			for _, p := range problems {
+				fmt.Printf("%s: %s: %s\n", file, p.Location, p.Message)
+			}
+			total += len(problems)
+		}
+
+		if total > 0 {
+			fmt.Printf("\n%d problems found\n", total)
+			os.Exit(1)
+		}
+		fmt.Println("No problems found")
+	},
+}
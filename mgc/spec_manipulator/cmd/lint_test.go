@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func Test_lintSpec(t *testing.T) {
+	tests := []struct {
+		file     string
+		expected []string
+	}{
+		{
+			file: "valid.yaml",
+		},
+		{
+			file: "invalid_extension.yaml",
+			expected: []string{
+				"/tags/0/x-mgc-hidden: invalid x-mgc-hidden:\n    - value: expected boolean, but got string",
+				"/paths/~1v1~1instances~1{id}/get/x-mgc-colour: unknown extension x-mgc-colour, expected one of [x-mgc-confirmable x-mgc-description x-mgc-extra-parameters x-mgc-hidden x-mgc-name x-mgc-observations x-mgc-output-flag x-mgc-promptInput x-mgc-requestBodyParameters x-mgc-transforms x-mgc-wait-termination]",
+				`/paths/~1v1~1instances~1{id}/get/x-mgc-wait-termination: invalid x-mgc-wait-termination:` + "\n" + `    - /interval: does not match pattern '^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$'`,
+			},
+		},
+		{
+			file: "wait_termination.yaml",
+			expected: []string{
+				`/paths/~1v1~1instances~1{id}/get/x-mgc-wait-termination: jsonPathQuery: "$.result.state" does not match the response of GET /v1/instances/{}: field "state" does not exist`,
+				`/paths/~1v1~1instances~1{id}/get/x-mgc-wait-termination: errorJsonPathQuery: "$.parameters.instance_id" refers to unknown parameter "instance_id" of GET /v1/instances/{}`,
+				`/paths/~1v1~1instances~1{id}/get/x-mgc-wait-termination: errorJsonPathQuery: "$.owner.result.id" refers to the owner, but only links have one`,
+				`/paths/~1v1~1instances~1{id}/delete/x-mgc-wait-termination: jsonPathQuery: "$.result.status" refers to the result of DELETE /v1/instances/{}, which has no JSON response`,
+			},
+		},
+		{
+			file: "links.yaml",
+			expected: []string{
+				`/paths/~1v1~1instances/post/responses/200/links/get: operationId "get-instance" does not exist`,
+				`/paths/~1v1~1instances/post/responses/200/links/delete: operationRef "#/paths/~1v1~1instances~1{id}/delete" does not exist`,
+				`/paths/~1v1~1instances/post/responses/200/links/other: missing operationId or operationRef`,
+			},
+		},
+		{
+			file: "duplicate_command.yaml",
+			expected: []string{
+				`cli: command "instances list" is generated for 2 operations: GET /v1/instances, GET /v1/instances/{}. Use x-mgc-name to rename them`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.file, func(t *testing.T) {
+			problems, err := lintSpec(filepath.Join("testdata", "lint", tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, p := range problems {
+				got = append(got, p.Location+": "+p.Message)
+			}
+			if !slices.Equal(tc.expected, got) {
+				t.Errorf("diverging problems:\nEXPECTED:\n%s\n\nGOT:\n%s\n", strings.Join(tc.expected, "\n"), strings.Join(got, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vmware-labs/yaml-jsonpath/pkg/yamlpath"
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPI Overlay 1.0: https://spec.openapis.org/overlay/v1.0.0.html
+
+const (
+	overlayFileSuffix = ".overlay.yaml"
+	// relative to the executable, built by build.sh at mgc/spec_manipulator
+	OVERLAYS_DIR = "../../openapi-customizations"
+)
+
+var defaultOverlaysDir = func() string {
+	ex, err := os.Executable()
+	if err != nil {
+		panic(err)
+	}
+	return filepath.Join(filepath.Dir(ex), OVERLAYS_DIR)
+}
+
+func addOverlaysFlag(cmd *cobra.Command) {
+	cmd.Flags().String("overlays", defaultOverlaysDir(), "Directory with the <menu>.overlay.yaml OpenAPI Overlay files applied to each spec, empty to skip them")
+}
+
+type overlayAction struct {
+	// JSONPath selecting the objects or arrays to change
+	Target      string `yaml:"target"`
+	Description string `yaml:"description,omitempty"`
+	// Merged into the selected objects, or appended to the selected arrays
+	Update yaml.Node `yaml:"update,omitempty"`
+	// Removes the selected nodes from their parents
+	Remove bool `yaml:"remove,omitempty"`
+}
+
+type overlayDocument struct {
+	Overlay string `yaml:"overlay"`
+	Info    struct {
+		Title   string `yaml:"title"`
+		Version string `yaml:"version"`
+	} `yaml:"info"`
+	Extends string           `yaml:"extends,omitempty"`
+	Actions []*overlayAction `yaml:"actions"`
+}
+
+func loadOverlay(file string) (*overlayDocument, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	overlay := &overlayDocument{}
+	if err = yaml.Unmarshal(data, overlay); err != nil {
+		return nil, fmt.Errorf("invalid overlay %s: %w", file, err)
+	}
+	if !strings.HasPrefix(overlay.Overlay, "1.0.") {
+		return nil, fmt.Errorf("invalid overlay %s: unsupported version %q, expected 1.0.x", file, overlay.Overlay)
+	}
+	if len(overlay.Actions) == 0 {
+		return nil, fmt.Errorf("invalid overlay %s: missing actions", file)
+	}
+	return overlay, nil
+}
+
+func cloneNode(node *yaml.Node) *yaml.Node {
+	clone := *node
+	clone.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		clone.Content[i] = cloneNode(child)
+	}
+	return &clone
+}
+
+// mergeNode merges the update mapping into target, replacing the values that are not mappings in both
+func mergeNode(target, update *yaml.Node) {
+	for i := 0; i < len(update.Content); i += 2 {
+		key, value := update.Content[i], update.Content[i+1]
+
+		found := false
+		for j := 0; j < len(target.Content); j += 2 {
+			if target.Content[j].Value != key.Value {
+				continue
+			}
+			found = true
+			if existing := target.Content[j+1]; existing.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode {
+				mergeNode(existing, value)
+			} else {
+				target.Content[j+1] = cloneNode(value)
+			}
+			break
+		}
+
+		if !found {
+			target.Content = append(target.Content, cloneNode(key), cloneNode(value))
+		}
+	}
+}
+
+// removeNode removes target from its parent, searching from node
+func removeNode(node, target *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i < len(node.Content); i += 2 {
+			if node.Content[i+1] == target {
+				node.Content = append(node.Content[:i], node.Content[i+2:]...)
+				return true
+			}
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			if child == target {
+				node.Content = append(node.Content[:i], node.Content[i+1:]...)
+				return true
+			}
+		}
+	}
+
+	for _, child := range node.Content {
+		if removeNode(child, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func applyOverlayAction(root *yaml.Node, action *overlayAction) error {
+	hasUpdate := action.Update.Kind != 0
+	if action.Remove == hasUpdate {
+		return errors.New("expected exactly one of update or remove")
+	}
+
+	path, err := yamlpath.NewPath(action.Target)
+	if err != nil {
+		return fmt.Errorf("invalid target %q: %w", action.Target, err)
+	}
+	matches, err := path.Find(root)
+	if err != nil {
+		return fmt.Errorf("invalid target %q: %w", action.Target, err)
+	}
+	if len(matches) == 0 {
+		return fmt.Errorf("target %q matches nothing", action.Target)
+	}
+
+	for _, match := range matches {
+		switch {
+		case action.Remove:
+			removeNode(root, match)
+		case match.Kind == yaml.SequenceNode:
+			match.Content = append(match.Content, cloneNode(&action.Update))
+		case match.Kind == yaml.MappingNode && action.Update.Kind == yaml.MappingNode:
+			mergeNode(match, &action.Update)
+		default:
+			return fmt.Errorf("target %q must select objects or arrays, and update must be an object for objects", action.Target)
+		}
+	}
+	return nil
+}
+
+// clearFlowStyle keeps the output in block style when the spec was JSON
+func clearFlowStyle(node *yaml.Node) {
+	node.Style &^= yaml.FlowStyle
+	for _, child := range node.Content {
+		clearFlowStyle(child)
+	}
+}
+
+// applyOverlay applies all actions in order. Actions that fail, including targets matching nothing,
+// are reported together and the spec is not returned
+func applyOverlay(specData []byte, overlay *overlayDocument) ([]byte, error) {
+	root := &yaml.Node{}
+	if err := yaml.Unmarshal(specData, root); err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for i, action := range overlay.Actions {
+		if err := applyOverlayAction(root, action); err != nil {
+			name := action.Description
+			if name == "" {
+				name = action.Target
+			}
+			errs = append(errs, fmt.Errorf("action %d (%s): %w", i, name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	clearFlowStyle(root)
+	return yaml.Marshal(root)
+}
+
+// applySpecOverlay applies the "<menu>.overlay.yaml" file from dir, if it exists
+func applySpecOverlay(dir string, menu string, specData []byte) ([]byte, error) {
+	if dir == "" {
+		return specData, nil
+	}
+
+	file := filepath.Join(dir, menu+overlayFileSuffix)
+	if _, err := os.Stat(file); os.IsNotExist(err) {
+		return specData, nil
+	}
+
+	overlay, err := loadOverlay(file)
+	if err != nil {
+		return nil, err
+	}
+	result, err := applyOverlay(specData, overlay)
+	if err != nil {
+		return nil, fmt.Errorf("cannot apply overlay %s:\n%w", file, err)
+	}
+	fmt.Printf("Overlay applied: %s\n", file)
+	return result, nil
+}
+
+var overlaySpecCmd = &cobra.Command{
+	Use:   "overlay [spec] [overlay...]",
+	Short: "Apply OpenAPI Overlay documents to a spec",
+	Long: `Apply OpenAPI Overlay 1.0 documents to a spec, in order, and print the result.
+
+Each action selects nodes with a JSONPath target, then merges the update
+object into them, appends it to the selected arrays or removes them.
+Actions whose target matches nothing are errors.`,
+	Example: `  specs overlay cli_specs/block-storage.jaxyendy.openapi.json block-storage.overlay.yaml
+
+  # block-storage.overlay.yaml
+  overlay: 1.0.0
+  info:
+    title: Block Storage customizations
+    version: 1.0.0
+  actions:
+    - target: $.paths['/v1/volumes/{id}'].delete
+      update:
+        x-mgc-name: remove
+    - target: $.paths['/v1/volumes'].get.parameters[?(@.name == '_sort')]
+      remove: true`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		specData, err := os.ReadFile(args[0])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		for _, file := range args[1:] {
+			overlay, err := loadOverlay(file)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			specData, err = applyOverlay(specData, overlay)
+			if err != nil {
+				fmt.Printf("cannot apply overlay %s:\n%s\n", file, err)
+				os.Exit(1)
+			}
+		}
+
+		fmt.Print(string(specData))
+	},
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func Test_applyOverlayAction(t *testing.T) {
+	const spec = `
+paths:
+  /volumes:
+    get:
+      operationId: list
+      parameters:
+        - {name: _limit, in: query}
+        - {name: _sort, in: query}
+`
+
+	tests := []struct {
+		name     string
+		action   string
+		expected string
+		err      string
+	}{
+		{
+			name: "merge into object",
+			action: `
+target: $.paths['/volumes'].get
+update:
+  operationId: list-volumes
+  x-mgc-name: list`,
+			expected: `
+paths:
+  /volumes:
+    get:
+      operationId: list-volumes
+      parameters:
+        - {name: _limit, in: query}
+        - {name: _sort, in: query}
+      x-mgc-name: list
+`,
+		},
+		{
+			name: "append into array",
+			action: `
+target: $.paths['/volumes'].get.parameters
+update: {name: _offset, in: query}`,
+			expected: `
+paths:
+  /volumes:
+    get:
+      operationId: list
+      parameters:
+        - {name: _limit, in: query}
+        - {name: _sort, in: query}
+        - {name: _offset, in: query}
+`,
+		},
+		{
+			name: "remove array element",
+			action: `
+target: $.paths['/volumes'].get.parameters[?(@.name == '_sort')]
+remove: true`,
+			expected: `
+paths:
+  /volumes:
+    get:
+      operationId: list
+      parameters:
+        - {name: _limit, in: query}
+`,
+		},
+		{
+			name: "target matches nothing",
+			action: `
+target: $.paths['/snapshots'].get
+update: {x-mgc-name: list}`,
+			err: `target "$.paths['/snapshots'].get" matches nothing`,
+		},
+		{
+			name: "update and remove",
+			action: `
+target: $.paths['/volumes'].get
+update: {x-mgc-name: list}
+remove: true`,
+			err: "expected exactly one of update or remove",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			root := &yaml.Node{}
+			if err := yaml.Unmarshal([]byte(spec), root); err != nil {
+				t.Fatal(err)
+			}
+			action := &overlayAction{}
+			if err := yaml.Unmarshal([]byte(tc.action), action); err != nil {
+				t.Fatal(err)
+			}
+
+			err := applyOverlayAction(root, action)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Errorf("expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got, expected any
+			if err = root.Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if err = yaml.Unmarshal([]byte(tc.expected), &expected); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(expected, got) {
+				result, _ := yaml.Marshal(root)
+				t.Errorf("expected:\n%s\ngot:\n%s", tc.expected, result)
+			}
+		})
+	}
+}
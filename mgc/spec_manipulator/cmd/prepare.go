@@ -39,6 +39,8 @@ func runPrepare(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	overlaysDir, _ := cmd.Flags().GetString("overlays")
+
 	finalFile := filepath.Join(currentDir(), "specs.go.tmp")
 	newFileSpecs, err := os.OpenFile(finalFile, os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
@@ -84,6 +86,16 @@ func runPrepare(cmd *cobra.Command, args []string) {
 				return
 			}
 
+			// customizations must apply cleanly, otherwise they silently drift from the spec
+			fileBytes, err = applySpecOverlay(overlaysDir, v.Menu, fileBytes)
+			if err != nil {
+				fmt.Println(err)
+				// os.Exit skips the deferred Close, and the partial file must not be used
+				_ = newFileSpecs.Close()
+				_ = os.Remove(finalFile)
+				os.Exit(1)
+			}
+
 			document, err := libopenapi.NewDocument(fileBytes)
 			if err != nil {
 				panic(fmt.Sprintf("cannot read document: %e", err))
@@ -226,3 +238,7 @@ var prepareToGoCmd = &cobra.Command{
 	Hidden: true,
 	Run:    runPrepare,
 }
+
+func init() {
+	addOverlaysFlag(prepareToGoCmd)
+}
@@ -48,6 +48,9 @@ func init() {
 	rootCmd.AddCommand(listSpecsCmd)     // list specs
 	rootCmd.AddCommand(prepareToGoCmd)   // convert spec to golang
 	rootCmd.AddCommand(downgradeSpecCmd) // downgrade spec
+	rootCmd.AddCommand(diffSpecsCmd)     // diff specs
+	rootCmd.AddCommand(overlaySpecCmd)   // apply overlays
+	rootCmd.AddCommand(lintSpecsCmd)     // lint specs
 
 }
 
@@ -0,0 +1,7 @@
+data "mgc_dbaas_engines" "engines" {
+  status = "ACTIVE"
+}
+
+output "dbaas_engines" {
+  value = data.mgc_dbaas_engines.engines.engines
+}
@@ -0,0 +1,7 @@
+data "mgc_dbaas_instance_types" "instance_types" {
+  status = "ACTIVE"
+}
+
+output "dbaas_instance_types" {
+  value = data.mgc_dbaas_instance_types.instance_types.instance_types
+}
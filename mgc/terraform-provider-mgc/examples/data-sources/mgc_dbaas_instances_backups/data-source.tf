@@ -0,0 +1,8 @@
+data "mgc_dbaas_instances_backups" "backups" {
+  instance_id = mgc_dbaas_instances.dbaas_instances.id
+  status      = "CREATED"
+}
+
+output "dbaas_backups" {
+  value = data.mgc_dbaas_instances_backups.backups.backups
+}
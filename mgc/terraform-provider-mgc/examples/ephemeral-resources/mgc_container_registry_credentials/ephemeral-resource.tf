@@ -0,0 +1,9 @@
+ephemeral "mgc_container_registry_credentials" "registry" {}
+
+provider "docker" {
+  registry_auth {
+    address  = var.registry_address
+    username = ephemeral.mgc_container_registry_credentials.registry.username
+    password = ephemeral.mgc_container_registry_credentials.registry.password
+  }
+}
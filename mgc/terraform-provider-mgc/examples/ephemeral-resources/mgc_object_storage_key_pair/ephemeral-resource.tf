@@ -0,0 +1,14 @@
+ephemeral "mgc_object_storage_key_pair" "terraform" {
+  name = "terraform"
+}
+
+provider "mgc" {
+  alias  = "buckets"
+  region = var.region
+  object_storage = {
+    key_pair = {
+      key_id     = ephemeral.mgc_object_storage_key_pair.terraform.key_id
+      key_secret = ephemeral.mgc_object_storage_key_pair.terraform.key_secret
+    }
+  }
+}
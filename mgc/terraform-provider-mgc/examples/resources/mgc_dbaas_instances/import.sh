@@ -0,0 +1 @@
+terraform import mgc_dbaas_instances.dbaas_instances 123
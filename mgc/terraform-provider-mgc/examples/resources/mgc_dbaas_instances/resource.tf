@@ -1,10 +1,26 @@
+data "mgc_dbaas_engines" "active" {
+  status = "ACTIVE"
+}
+
+data "mgc_dbaas_instance_types" "mysql" {
+  engine_id = data.mgc_dbaas_engines.active.engines[0].id
+}
+
 resource "mgc_dbaas_instances" "dbaas_instances" {
-  name      = "my-database-instance"
-  flavor_id = "your-flavor-id"
-  user      = "db_user"
-  password  = "secure_password123"
+  name             = "my-database-instance"
+  user             = "db_user"
+  password         = "secure_password123"
+  engine_id        = data.mgc_dbaas_engines.active.engines[0].id
+  instance_type_id = data.mgc_dbaas_instance_types.mysql.instance_types[0].id
+  volume_size      = 50 # Size in GiB
+
+  backup_retention_days = 7
+  backup_start_at       = "03:00:00"
 
-  volume {
-    size = 50 # Size in GiB
+  parameters = {
+    max_connections = "200"
   }
+
+  # Set to "STOPPED" to stop the instance
+  status = "ACTIVE"
 }
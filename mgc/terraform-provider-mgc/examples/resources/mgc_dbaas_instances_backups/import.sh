@@ -0,0 +1 @@
+terraform import mgc_dbaas_instances_backups.backup instance_id,backup_id
@@ -1,4 +1,4 @@
 resource "mgc_dbaas_instances_backups" "backup" {
-  instance_id = mgc_dbaas_instances.instance.id
+  instance_id = mgc_dbaas_instances.dbaas_instances.id
   mode        = "FULL"
-}
\ No newline at end of file
+}
@@ -0,0 +1 @@
+terraform import mgc_dbaas_replicas.dbaas_replica 123
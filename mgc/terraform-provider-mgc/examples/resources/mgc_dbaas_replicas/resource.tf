@@ -1,4 +1,4 @@
 resource "mgc_dbaas_replicas" "dbaas_replica" {
-  name = "dbaas-replica"
-  source_id = "123"
-}
\ No newline at end of file
+  name      = "dbaas-replica"
+  source_id = mgc_dbaas_instances.dbaas_instances.id
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"magalu.cloud/terraform-provider-mgc/mgc/tfutil"
 
@@ -12,7 +13,7 @@ import (
 )
 
 type SDKFrom interface {
-	resource.ConfigureRequest | datasource.ConfigureRequest
+	resource.ConfigureRequest | datasource.ConfigureRequest | ephemeral.ConfigureRequest
 }
 
 func NewSDKClient[T SDKFrom](req T) (*mgcSdk.Client, error, error) {
@@ -32,11 +33,46 @@ func NewSDKClient[T SDKFrom](req T) (*mgcSdk.Client, error, error) {
 			break
 		}
 		return nil, fmt.Errorf("%s", devErrMsg), fmt.Errorf("unexpected Data Source Configure Type")
+	case ephemeral.ConfigureRequest:
+		if cfg, ok := tp.ProviderData.(tfutil.ProviderConfig); ok {
+			config = cfg
+			break
+		}
+		return nil, fmt.Errorf("%s", devErrMsg), fmt.Errorf("unexpected Ephemeral Resource Configure Type")
 	default:
 		return nil, fmt.Errorf("%s", devErrMsg), fmt.Errorf("provider data is null")
 	}
 
+	sdkClient := config.SdkClient
+	if sdkClient == nil {
+		return nil, fmt.Errorf("%s", devErrMsg), fmt.Errorf("provider SDK is not configured")
+	}
+
+	workspace := config.Workspace.ValueString()
+	if config.ApiKey.ValueString() == "" {
+		if workspace == "" {
+			return nil, fmt.Errorf("provider with api_key must be setted"), fmt.Errorf(`please check the resource to see if they are using 'provider' and verify if the provider has the 'api_key' or 'workspace' correctly set`)
+		}
+		if !sdkClient.Sdk().Auth().HasTokens() {
+			return nil, fmt.Errorf("workspace %q is not logged in", workspace), fmt.Errorf(`please run 'mgc auth login' with the workspace %q selected or set the provider 'api_key'`, workspace)
+		}
+	}
+
+	return sdkClient, nil, nil
+}
+
+// NewProviderSDKClient creates the SDK shared by all resources, data sources and
+// ephemeral resources of the provider, using the workspace and settings of config
+func NewProviderSDKClient(config tfutil.ProviderConfig) (*mgcSdk.Client, error) {
+	workspace := config.Workspace.ValueString()
+
 	local_sdk := sdk.NewSdk()
+	if workspace != "" {
+		var err error
+		if local_sdk, err = sdk.NewSdkForWorkspace(workspace); err != nil {
+			return nil, err
+		}
+	}
 	sdkClient := mgcSdk.NewClient(local_sdk)
 
 	if config.Region.ValueString() != "" {
@@ -46,16 +82,22 @@ func NewSDKClient[T SDKFrom](req T) (*mgcSdk.Client, error, error) {
 		_ = sdkClient.Sdk().Config().SetTempConfig("env", config.Env.ValueString())
 	}
 
-	if config.ApiKey.ValueString() == "" {
-		return nil, fmt.Errorf("provider with api_key must be setted"), fmt.Errorf(`please check the resource to see if they are using 'provider' and verify if the provider has the 'api_key' correctly set`)
+	if config.ApiKey.ValueString() != "" {
+		_ = sdkClient.Sdk().Auth().SetAPIKey(config.ApiKey.ValueString())
 	}
 
-	_ = sdkClient.Sdk().Auth().SetAPIKey(config.ApiKey.ValueString())
-
-	if config.ObjectStorage != nil && config.ObjectStorage.ObjectKeyPair != nil {
+	// With a workspace, its stored key pair is used unless one is explicitly given
+	if config.ObjectStorage != nil && config.ObjectStorage.ObjectKeyPair != nil &&
+		(workspace == "" || config.ObjectStorage.ObjectKeyPair.KeyID.ValueString() != "") {
 		sdkClient.Sdk().Config().AddTempKeyPair("apikey", config.ObjectStorage.ObjectKeyPair.KeyID.ValueString(),
 			config.ObjectStorage.ObjectKeyPair.KeySecret.ValueString())
 	}
 
-	return sdkClient, nil, nil
+	// The SDK members are created on first use, which is not safe
+	// for the resources configured concurrently, so create them now
+	_ = sdkClient.Sdk().Auth()
+	_ = sdkClient.Sdk().HttpClient()
+	_ = sdkClient.Sdk().Group()
+
+	return sdkClient, nil
 }
@@ -0,0 +1,145 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mgcSdk "magalu.cloud/lib"
+	sdkDbaasEngines "magalu.cloud/lib/products/dbaas/engines"
+	"magalu.cloud/terraform-provider-mgc/mgc/client"
+	"magalu.cloud/terraform-provider-mgc/mgc/tfutil"
+)
+
+const dbaasListPageSize = 25
+
+var _ datasource.DataSource = &DataSourceDbaasEngines{}
+
+type DataSourceDbaasEngines struct {
+	sdkClient    *mgcSdk.Client
+	dbaasEngines sdkDbaasEngines.Service
+}
+
+type dbaasEngineModel struct {
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Engine  types.String `tfsdk:"engine"`
+	Version types.String `tfsdk:"version"`
+	Status  types.String `tfsdk:"status"`
+}
+
+type dbaasEnginesModel struct {
+	Status  types.String       `tfsdk:"status"`
+	Engines []dbaasEngineModel `tfsdk:"engines"`
+}
+
+func NewDataSourceDbaasEngines() datasource.DataSource {
+	return &DataSourceDbaasEngines{}
+}
+
+func (r *DataSourceDbaasEngines) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dbaas_engines"
+}
+
+func (r *DataSourceDbaasEngines) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	var err error
+	var errDetail error
+	r.sdkClient, err, errDetail = client.NewSDKClient(req)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			err.Error(),
+			errDetail.Error(),
+		)
+		return
+	}
+
+	r.dbaasEngines = sdkDbaasEngines.NewService(ctx, r.sdkClient)
+}
+
+func (r *DataSourceDbaasEngines) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Available database engines.",
+		Attributes: map[string]schema.Attribute{
+			"status": schema.StringAttribute{
+				Description: "Filter engines by status. Options: ACTIVE / DEPRECATED.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ACTIVE", "DEPRECATED"),
+				},
+			},
+			"engines": schema.ListNestedAttribute{
+				Description: "List of database engines.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "ID of the engine.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the engine.",
+							Computed:    true,
+						},
+						"engine": schema.StringAttribute{
+							Description: "Type of the engine, such as mysql.",
+							Computed:    true,
+						},
+						"version": schema.StringAttribute{
+							Description: "Version of the engine.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Status of the engine.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *DataSourceDbaasEngines) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data dbaasEnginesModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Engines = []dbaasEngineModel{}
+	limit := dbaasListPageSize
+	for offset := 0; ; offset += limit {
+		result, err := r.dbaasEngines.ListContext(ctx, sdkDbaasEngines.ListParameters{
+			Limit:  &limit,
+			Offset: &offset,
+			Status: data.Status.ValueStringPointer(),
+		}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkDbaasEngines.ListConfigs{}))
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to list database engines", err.Error())
+			return
+		}
+
+		for _, engine := range result.Results {
+			data.Engines = append(data.Engines, dbaasEngineModel{
+				ID:      types.StringValue(engine.Id),
+				Name:    types.StringValue(engine.Name),
+				Engine:  types.StringValue(engine.Engine),
+				Version: types.StringValue(engine.Version),
+				Status:  types.StringValue(engine.Status),
+			})
+		}
+
+		if len(result.Results) < limit {
+			break
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
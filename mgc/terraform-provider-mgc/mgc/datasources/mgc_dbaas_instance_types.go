@@ -0,0 +1,167 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mgcSdk "magalu.cloud/lib"
+	sdkDbaasInstanceTypes "magalu.cloud/lib/products/dbaas/instance_types"
+	"magalu.cloud/terraform-provider-mgc/mgc/client"
+	"magalu.cloud/terraform-provider-mgc/mgc/tfutil"
+)
+
+var _ datasource.DataSource = &DataSourceDbaasInstanceTypes{}
+
+type DataSourceDbaasInstanceTypes struct {
+	sdkClient          *mgcSdk.Client
+	dbaasInstanceTypes sdkDbaasInstanceTypes.Service
+}
+
+type dbaasInstanceTypeModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Label             types.String `tfsdk:"label"`
+	Vcpu              types.String `tfsdk:"vcpu"`
+	Ram               types.String `tfsdk:"ram"`
+	Size              types.String `tfsdk:"size"`
+	FamilySlug        types.String `tfsdk:"family_slug"`
+	FamilyDescription types.String `tfsdk:"family_description"`
+}
+
+type dbaasInstanceTypesModel struct {
+	EngineID      types.String             `tfsdk:"engine_id"`
+	Status        types.String             `tfsdk:"status"`
+	InstanceTypes []dbaasInstanceTypeModel `tfsdk:"instance_types"`
+}
+
+func NewDataSourceDbaasInstanceTypes() datasource.DataSource {
+	return &DataSourceDbaasInstanceTypes{}
+}
+
+func (r *DataSourceDbaasInstanceTypes) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dbaas_instance_types"
+}
+
+func (r *DataSourceDbaasInstanceTypes) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	var err error
+	var errDetail error
+	r.sdkClient, err, errDetail = client.NewSDKClient(req)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			err.Error(),
+			errDetail.Error(),
+		)
+		return
+	}
+
+	r.dbaasInstanceTypes = sdkDbaasInstanceTypes.NewService(ctx, r.sdkClient)
+}
+
+func (r *DataSourceDbaasInstanceTypes) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Available database instance types.",
+		Attributes: map[string]schema.Attribute{
+			"engine_id": schema.StringAttribute{
+				Description: "Filter instance types compatible with the given engine ID.",
+				Optional:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Filter instance types by status. Options: ACTIVE / DEPRECATED.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ACTIVE", "DEPRECATED"),
+				},
+			},
+			"instance_types": schema.ListNestedAttribute{
+				Description: "List of database instance types.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "ID of the instance type.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the instance type.",
+							Computed:    true,
+						},
+						"label": schema.StringAttribute{
+							Description: "Label of the instance type, such as DBaaS-BV1-4-10.",
+							Computed:    true,
+						},
+						"vcpu": schema.StringAttribute{
+							Description: "Number of virtual CPUs.",
+							Computed:    true,
+						},
+						"ram": schema.StringAttribute{
+							Description: "Amount of RAM.",
+							Computed:    true,
+						},
+						"size": schema.StringAttribute{
+							Description: "Size of the instance type.",
+							Computed:    true,
+						},
+						"family_slug": schema.StringAttribute{
+							Description: "Slug of the instance type family.",
+							Computed:    true,
+						},
+						"family_description": schema.StringAttribute{
+							Description: "Description of the instance type family.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *DataSourceDbaasInstanceTypes) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data dbaasInstanceTypesModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.InstanceTypes = []dbaasInstanceTypeModel{}
+	limit := dbaasListPageSize
+	for offset := 0; ; offset += limit {
+		result, err := r.dbaasInstanceTypes.ListContext(ctx, sdkDbaasInstanceTypes.ListParameters{
+			Limit:    &limit,
+			Offset:   &offset,
+			EngineId: data.EngineID.ValueStringPointer(),
+			Status:   data.Status.ValueStringPointer(),
+		}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkDbaasInstanceTypes.ListConfigs{}))
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to list database instance types", err.Error())
+			return
+		}
+
+		for _, instanceType := range result.Results {
+			data.InstanceTypes = append(data.InstanceTypes, dbaasInstanceTypeModel{
+				ID:                types.StringValue(instanceType.Id),
+				Name:              types.StringValue(instanceType.Name),
+				Label:             types.StringValue(instanceType.Label),
+				Vcpu:              types.StringValue(instanceType.Vcpu),
+				Ram:               types.StringValue(instanceType.Ram),
+				Size:              types.StringValue(instanceType.Size),
+				FamilySlug:        types.StringValue(instanceType.FamilySlug),
+				FamilyDescription: types.StringValue(instanceType.FamilyDescription),
+			})
+		}
+
+		if len(result.Results) < limit {
+			break
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,180 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mgcSdk "magalu.cloud/lib"
+	sdkDbaasBackups "magalu.cloud/lib/products/dbaas/instances/backups"
+	"magalu.cloud/terraform-provider-mgc/mgc/client"
+	"magalu.cloud/terraform-provider-mgc/mgc/tfutil"
+)
+
+var _ datasource.DataSource = &DataSourceDbaasInstancesBackups{}
+
+type DataSourceDbaasInstancesBackups struct {
+	sdkClient    *mgcSdk.Client
+	dbaasBackups sdkDbaasBackups.Service
+}
+
+type dbaasBackupModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Mode      types.String `tfsdk:"mode"`
+	Type      types.String `tfsdk:"type"`
+	Status    types.String `tfsdk:"status"`
+	Size      types.Int64  `tfsdk:"size"`
+	DbSize    types.Int64  `tfsdk:"db_size"`
+	CreatedAt types.String `tfsdk:"created_at"`
+}
+
+type dbaasBackupsModel struct {
+	InstanceID types.String       `tfsdk:"instance_id"`
+	Type       types.String       `tfsdk:"type"`
+	Status     types.String       `tfsdk:"status"`
+	Backups    []dbaasBackupModel `tfsdk:"backups"`
+}
+
+func NewDataSourceDbaasInstancesBackups() datasource.DataSource {
+	return &DataSourceDbaasInstancesBackups{}
+}
+
+func (r *DataSourceDbaasInstancesBackups) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dbaas_instances_backups"
+}
+
+func (r *DataSourceDbaasInstancesBackups) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	var err error
+	var errDetail error
+	r.sdkClient, err, errDetail = client.NewSDKClient(req)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			err.Error(),
+			errDetail.Error(),
+		)
+		return
+	}
+
+	r.dbaasBackups = sdkDbaasBackups.NewService(ctx, r.sdkClient)
+}
+
+func (r *DataSourceDbaasInstancesBackups) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Backups of a database instance.",
+		Attributes: map[string]schema.Attribute{
+			"instance_id": schema.StringAttribute{
+				Description: "The ID of the database instance.",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Filter backups by type. Options: ON_DEMAND / AUTOMATED.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ON_DEMAND", "AUTOMATED"),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Filter backups by status, such as CREATED.",
+				Optional:    true,
+			},
+			"backups": schema.ListNestedAttribute{
+				Description: "List of backups.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "ID of the backup.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the backup.",
+							Computed:    true,
+						},
+						"mode": schema.StringAttribute{
+							Description: "Mode of the backup, FULL or INCREMENTAL.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Type of the backup, ON_DEMAND or AUTOMATED.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Status of the backup.",
+							Computed:    true,
+						},
+						"size": schema.Int64Attribute{
+							Description: "Backup file size in kilobytes.",
+							Computed:    true,
+						},
+						"db_size": schema.Int64Attribute{
+							Description: "Database size in kilobytes.",
+							Computed:    true,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "Creation timestamp of the backup.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *DataSourceDbaasInstancesBackups) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data dbaasBackupsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Backups = []dbaasBackupModel{}
+	limit := dbaasListPageSize
+	for offset := 0; ; offset += limit {
+		result, err := r.dbaasBackups.ListContext(ctx, sdkDbaasBackups.ListParameters{
+			Limit:      &limit,
+			Offset:     &offset,
+			InstanceId: data.InstanceID.ValueString(),
+			Type:       data.Type.ValueStringPointer(),
+			Status:     data.Status.ValueStringPointer(),
+		}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkDbaasBackups.ListConfigs{}))
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to list database backups", err.Error())
+			return
+		}
+
+		for _, backup := range result.Results {
+			item := dbaasBackupModel{
+				ID:        types.StringValue(backup.Id),
+				Name:      types.StringPointerValue(backup.Name),
+				Mode:      types.StringValue(backup.Mode),
+				Type:      types.StringValue(backup.Type),
+				Status:    types.StringValue(backup.Status),
+				Size:      types.Int64Null(),
+				DbSize:    types.Int64Null(),
+				CreatedAt: types.StringValue(backup.CreatedAt),
+			}
+			if backup.Size != nil {
+				item.Size = types.Int64Value(int64(*backup.Size))
+			}
+			if backup.DbSize != nil {
+				item.DbSize = types.Int64Value(int64(*backup.DbSize))
+			}
+			data.Backups = append(data.Backups, item)
+		}
+
+		if len(result.Results) < limit {
+			break
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
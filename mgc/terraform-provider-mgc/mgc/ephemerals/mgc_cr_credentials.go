@@ -0,0 +1,97 @@
+package ephemerals
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mgcSdk "magalu.cloud/lib"
+	crCredentials "magalu.cloud/lib/products/container_registry/credentials"
+	"magalu.cloud/terraform-provider-mgc/mgc/client"
+	tfutil "magalu.cloud/terraform-provider-mgc/mgc/tfutil"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &EphemeralContainerRegistryCredentials{}
+	_ ephemeral.EphemeralResourceWithConfigure = &EphemeralContainerRegistryCredentials{}
+)
+
+func NewEphemeralContainerRegistryCredentials() ephemeral.EphemeralResource {
+	return &EphemeralContainerRegistryCredentials{}
+}
+
+type EphemeralContainerRegistryCredentials struct {
+	sdkClient   *mgcSdk.Client
+	credentials crCredentials.Service
+}
+
+type EphemeralContainerRegistryCredentialsModel struct {
+	Email    types.String `tfsdk:"email"`
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+}
+
+func (e *EphemeralContainerRegistryCredentials) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container_registry_credentials"
+}
+
+func (e *EphemeralContainerRegistryCredentials) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"email": schema.StringAttribute{
+				Computed:    true,
+				Description: "The email of the container registry user.",
+			},
+			"username": schema.StringAttribute{
+				Computed:    true,
+				Description: "The username used to login to the container registry.",
+			},
+			"password": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The password used to login to the container registry.",
+			},
+		},
+	}
+	resp.Schema.Description = "Get the credentials to login to the container registry without persisting them in the Terraform state. Requires Terraform 1.10 or later."
+}
+
+func (e *EphemeralContainerRegistryCredentials) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data EphemeralContainerRegistryCredentialsModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sdkOutput, err := e.credentials.ListContext(ctx, tfutil.GetConfigsFromTags(e.sdkClient.Sdk().Config().Get, crCredentials.ListConfigs{}))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get container registry credentials", err.Error())
+		return
+	}
+
+	data.Email = types.StringValue(sdkOutput.Email)
+	data.Username = types.StringValue(sdkOutput.Username)
+	data.Password = types.StringValue(sdkOutput.Password)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (e *EphemeralContainerRegistryCredentials) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	var err error
+	var errDetail error
+	e.sdkClient, err, errDetail = client.NewSDKClient(req)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			err.Error(),
+			errDetail.Error(),
+		)
+		return
+	}
+
+	e.credentials = crCredentials.NewService(ctx, e.sdkClient)
+}
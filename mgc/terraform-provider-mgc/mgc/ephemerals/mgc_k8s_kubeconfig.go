@@ -0,0 +1,131 @@
+package ephemerals
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mgcSdk "magalu.cloud/lib"
+	"magalu.cloud/lib/products/kubernetes/cluster"
+	"magalu.cloud/terraform-provider-mgc/mgc/client"
+	tfutil "magalu.cloud/terraform-provider-mgc/mgc/tfutil"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &EphemeralKubernetesClusterKubeConfig{}
+	_ ephemeral.EphemeralResourceWithConfigure = &EphemeralKubernetesClusterKubeConfig{}
+)
+
+func NewEphemeralKubernetesClusterKubeConfig() ephemeral.EphemeralResource {
+	return &EphemeralKubernetesClusterKubeConfig{}
+}
+
+type EphemeralKubernetesClusterKubeConfig struct {
+	sdkClient *mgcSdk.Client
+	cluster   cluster.Service
+}
+
+type EphemeralKubernetesClusterKubeConfigModel struct {
+	ClusterID            types.String `tfsdk:"cluster_id"`
+	RawConfig            types.String `tfsdk:"kubeconfig"`
+	Host                 types.String `tfsdk:"host"`
+	ClusterCACertificate types.String `tfsdk:"cluster_ca_certificate"`
+	ClientCertificate    types.String `tfsdk:"client_certificate"`
+	ClientKey            types.String `tfsdk:"client_key"`
+	Token                types.String `tfsdk:"token"`
+}
+
+func (e *EphemeralKubernetesClusterKubeConfig) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kubernetes_cluster_kubeconfig"
+}
+
+func (e *EphemeralKubernetesClusterKubeConfig) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The unique identifier of the Kubernetes cluster.",
+			},
+			"kubeconfig": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The full contents of the Kubernetes cluster's kubeconfig yaml file.",
+			},
+			"host": schema.StringAttribute{
+				Computed:    true,
+				Description: "The address of the Kubernetes API server.",
+			},
+			"cluster_ca_certificate": schema.StringAttribute{
+				Computed:    true,
+				Description: "PEM-encoded root certificate of the Kubernetes API server.",
+			},
+			"client_certificate": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "PEM-encoded client certificate used to authenticate to the cluster.",
+			},
+			"client_key": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "PEM-encoded client key used to authenticate to the cluster.",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Bearer token used to authenticate to the cluster, if any.",
+			},
+		},
+	}
+	resp.Schema.Description = "Get the kubeconfig of a Kubernetes cluster by cluster_id without persisting it in the Terraform state. Requires Terraform 1.10 or later."
+}
+
+func (e *EphemeralKubernetesClusterKubeConfig) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data EphemeralKubernetesClusterKubeConfigModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sdkOuput, err := e.cluster.Kubeconfig(cluster.KubeconfigParameters{
+		ClusterId: data.ClusterID.ValueString(),
+	}, tfutil.GetConfigsFromTags(e.sdkClient.Sdk().Config().Get, cluster.KubeconfigConfigs{}))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get kubeconfig", err.Error())
+		return
+	}
+
+	creds, err := tfutil.ParseKubeconfig(sdkOuput)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to parse kubeconfig", err.Error())
+		return
+	}
+
+	data.RawConfig = types.StringValue(sdkOuput)
+	data.Host = types.StringValue(creds.Host)
+	data.ClusterCACertificate = types.StringValue(creds.ClusterCACertificate)
+	data.ClientCertificate = types.StringValue(creds.ClientCertificate)
+	data.ClientKey = types.StringValue(creds.ClientKey)
+	data.Token = types.StringValue(creds.Token)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (e *EphemeralKubernetesClusterKubeConfig) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	var err error
+	var errDetail error
+	e.sdkClient, err, errDetail = client.NewSDKClient(req)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			err.Error(),
+			errDetail.Error(),
+		)
+		return
+	}
+
+	e.cluster = cluster.NewService(ctx, e.sdkClient)
+}
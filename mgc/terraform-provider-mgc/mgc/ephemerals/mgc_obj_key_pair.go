@@ -0,0 +1,140 @@
+package ephemerals
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mgcSdk "magalu.cloud/lib"
+	sdkApiKey "magalu.cloud/lib/products/object_storage/api_key"
+	"magalu.cloud/terraform-provider-mgc/mgc/client"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &EphemeralObjectStorageKeyPair{}
+	_ ephemeral.EphemeralResourceWithConfigure = &EphemeralObjectStorageKeyPair{}
+)
+
+func NewEphemeralObjectStorageKeyPair() ephemeral.EphemeralResource {
+	return &EphemeralObjectStorageKeyPair{}
+}
+
+type EphemeralObjectStorageKeyPair struct {
+	sdkClient *mgcSdk.Client
+	apiKey    sdkApiKey.Service
+}
+
+type EphemeralObjectStorageKeyPairModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	KeyID       types.String `tfsdk:"key_id"`
+	KeySecret   types.String `tfsdk:"key_secret"`
+	EndValidity types.String `tfsdk:"end_validity"`
+}
+
+func (e *EphemeralObjectStorageKeyPair) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object_storage_key_pair"
+}
+
+func (e *EphemeralObjectStorageKeyPair) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The UUID of the Object Storage API key. Either id or name must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("name")),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the Object Storage API key. Either id or name must be set.",
+			},
+			"description": schema.StringAttribute{
+				Computed:    true,
+				Description: "The description of the Object Storage API key.",
+			},
+			"key_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The key pair ID, used as the S3 access key.",
+			},
+			"key_secret": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The key pair secret, used as the S3 secret key.",
+			},
+			"end_validity": schema.StringAttribute{
+				Computed:    true,
+				Description: "The date the key pair expires, if any.",
+			},
+		},
+	}
+	resp.Schema.Description = "Get an Object Storage key pair without persisting its secret in the Terraform state. Requires Terraform 1.10 or later."
+}
+
+func (e *EphemeralObjectStorageKeyPair) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data EphemeralObjectStorageKeyPairModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, err := e.apiKey.ListContext(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list Object Storage key pairs", err.Error())
+		return
+	}
+
+	var key *sdkApiKey.ListResultItem
+	for i, k := range keys {
+		if (!data.ID.IsNull() && k.Uuid == data.ID.ValueString()) ||
+			(!data.Name.IsNull() && k.Name == data.Name.ValueString()) {
+			if key != nil {
+				resp.Diagnostics.AddError("Ambiguous Object Storage key pair", fmt.Sprintf("More than one valid key pair named %q was found, use id instead", data.Name.ValueString()))
+				return
+			}
+			key = &keys[i]
+		}
+	}
+
+	if key == nil {
+		resp.Diagnostics.AddError("Object Storage key pair not found", "No valid (not revoked nor expired) key pair matches the given id or name")
+		return
+	}
+
+	data.ID = types.StringValue(key.Uuid)
+	data.Name = types.StringValue(key.Name)
+	data.Description = types.StringValue(key.Description)
+	data.KeyID = types.StringValue(key.KeyPairId)
+	data.KeySecret = types.StringValue(key.KeyPairSecret)
+	data.EndValidity = types.StringPointerValue(key.EndValidity)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (e *EphemeralObjectStorageKeyPair) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	var err error
+	var errDetail error
+	e.sdkClient, err, errDetail = client.NewSDKClient(req)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			err.Error(),
+			errDetail.Error(),
+		)
+		return
+	}
+
+	e.apiKey = sdkApiKey.NewService(ctx, e.sdkClient)
+}
@@ -7,13 +7,17 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"magalu.cloud/terraform-provider-mgc/mgc/client"
 	datasources "magalu.cloud/terraform-provider-mgc/mgc/datasources"
+	ephemerals "magalu.cloud/terraform-provider-mgc/mgc/ephemerals"
 	resources "magalu.cloud/terraform-provider-mgc/mgc/resources"
 	"magalu.cloud/terraform-provider-mgc/mgc/tfutil"
 
@@ -22,6 +26,11 @@ import (
 
 const providerTypeName = "mgc"
 
+var (
+	_ provider.Provider                       = &mgcProvider{}
+	_ provider.ProviderWithEphemeralResources = &mgcProvider{}
+)
+
 type mgcProvider struct {
 	version string
 	commit  string
@@ -56,6 +65,15 @@ func (p *mgcProvider) Schema(ctx context.Context, req provider.SchemaRequest, re
 				Description: "The Magalu API Key for authentication. Can be set with environment variable MGC_API_KEY.",
 				Optional:    true,
 			},
+			"workspace": schema.StringAttribute{
+				Description: "The name of an existing MGC CLI workspace to load credentials, tenant, region and environment from, so a `mgc auth login` can be reused. Expired access tokens are refreshed and stored back in the workspace. Can be set with environment variable MGC_WORKSPACE.",
+				Optional:    true,
+			},
+			"default_labels": schema.SetAttribute{
+				Description: "Labels applied to every resource that supports labels, merged with the labels set on the resource itself. Kubernetes node pools get them as tags. Virtual machine instances and node pools can't change their labels, so they are replaced when these change.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 			"object_storage": schema.SingleNestedAttribute{
 				Description: "Configuration settings for Object Storage",
 				Optional:    true,
@@ -87,7 +105,14 @@ func (p *mgcProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		tflog.Error(ctx, "fail to get configs from provider")
 	}
 
-	if data.ApiKey.ValueString() == "" {
+	if data.Workspace.ValueString() == "" {
+		data.Workspace = types.StringValue(os.Getenv("MGC_WORKSPACE"))
+	}
+	workspace := data.Workspace.ValueString()
+
+	// When using a workspace, its own configuration takes place of the defaults
+	// and of the environment variables for the credentials
+	if data.ApiKey.ValueString() == "" && workspace == "" {
 		if apiKeyFromOS := os.Getenv("MGC_API_KEY"); apiKeyFromOS != "" {
 			data.ApiKey = types.StringValue(apiKeyFromOS)
 		} else {
@@ -98,7 +123,7 @@ func (p *mgcProvider) Configure(ctx context.Context, req provider.ConfigureReque
 	if data.Env.ValueString() == "" {
 		if envFromOS := os.Getenv("MGC_ENV"); envFromOS != "" {
 			data.Env = types.StringValue(envFromOS)
-		} else {
+		} else if workspace == "" {
 			data.Env = types.StringValue("prod")
 		}
 	}
@@ -106,12 +131,12 @@ func (p *mgcProvider) Configure(ctx context.Context, req provider.ConfigureReque
 	if data.Region.ValueString() == "" {
 		if regionFromOS := os.Getenv("MGC_REGION"); regionFromOS != "" {
 			data.Region = types.StringValue(regionFromOS)
-		} else {
+		} else if workspace == "" {
 			data.Region = types.StringValue("br-se1")
 		}
 	}
 
-	if data.ObjectStorage == nil || (os.Getenv("MGC_OBJ_KEY_ID") != "" && os.Getenv("MGC_OBJ_KEY_SECRET") != "") {
+	if (data.ObjectStorage == nil && workspace == "") || (os.Getenv("MGC_OBJ_KEY_ID") != "" && os.Getenv("MGC_OBJ_KEY_SECRET") != "") {
 		data.ObjectStorage = &tfutil.ObjectStorageConfig{
 			ObjectKeyPair: &tfutil.KeyPair{
 				KeyID:     types.StringValue(os.Getenv("MGC_OBJ_KEY_ID")),
@@ -120,8 +145,18 @@ func (p *mgcProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		}
 	}
 
+	sdkClient, err := client.NewProviderSDKClient(data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("workspace"), "Invalid workspace",
+			fmt.Sprintf("Unable to load the MGC CLI workspace %q: %s. Check the available workspaces with 'mgc workspace list'.", workspace, err))
+		return
+	}
+	data.SdkClient = sdkClient
+	data.Catalog = tfutil.NewCatalog()
+
 	resp.DataSourceData = data
 	resp.ResourceData = data
+	resp.EphemeralResourceData = data
 }
 
 func (p *mgcProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -147,6 +182,9 @@ func (p *mgcProvider) Resources(ctx context.Context) []func() resource.Resource
 		resources.NewNetworkSecurityGroupsAttachResource,
 		resources.NewNetworkPublicIPAttachResource,
 		resources.NewNetworkSubnetPoolsBookCIDRResource,
+		resources.NewDbaasInstancesResource,
+		resources.NewDbaasReplicasResource,
+		resources.NewDbaasInstancesBackupsResource,
 	)
 }
 
@@ -168,6 +206,17 @@ func (p *mgcProvider) DataSources(ctx context.Context) []func() datasource.DataS
 		datasources.NewDataSourceNetworkVpcsSubnet,
 		datasources.NewDataSourceNetworkSubnetpool,
 		datasources.NewDataSourceNetworkPublicIP,
+		datasources.NewDataSourceDbaasEngines,
+		datasources.NewDataSourceDbaasInstanceTypes,
+		datasources.NewDataSourceDbaasInstancesBackups,
+	}
+}
+
+func (p *mgcProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		ephemerals.NewEphemeralKubernetesClusterKubeConfig,
+		ephemerals.NewEphemeralContainerRegistryCredentials,
+		ephemerals.NewEphemeralObjectStorageKeyPair,
 	}
 }
 
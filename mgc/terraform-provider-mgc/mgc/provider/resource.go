@@ -21,7 +21,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &MgcResource{}
 var _ resource.ResourceWithImportState = &MgcResource{}
-var autoGeneratedTFModules = []string{"mgc_dbaas", "mgc_container"}
+var autoGeneratedTFModules = []string{"mgc_container"}
 
 // MgcResource defines the resource implementation.
 type MgcResource struct {
@@ -0,0 +1,263 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	mgcHttp "magalu.cloud/core/http"
+	sdkDbaasInstances "magalu.cloud/lib/products/dbaas/instances"
+	sdkDbaasReplicas "magalu.cloud/lib/products/dbaas/replicas"
+)
+
+const (
+	DbaasPoolingTimeout = 60 * time.Minute
+
+	dbaasStatusActive  = "ACTIVE"
+	dbaasStatusStopped = "STOPPED"
+	dbaasStatusError   = "ERROR"
+	dbaasStatusDeleted = "DELETED"
+
+	// Version 0 is the state of the resources generated from the OpenAPI spec
+	dbaasSchemaVersion = 1
+
+	// Private state key of the imported instances, they don't know their parameters
+	dbaasImportedKey = "imported"
+)
+
+// Interval between the checks while waiting for a status, tests shorten it
+var dbaasPoolingInterval = 10 * time.Second
+
+type dbaasAddressModel struct {
+	Access  types.String `tfsdk:"access"`
+	Address types.String `tfsdk:"address"`
+	Type    types.String `tfsdk:"type"`
+}
+
+func dbaasAddressesSchema() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Description: "The addresses of the database.",
+		Computed:    true,
+		PlanModifiers: []planmodifier.List{
+			listplanmodifier.UseStateForUnknown(),
+		},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"access": schema.StringAttribute{
+					Description: "Whether the address is private or public.",
+					Computed:    true,
+				},
+				"address": schema.StringAttribute{
+					Description: "The IP address.",
+					Computed:    true,
+				},
+				"type": schema.StringAttribute{
+					Description: "The IP version of the address.",
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+func dbaasAddressesToState[T sdkDbaasInstances.GetResultAddressesItem | sdkDbaasReplicas.GetResultAddressesItem](items []T) []dbaasAddressModel {
+	addresses := make([]dbaasAddressModel, 0, len(items))
+	for _, item := range items {
+		address := sdkDbaasInstances.GetResultAddressesItem(item)
+		addresses = append(addresses, dbaasAddressModel{
+			Access:  types.StringValue(address.Access),
+			Address: types.StringPointerValue(address.Address),
+			Type:    types.StringPointerValue(address.Type),
+		})
+	}
+	return addresses
+}
+
+// dbaasIsNotFound tells if the resource was deleted outside of Terraform
+func dbaasIsNotFound(err error) bool {
+	httpError := new(mgcHttp.HttpError)
+	return errors.As(err, &httpError) && httpError.Code == http.StatusNotFound
+}
+
+// dbaasStatusToState keeps the current status during transient ones (resizing, backing up...),
+// they are not a drift
+func dbaasStatusToState(current types.String, status string) types.String {
+	if status == dbaasStatusActive || status == dbaasStatusStopped || current.ValueString() == "" {
+		return types.StringValue(status)
+	}
+	return current
+}
+
+// dbaasPoll calls check every dbaasPoolingInterval until it's done or fails, giving up when
+// ctx is done or after DbaasPoolingTimeout
+func dbaasPoll(ctx context.Context, check func() (bool, error)) error {
+	ctx, cancel := context.WithTimeout(ctx, DbaasPoolingTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(dbaasPoolingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		done, err := check()
+		if err != nil || done {
+			return err
+		}
+	}
+}
+
+// dbaasStatusManager starts, stops and waits for database instances and replicas, which
+// share the same lifecycle
+type dbaasStatusManager[T any] struct {
+	// Used in messages, ex: "database instance"
+	kind   string
+	get    func(ctx context.Context, id string) (*T, error)
+	status func(result *T) string
+	start  func(ctx context.Context, id string) error
+	stop   func(ctx context.Context, id string) error
+}
+
+func (m *dbaasStatusManager[T]) waitStatus(ctx context.Context, id string, status string) (*T, error) {
+	var result *T
+	err := dbaasPoll(ctx, func() (bool, error) {
+		var err error
+		if result, err = m.get(ctx, id); err != nil {
+			return false, err
+		}
+		current := m.status(result)
+		if current == dbaasStatusError {
+			return false, fmt.Errorf("%s %s is in %s status", m.kind, id, current)
+		}
+		tflog.Debug(ctx, fmt.Sprintf("%s current status: %s", m.kind, current))
+		return current == status, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("waiting for %s status %s: %w", m.kind, status, err)
+	}
+	return result, nil
+}
+
+func (m *dbaasStatusManager[T]) waitDeleted(ctx context.Context, id string) error {
+	err := dbaasPoll(ctx, func() (bool, error) {
+		result, err := m.get(ctx, id)
+		if err != nil {
+			// Deleted resources may not be found anymore
+			return true, nil
+		}
+		tflog.Debug(ctx, fmt.Sprintf("%s current status: %s", m.kind, m.status(result)))
+		return m.status(result) == dbaasStatusDeleted, nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for %s deletion: %w", m.kind, err)
+	}
+	return nil
+}
+
+// setStatus starts the resource if it's stopped or stops it if it's not, according to status,
+// ACTIVE or STOPPED, and waits for it
+func (m *dbaasStatusManager[T]) setStatus(ctx context.Context, id string, current *T, status string) (*T, error) {
+	var change func(ctx context.Context, id string) error
+	switch {
+	case status == dbaasStatusActive && m.status(current) == dbaasStatusStopped:
+		change = m.start
+	case status == dbaasStatusStopped && m.status(current) != dbaasStatusStopped:
+		change = m.stop
+	default:
+		return current, nil
+	}
+
+	if err := change(ctx, id); err != nil {
+		return nil, err
+	}
+	return m.waitStatus(ctx, id, status)
+}
+
+// dbaasStateUpgraderV0 upgrades the state of the resources generated from the OpenAPI spec.
+// Their schema is not available anymore, so the raw state is decoded into prior.
+func dbaasStateUpgraderV0[P any, M any](upgrade func(prior P) M) resource.StateUpgrader {
+	return resource.StateUpgrader{
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			if req.RawState == nil {
+				resp.Diagnostics.AddError("Unable to upgrade state", "Missing prior state")
+				return
+			}
+
+			var prior P
+			if err := json.Unmarshal(req.RawState.JSON, &prior); err != nil {
+				resp.Diagnostics.AddError("Unable to upgrade state", err.Error())
+				return
+			}
+
+			state := upgrade(prior)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		},
+	}
+}
+
+type dbaasAddressStateV0 struct {
+	Access  *string `json:"access"`
+	Address *string `json:"address"`
+	Type    *string `json:"type"`
+}
+
+type dbaasVolumeStateV0 struct {
+	Size *int64  `json:"size"`
+	Type *string `json:"type"`
+}
+
+func dbaasAddressesFromStateV0(prior []dbaasAddressStateV0) []dbaasAddressModel {
+	addresses := make([]dbaasAddressModel, 0, len(prior))
+	for _, address := range prior {
+		addresses = append(addresses, dbaasAddressModel{
+			Access:  types.StringPointerValue(address.Access),
+			Address: types.StringPointerValue(address.Address),
+			Type:    types.StringPointerValue(address.Type),
+		})
+	}
+	return addresses
+}
+
+// dbaasVolumeFromStateV0 prefers the volume reported by the API, "current_volume", to the
+// requested one
+func dbaasVolumeFromStateV0(volume, current *dbaasVolumeStateV0) (types.Int64, types.String) {
+	if current == nil || current.Size == nil {
+		current = volume
+	}
+	if current == nil {
+		return types.Int64Null(), types.StringNull()
+	}
+	return types.Int64PointerValue(current.Size), types.StringPointerValue(current.Type)
+}
+
+// The generated resources accepted the deprecated IDs as well
+func dbaasFirstNonNull(values ...*string) types.String {
+	for _, value := range values {
+		if value != nil {
+			return types.StringValue(*value)
+		}
+	}
+	return types.StringNull()
+}
+
+func dbaasStatusFromStateV0(statuses ...*string) types.String {
+	for _, status := range statuses {
+		if status != nil && (*status == dbaasStatusActive || *status == dbaasStatusStopped) {
+			return types.StringValue(*status)
+		}
+	}
+	return types.StringNull()
+}
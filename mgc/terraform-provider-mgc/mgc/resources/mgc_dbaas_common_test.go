@@ -0,0 +1,129 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	mgcHttp "magalu.cloud/core/http"
+)
+
+func init() {
+	dbaasPoolingInterval = time.Millisecond
+}
+
+func TestDbaasPoll(t *testing.T) {
+	ctx := context.Background()
+
+	checks := 0
+	err := dbaasPoll(ctx, func() (bool, error) {
+		checks++
+		return checks == 3, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, checks)
+
+	errCheck := errors.New("check failed")
+	err = dbaasPoll(ctx, func() (bool, error) {
+		return false, errCheck
+	})
+	assert.ErrorIs(t, err, errCheck)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = dbaasPoll(cancelled, func() (bool, error) {
+		t.Error("expected no check after the context is done")
+		return false, nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+type dbaasTestResource struct {
+	status string
+}
+
+func newDbaasTestStatusManager(statuses ...string) (*dbaasStatusManager[dbaasTestResource], *[]string) {
+	calls := []string{}
+	return &dbaasStatusManager[dbaasTestResource]{
+		kind: "test resource",
+		get: func(context.Context, string) (*dbaasTestResource, error) {
+			status := statuses[0]
+			if len(statuses) > 1 {
+				statuses = statuses[1:]
+			}
+			return &dbaasTestResource{status: status}, nil
+		},
+		status: func(result *dbaasTestResource) string { return result.status },
+		start: func(context.Context, string) error {
+			calls = append(calls, "start")
+			return nil
+		},
+		stop: func(context.Context, string) error {
+			calls = append(calls, "stop")
+			return nil
+		},
+	}, &calls
+}
+
+func TestDbaasStatusManagerSetStatus(t *testing.T) {
+	testCases := []struct {
+		name     string
+		current  string
+		status   string
+		expected []string
+	}{
+		{name: "start stopped", current: dbaasStatusStopped, status: dbaasStatusActive, expected: []string{"start"}},
+		{name: "stop active", current: dbaasStatusActive, status: dbaasStatusStopped, expected: []string{"stop"}},
+		{name: "stop transient", current: "BACKING_UP", status: dbaasStatusStopped, expected: []string{"stop"}},
+		{name: "already active", current: dbaasStatusActive, status: dbaasStatusActive, expected: []string{}},
+		{name: "already stopped", current: dbaasStatusStopped, status: dbaasStatusStopped, expected: []string{}},
+		{name: "don't start transient", current: "RESIZING", status: dbaasStatusActive, expected: []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, calls := newDbaasTestStatusManager("STARTING", tc.status)
+			result, err := m.setStatus(context.Background(), "id", &dbaasTestResource{status: tc.current}, tc.status)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, *calls)
+			if len(tc.expected) > 0 {
+				assert.Equal(t, tc.status, result.status)
+			}
+		})
+	}
+}
+
+func TestDbaasStatusManagerWaitStatus(t *testing.T) {
+	ctx := context.Background()
+
+	m, _ := newDbaasTestStatusManager("CREATING", "CREATING", dbaasStatusActive)
+	result, err := m.waitStatus(ctx, "id", dbaasStatusActive)
+	assert.NoError(t, err)
+	assert.Equal(t, dbaasStatusActive, result.status)
+
+	m, _ = newDbaasTestStatusManager("CREATING", dbaasStatusError)
+	_, err = m.waitStatus(ctx, "id", dbaasStatusActive)
+	assert.ErrorContains(t, err, "test resource id is in ERROR status")
+}
+
+func TestDbaasStatusManagerWaitDeleted(t *testing.T) {
+	m, _ := newDbaasTestStatusManager("DELETING", dbaasStatusDeleted)
+	assert.NoError(t, m.waitDeleted(context.Background(), "id"))
+
+	m.get = func(context.Context, string) (*dbaasTestResource, error) {
+		return nil, errors.New("not found")
+	}
+	assert.NoError(t, m.waitDeleted(context.Background(), "id"))
+}
+
+func TestDbaasIsNotFound(t *testing.T) {
+	notFound := &mgcHttp.HttpError{Code: 404, Status: "404 Not Found"}
+	assert.True(t, dbaasIsNotFound(notFound))
+	assert.True(t, dbaasIsNotFound(fmt.Errorf("get: %w", notFound)))
+	assert.False(t, dbaasIsNotFound(&mgcHttp.HttpError{Code: 500, Status: "500 Internal Server Error"}))
+	assert.False(t, dbaasIsNotFound(errors.New("not found")))
+	assert.False(t, dbaasIsNotFound(nil))
+}
@@ -0,0 +1,646 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	mgcSdk "magalu.cloud/lib"
+	sdkDbaasInstanceTypes "magalu.cloud/lib/products/dbaas/instance_types"
+	sdkDbaasInstances "magalu.cloud/lib/products/dbaas/instances"
+	"magalu.cloud/terraform-provider-mgc/mgc/client"
+	"magalu.cloud/terraform-provider-mgc/mgc/tfutil"
+)
+
+var (
+	_ resource.Resource                 = &dbaasInstances{}
+	_ resource.ResourceWithConfigure    = &dbaasInstances{}
+	_ resource.ResourceWithImportState  = &dbaasInstances{}
+	_ resource.ResourceWithModifyPlan   = &dbaasInstances{}
+	_ resource.ResourceWithUpgradeState = &dbaasInstances{}
+)
+
+type dbaasInstanceModel struct {
+	ID                  types.String            `tfsdk:"id"`
+	Name                types.String            `tfsdk:"name"`
+	User                types.String            `tfsdk:"user"`
+	Password            types.String            `tfsdk:"password"`
+	EngineID            types.String            `tfsdk:"engine_id"`
+	InstanceTypeID      types.String            `tfsdk:"instance_type_id"`
+	VolumeSize          types.Int64             `tfsdk:"volume_size"`
+	VolumeType          types.String            `tfsdk:"volume_type"`
+	BackupRetentionDays types.Int64             `tfsdk:"backup_retention_days"`
+	BackupStartAt       types.String            `tfsdk:"backup_start_at"`
+	Parameters          map[string]types.String `tfsdk:"parameters"`
+	Status              types.String            `tfsdk:"status"`
+	Addresses           []dbaasAddressModel     `tfsdk:"addresses"`
+	CreatedAt           types.String            `tfsdk:"created_at"`
+}
+
+func NewDbaasInstancesResource() resource.Resource {
+	return &dbaasInstances{}
+}
+
+type dbaasInstances struct {
+	sdkClient      *mgcSdk.Client
+	dbaasInstances sdkDbaasInstances.Service
+	lifecycle      *dbaasStatusManager[sdkDbaasInstances.GetResult]
+	catalog        *tfutil.Catalog
+}
+
+func (r *dbaasInstances) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dbaas_instances"
+}
+
+func (r *dbaasInstances) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	var err error
+	var errDetail error
+	r.sdkClient, err, errDetail = client.NewSDKClient(req)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			err.Error(),
+			errDetail.Error(),
+		)
+		return
+	}
+
+	r.dbaasInstances = sdkDbaasInstances.NewService(ctx, r.sdkClient)
+	r.lifecycle = &dbaasStatusManager[sdkDbaasInstances.GetResult]{
+		kind:   "database instance",
+		get:    r.get,
+		status: func(result *sdkDbaasInstances.GetResult) string { return result.Status },
+		start:  r.start,
+		stop:   r.stop,
+	}
+	r.catalog = tfutil.CatalogFromProviderData(req.ProviderData)
+}
+
+func (r *dbaasInstances) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	description := "Database instances management. Instance type and volume are resized in place and the instance can be stopped and started through its status."
+	resp.Schema = schema.Schema{
+		Description:         description,
+		MarkdownDescription: description,
+		Version:             dbaasSchemaVersion,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the database instance.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the database instance.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user": schema.StringAttribute{
+				Description: "The username of the database administrator.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"password": schema.StringAttribute{
+				Description: "The password of the database administrator. It can't be changed after creation.",
+				Required:    true,
+				Sensitive:   true,
+			},
+			"engine_id": schema.StringAttribute{
+				Description: "The ID of the database engine. See the `mgc_dbaas_engines` data source. The default engine is used when omitted.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"instance_type_id": schema.StringAttribute{
+				Description: "The ID of the instance type. See the `mgc_dbaas_instance_types` data source. Changing it resizes the instance in place.",
+				Required:    true,
+			},
+			"volume_size": schema.Int64Attribute{
+				Description: "The size of the volume in GiB. It can only be increased, which is done in place.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"volume_type": schema.StringAttribute{
+				Description: "The type of the volume.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"backup_retention_days": schema.Int64Attribute{
+				Description: "The number of days that a particular backup is kept until its deletion.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"backup_start_at": schema.StringAttribute{
+				Description: "Start time (UTC timezone, HH:MM:SS) which is allowed to start the automated backup process.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"parameters": schema.MapAttribute{
+				Description: "Database parameters applied on creation, by name. Numbers and booleans are sent with their own types. They can't be changed after creation.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"status": schema.StringAttribute{
+				Description: "The status of the database instance. Set it to STOPPED to stop the instance or to ACTIVE to start it again.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(dbaasStatusActive, dbaasStatusStopped),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"addresses": dbaasAddressesSchema(),
+			"created_at": schema.StringAttribute{
+				Description: "The timestamp when the database instance was created.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *dbaasInstances) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	imported, diags := req.Private.GetKey(ctx, dbaasImportedKey)
+	resp.Diagnostics.Append(diags...)
+	r.modifyPlan(ctx, req, resp, imported != nil)
+}
+
+// modifyPlan validates the plan, imported tells if the instance was imported instead of created
+func (r *dbaasInstances) modifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, imported bool) {
+	if r.catalog != nil {
+		instanceTypePath := path.Root("instance_type_id")
+		instanceType, diags := tfutil.PlannedStringChange(ctx, req, instanceTypePath)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(r.catalog.Validate(ctx, instanceType, "DBaaS instance type", listDbaasInstanceTypeIDs(r.sdkClient), instanceTypePath)...)
+	}
+
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state dbaasInstanceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.VolumeSize.IsUnknown() && plan.VolumeSize.ValueInt64() < state.VolumeSize.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(path.Root("volume_size"), "Volume size cannot be decreased",
+			fmt.Sprintf("The volume size can only be increased, current size is %d GiB.", state.VolumeSize.ValueInt64()))
+	}
+
+	// The API can't change them and replacing the instance would lose its data. Imported
+	// instances don't know them, so they are taken from the configuration. Instances created
+	// without parameters have none in the state as well, adding them would never be applied.
+	if !state.Password.IsNull() && !plan.Password.IsUnknown() && !plan.Password.Equal(state.Password) {
+		resp.Diagnostics.AddAttributeError(path.Root("password"), "Password cannot be changed",
+			"The password of an existing database instance can't be changed. Restore the previous password or replace the instance with `terraform apply -replace`.")
+	}
+	if (state.Parameters != nil || !imported) && !maps.EqualFunc(plan.Parameters, state.Parameters, dbaasParameterPlanEqual) {
+		resp.Diagnostics.AddAttributeError(path.Root("parameters"), "Parameters cannot be changed",
+			"The parameters of an existing database instance can't be changed. Restore the previous parameters or replace the instance with `terraform apply -replace`.")
+	}
+}
+
+func dbaasParameterPlanEqual(planned, current types.String) bool {
+	return planned.IsUnknown() || planned.Equal(current)
+}
+
+func listDbaasInstanceTypeIDs(sdkClient *mgcSdk.Client) tfutil.CatalogFetch {
+	return func(ctx context.Context) ([]string, error) {
+		service := sdkDbaasInstanceTypes.NewService(ctx, sdkClient)
+		ids := []string{}
+		limit := 25
+		for offset := 0; ; offset += limit {
+			result, err := service.ListContext(ctx, sdkDbaasInstanceTypes.ListParameters{
+				Limit:  &limit,
+				Offset: &offset,
+			}, tfutil.GetConfigsFromTags(sdkClient.Sdk().Config().Get, sdkDbaasInstanceTypes.ListConfigs{}))
+			if err != nil {
+				return nil, err
+			}
+			for _, instanceType := range result.Results {
+				ids = append(ids, instanceType.Id)
+			}
+			if len(result.Results) < limit {
+				return ids, nil
+			}
+		}
+	}
+}
+
+func dbaasParameterValue(value string) *sdkDbaasInstances.CreateParametersParametersItemValue {
+	var v sdkDbaasInstances.CreateParametersParametersItemValue
+	if i, err := strconv.Atoi(value); err == nil {
+		v = sdkDbaasInstances.NewCreateParametersParametersItemValueInt(i)
+	} else if f, err := strconv.ParseFloat(value, 64); err == nil {
+		v = sdkDbaasInstances.NewCreateParametersParametersItemValueFloat64(f)
+	} else if b, err := strconv.ParseBool(value); err == nil && (value == "true" || value == "false") {
+		v = sdkDbaasInstances.NewCreateParametersParametersItemValueBool(b)
+	} else {
+		v = sdkDbaasInstances.NewCreateParametersParametersItemValueString(value)
+	}
+	return &v
+}
+
+func dbaasParameterString(value *sdkDbaasInstances.GetResultParametersItemValue) string {
+	switch {
+	case value == nil:
+		return ""
+	case value.Int != nil:
+		return strconv.Itoa(*value.Int)
+	case value.Float64 != nil:
+		return strconv.FormatFloat(*value.Float64, 'f', -1, 64)
+	case value.Bool != nil:
+		return strconv.FormatBool(*value.Bool)
+	case value.String != nil:
+		return *value.String
+	}
+	return ""
+}
+
+// dbaasNormalizeParameter formats numbers the same way, so "1.50" and 1.5 are equal
+func dbaasNormalizeParameter(value string) string {
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return value
+}
+
+func (r *dbaasInstances) toState(result sdkDbaasInstances.GetResult, state *dbaasInstanceModel) {
+	state.ID = types.StringValue(result.Id)
+	state.Name = types.StringValue(result.Name)
+	state.EngineID = types.StringValue(result.EngineId)
+	state.InstanceTypeID = types.StringValue(result.InstanceTypeId)
+	state.VolumeSize = types.Int64Value(int64(result.Volume.Size))
+	state.VolumeType = types.StringValue(result.Volume.Type)
+	state.BackupRetentionDays = types.Int64Value(int64(result.BackupRetentionDays))
+	state.BackupStartAt = types.StringValue(result.BackupStartAt)
+	state.CreatedAt = types.StringValue(result.CreatedAt)
+
+	state.Status = dbaasStatusToState(state.Status, result.Status)
+	state.Addresses = dbaasAddressesToState(result.Addresses)
+
+	// The API reports every parameter, including defaults. Only track the configured ones,
+	// keeping how they were written if the values are the same.
+	if state.Parameters != nil {
+		parameters := make(map[string]types.String, len(state.Parameters))
+		for _, p := range result.Parameters {
+			configured, ok := state.Parameters[p.Name]
+			if !ok {
+				continue
+			}
+			value := dbaasParameterString(p.Value)
+			if dbaasNormalizeParameter(configured.ValueString()) == dbaasNormalizeParameter(value) {
+				value = configured.ValueString()
+			}
+			parameters[p.Name] = types.StringValue(value)
+		}
+		state.Parameters = parameters
+	}
+}
+
+func (r *dbaasInstances) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data dbaasInstanceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := sdkDbaasInstances.CreateParameters{
+		Name:           data.Name.ValueString(),
+		User:           data.User.ValueString(),
+		Password:       data.Password.ValueString(),
+		EngineId:       data.EngineID.ValueStringPointer(),
+		InstanceTypeId: data.InstanceTypeID.ValueStringPointer(),
+		BackupStartAt:  data.BackupStartAt.ValueStringPointer(),
+		Volume: sdkDbaasInstances.CreateParametersVolume{
+			Size: int(data.VolumeSize.ValueInt64()),
+			Type: data.VolumeType.ValueStringPointer(),
+		},
+	}
+	if !data.BackupRetentionDays.IsNull() && !data.BackupRetentionDays.IsUnknown() {
+		days := int(data.BackupRetentionDays.ValueInt64())
+		params.BackupRetentionDays = &days
+	}
+	if len(data.Parameters) > 0 {
+		parameters := sdkDbaasInstances.CreateParametersParameters{}
+		for name, value := range data.Parameters {
+			parameters = append(parameters, sdkDbaasInstances.CreateParametersParametersItem{
+				Name:  name,
+				Value: dbaasParameterValue(value.ValueString()),
+			})
+		}
+		params.Parameters = &parameters
+	}
+
+	created, err := r.dbaasInstances.CreateContext(ctx, params,
+		tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkDbaasInstances.CreateConfigs{}))
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating database instance", err.Error())
+		return
+	}
+
+	// Save the ID right away, so a failure while waiting doesn't leak the instance
+	data.ID = types.StringValue(created.Id)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), data.ID)...)
+
+	result, err := r.lifecycle.waitStatus(ctx, created.Id, dbaasStatusActive)
+	if err != nil {
+		resp.Diagnostics.AddError("Error waiting for the database instance to be active", err.Error())
+		return
+	}
+
+	if data.Status.ValueString() == dbaasStatusStopped {
+		if result, err = r.lifecycle.setStatus(ctx, created.Id, result, dbaasStatusStopped); err != nil {
+			resp.Diagnostics.AddError("Error stopping database instance", err.Error())
+			return
+		}
+	}
+
+	data.Status = types.StringNull()
+	r.toState(*result, &data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *dbaasInstances) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data dbaasInstanceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.get(ctx, data.ID.ValueString())
+	if dbaasIsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading database instance", err.Error())
+		return
+	}
+	if result.Status == dbaasStatusDeleted {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	r.toState(*result, &data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *dbaasInstances) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state dbaasInstanceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+	result, err := r.get(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading database instance", err.Error())
+		return
+	}
+
+	resizeParams := sdkDbaasInstances.ResizeParameters{InstanceId: id}
+	needsResize := false
+	if plan.InstanceTypeID.ValueString() != state.InstanceTypeID.ValueString() {
+		resizeParams.InstanceTypeId = plan.InstanceTypeID.ValueStringPointer()
+		needsResize = true
+	}
+	volumeTypeChanged := !plan.VolumeType.IsUnknown() && plan.VolumeType.ValueString() != state.VolumeType.ValueString()
+	if plan.VolumeSize.ValueInt64() != state.VolumeSize.ValueInt64() || volumeTypeChanged {
+		resizeParams.Volume = &sdkDbaasInstances.ResizeParametersVolume{
+			Size: int(plan.VolumeSize.ValueInt64()),
+		}
+		if volumeTypeChanged {
+			resizeParams.Volume.Type = plan.VolumeType.ValueStringPointer()
+		}
+		needsResize = true
+	}
+
+	desiredStatus := plan.Status.ValueString()
+	if desiredStatus == "" {
+		desiredStatus = result.Status
+	}
+
+	// Resizing requires a running instance, it's stopped again afterwards if desired
+	if needsResize || desiredStatus == dbaasStatusActive {
+		if result, err = r.lifecycle.setStatus(ctx, id, result, dbaasStatusActive); err != nil {
+			resp.Diagnostics.AddError("Error starting database instance", err.Error())
+			return
+		}
+	}
+
+	if needsResize {
+		_, err = r.dbaasInstances.ResizeContext(ctx, resizeParams,
+			tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkDbaasInstances.ResizeConfigs{}))
+		if err != nil {
+			resp.Diagnostics.AddError("Error resizing database instance", err.Error())
+			return
+		}
+		tflog.Debug(ctx, "waiting database instance resize")
+		if result, err = r.lifecycle.waitStatus(ctx, id, dbaasStatusActive); err != nil {
+			resp.Diagnostics.AddError("Error waiting for the database instance resize", err.Error())
+			return
+		}
+	}
+
+	updateParams := sdkDbaasInstances.UpdateParameters{InstanceId: id}
+	needsUpdate := false
+	if !plan.BackupRetentionDays.IsUnknown() && plan.BackupRetentionDays.ValueInt64() != state.BackupRetentionDays.ValueInt64() {
+		days := int(plan.BackupRetentionDays.ValueInt64())
+		updateParams.BackupRetentionDays = &days
+		needsUpdate = true
+	}
+	if !plan.BackupStartAt.IsUnknown() && plan.BackupStartAt.ValueString() != state.BackupStartAt.ValueString() {
+		updateParams.BackupStartAt = plan.BackupStartAt.ValueStringPointer()
+		needsUpdate = true
+	}
+	if needsUpdate {
+		_, err = r.dbaasInstances.UpdateContext(ctx, updateParams,
+			tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkDbaasInstances.UpdateConfigs{}))
+		if err != nil {
+			resp.Diagnostics.AddError("Error updating database instance", err.Error())
+			return
+		}
+		if result, err = r.get(ctx, id); err != nil {
+			resp.Diagnostics.AddError("Error reading database instance", err.Error())
+			return
+		}
+	}
+
+	if desiredStatus == dbaasStatusStopped {
+		if result, err = r.lifecycle.setStatus(ctx, id, result, dbaasStatusStopped); err != nil {
+			resp.Diagnostics.AddError("Error stopping database instance", err.Error())
+			return
+		}
+	}
+
+	plan.Status = types.StringNull()
+	r.toState(*result, &plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *dbaasInstances) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data dbaasInstanceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.dbaasInstances.DeleteContext(ctx, sdkDbaasInstances.DeleteParameters{
+		InstanceId: data.ID.ValueString(),
+	}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkDbaasInstances.DeleteConfigs{}))
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting database instance", err.Error())
+		return
+	}
+
+	if err = r.lifecycle.waitDeleted(ctx, data.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Error waiting for the database instance deletion", err.Error())
+	}
+}
+
+func (r *dbaasInstances) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, dbaasImportedKey, []byte("true"))...)
+}
+
+func (r *dbaasInstances) get(ctx context.Context, id string) (*sdkDbaasInstances.GetResult, error) {
+	result, err := r.dbaasInstances.GetContext(ctx, sdkDbaasInstances.GetParameters{
+		InstanceId: id,
+	}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkDbaasInstances.GetConfigs{}))
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *dbaasInstances) start(ctx context.Context, id string) error {
+	_, err := r.dbaasInstances.StartContext(ctx, sdkDbaasInstances.StartParameters{
+		InstanceId: id,
+	}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkDbaasInstances.StartConfigs{}))
+	return err
+}
+
+func (r *dbaasInstances) stop(ctx context.Context, id string) error {
+	_, err := r.dbaasInstances.StopContext(ctx, sdkDbaasInstances.StopParameters{
+		InstanceId: id,
+	}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkDbaasInstances.StopConfigs{}))
+	return err
+}
+
+func (r *dbaasInstances) UpgradeState(context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: dbaasStateUpgraderV0(dbaasInstanceStateV0.upgrade),
+	}
+}
+
+// dbaasInstanceStateV0 is the state of the generated resource, only the attributes that are kept
+type dbaasInstanceStateV0 struct {
+	ID                  *string                 `json:"id"`
+	Name                *string                 `json:"name"`
+	User                *string                 `json:"user"`
+	Password            *string                 `json:"password"`
+	EngineID            *string                 `json:"engine_id"`
+	DatastoreID         *string                 `json:"datastore_id"`
+	InstanceTypeID      *string                 `json:"instance_type_id"`
+	FlavorID            *string                 `json:"flavor_id"`
+	Volume              *dbaasVolumeStateV0     `json:"volume"`
+	CurrentVolume       *dbaasVolumeStateV0     `json:"current_volume"`
+	BackupRetentionDays *int64                  `json:"backup_retention_days"`
+	BackupStartAt       *string                 `json:"backup_start_at"`
+	Parameters          []dbaasParameterStateV0 `json:"parameters"`
+	Status              *string                 `json:"status"`
+	CurrentStatus       *string                 `json:"current_status"`
+	Addresses           []dbaasAddressStateV0   `json:"addresses"`
+	CreatedAt           *string                 `json:"created_at"`
+}
+
+type dbaasParameterStateV0 struct {
+	Name  string `json:"name"`
+	Value *struct {
+		Boolean *bool    `json:"boolean1"`
+		Integer *int64   `json:"integer1"`
+		Number  *float64 `json:"number1"`
+		String  *string  `json:"string1"`
+	} `json:"value"`
+}
+
+func (prior dbaasInstanceStateV0) upgrade() dbaasInstanceModel {
+	state := dbaasInstanceModel{
+		ID:                  types.StringPointerValue(prior.ID),
+		Name:                types.StringPointerValue(prior.Name),
+		User:                types.StringPointerValue(prior.User),
+		Password:            types.StringPointerValue(prior.Password),
+		EngineID:            dbaasFirstNonNull(prior.EngineID, prior.DatastoreID),
+		InstanceTypeID:      dbaasFirstNonNull(prior.InstanceTypeID, prior.FlavorID),
+		BackupRetentionDays: types.Int64PointerValue(prior.BackupRetentionDays),
+		BackupStartAt:       types.StringPointerValue(prior.BackupStartAt),
+		Status:              dbaasStatusFromStateV0(prior.CurrentStatus, prior.Status),
+		Addresses:           dbaasAddressesFromStateV0(prior.Addresses),
+		CreatedAt:           types.StringPointerValue(prior.CreatedAt),
+	}
+	state.VolumeSize, state.VolumeType = dbaasVolumeFromStateV0(prior.Volume, prior.CurrentVolume)
+
+	if len(prior.Parameters) > 0 {
+		state.Parameters = make(map[string]types.String, len(prior.Parameters))
+		for _, p := range prior.Parameters {
+			value := ""
+			switch {
+			case p.Value == nil:
+			case p.Value.Integer != nil:
+				value = strconv.FormatInt(*p.Value.Integer, 10)
+			case p.Value.Number != nil:
+				value = strconv.FormatFloat(*p.Value.Number, 'f', -1, 64)
+			case p.Value.Boolean != nil:
+				value = strconv.FormatBool(*p.Value.Boolean)
+			case p.Value.String != nil:
+				value = *p.Value.String
+			}
+			state.Parameters[p.Name] = types.StringValue(value)
+		}
+	}
+
+	return state
+}
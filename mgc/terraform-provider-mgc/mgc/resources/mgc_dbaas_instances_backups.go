@@ -0,0 +1,323 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	mgcSdk "magalu.cloud/lib"
+	sdkDbaasBackups "magalu.cloud/lib/products/dbaas/instances/backups"
+	"magalu.cloud/terraform-provider-mgc/mgc/client"
+	"magalu.cloud/terraform-provider-mgc/mgc/tfutil"
+)
+
+const (
+	dbaasBackupStatusCreated = "CREATED"
+	dbaasBackupStatusError   = "ERROR"
+)
+
+var (
+	_ resource.Resource                 = &dbaasInstancesBackups{}
+	_ resource.ResourceWithConfigure    = &dbaasInstancesBackups{}
+	_ resource.ResourceWithImportState  = &dbaasInstancesBackups{}
+	_ resource.ResourceWithUpgradeState = &dbaasInstancesBackups{}
+)
+
+type dbaasBackupModel struct {
+	ID         types.String `tfsdk:"id"`
+	InstanceID types.String `tfsdk:"instance_id"`
+	Mode       types.String `tfsdk:"mode"`
+	Name       types.String `tfsdk:"name"`
+	Type       types.String `tfsdk:"type"`
+	Status     types.String `tfsdk:"status"`
+	Size       types.Int64  `tfsdk:"size"`
+	DbSize     types.Int64  `tfsdk:"db_size"`
+	CreatedAt  types.String `tfsdk:"created_at"`
+}
+
+func NewDbaasInstancesBackupsResource() resource.Resource {
+	return &dbaasInstancesBackups{}
+}
+
+type dbaasInstancesBackups struct {
+	sdkClient    *mgcSdk.Client
+	dbaasBackups sdkDbaasBackups.Service
+}
+
+func (r *dbaasInstancesBackups) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dbaas_instances_backups"
+}
+
+func (r *dbaasInstancesBackups) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	var err error
+	var errDetail error
+	r.sdkClient, err, errDetail = client.NewSDKClient(req)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			err.Error(),
+			errDetail.Error(),
+		)
+		return
+	}
+
+	r.dbaasBackups = sdkDbaasBackups.NewService(ctx, r.sdkClient)
+}
+
+func (r *dbaasInstancesBackups) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	description := "Manual (on demand) backups of database instances."
+	resp.Schema = schema.Schema{
+		Description:         description,
+		MarkdownDescription: description,
+		Version:             dbaasSchemaVersion,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the backup.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"instance_id": schema.StringAttribute{
+				Description: "The ID of the database instance to backup.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"mode": schema.StringAttribute{
+				Description: "The backup mode. Options: FULL / INCREMENTAL. Default is FULL.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("FULL"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("FULL", "INCREMENTAL"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the backup.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "The type of the backup, ON_DEMAND for manual backups.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "The status of the backup.",
+				Computed:    true,
+			},
+			"size": schema.Int64Attribute{
+				Description: "The backup file size in kilobytes.",
+				Computed:    true,
+			},
+			"db_size": schema.Int64Attribute{
+				Description: "The database size in kilobytes.",
+				Computed:    true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "The timestamp when the backup was created.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *dbaasInstancesBackups) toState(result sdkDbaasBackups.GetResult, state *dbaasBackupModel) {
+	state.ID = types.StringValue(result.Id)
+	state.InstanceID = types.StringValue(result.InstanceId)
+	state.Mode = types.StringValue(result.Mode)
+	state.Name = types.StringPointerValue(result.Name)
+	state.Type = types.StringValue(result.Type)
+	state.Status = types.StringValue(result.Status)
+	state.CreatedAt = types.StringValue(result.CreatedAt)
+	state.Size = types.Int64Null()
+	if result.Size != nil {
+		state.Size = types.Int64Value(int64(*result.Size))
+	}
+	state.DbSize = types.Int64Null()
+	if result.DbSize != nil {
+		state.DbSize = types.Int64Value(int64(*result.DbSize))
+	}
+}
+
+func (r *dbaasInstancesBackups) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data dbaasBackupModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.dbaasBackups.CreateContext(ctx, sdkDbaasBackups.CreateParameters{
+		InstanceId: data.InstanceID.ValueString(),
+		Mode:       data.Mode.ValueString(),
+	}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkDbaasBackups.CreateConfigs{}))
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating database backup", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(created.Id)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), data.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("instance_id"), data.InstanceID)...)
+
+	result, err := r.waitBackupCreated(ctx, data.InstanceID.ValueString(), created.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Error waiting for the database backup to be created", err.Error())
+		return
+	}
+
+	r.toState(*result, &data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *dbaasInstancesBackups) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data dbaasBackupModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.get(ctx, data.InstanceID.ValueString(), data.ID.ValueString())
+	if dbaasIsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading database backup", err.Error())
+		return
+	}
+	if result.Status == dbaasStatusDeleted {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	r.toState(*result, &data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *dbaasInstancesBackups) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.AddError("Update is not supported for database backups", "")
+}
+
+func (r *dbaasInstancesBackups) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data dbaasBackupModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.dbaasBackups.DeleteContext(ctx, sdkDbaasBackups.DeleteParameters{
+		InstanceId: data.InstanceID.ValueString(),
+		BackupId:   data.ID.ValueString(),
+	}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkDbaasBackups.DeleteConfigs{}))
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting database backup", err.Error())
+		return
+	}
+}
+
+func (r *dbaasInstancesBackups) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	input := strings.Split(req.ID, ",")
+	if len(input) != 2 {
+		resp.Diagnostics.AddError("Invalid ID", "ID must be in the format instance_id,backup_id")
+		return
+	}
+
+	result, err := r.get(ctx, input[0], input[1])
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to import database backup", err.Error())
+		return
+	}
+
+	data := dbaasBackupModel{}
+	r.toState(*result, &data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *dbaasInstancesBackups) get(ctx context.Context, instanceID, backupID string) (*sdkDbaasBackups.GetResult, error) {
+	result, err := r.dbaasBackups.GetContext(ctx, sdkDbaasBackups.GetParameters{
+		InstanceId: instanceID,
+		BackupId:   backupID,
+	}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkDbaasBackups.GetConfigs{}))
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *dbaasInstancesBackups) waitBackupCreated(ctx context.Context, instanceID, backupID string) (*sdkDbaasBackups.GetResult, error) {
+	var result *sdkDbaasBackups.GetResult
+	err := dbaasPoll(ctx, func() (bool, error) {
+		var err error
+		if result, err = r.get(ctx, instanceID, backupID); err != nil {
+			return false, err
+		}
+		if result.Status == dbaasBackupStatusError {
+			return false, fmt.Errorf("database backup %s is in %s status", backupID, result.Status)
+		}
+		tflog.Debug(ctx, fmt.Sprintf("database backup current status: %s", result.Status))
+		return result.Status == dbaasBackupStatusCreated, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("waiting for database backup creation: %w", err)
+	}
+	return result, nil
+}
+
+func (r *dbaasInstancesBackups) UpgradeState(context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: dbaasStateUpgraderV0(dbaasBackupStateV0.upgrade),
+	}
+}
+
+// dbaasBackupStateV0 is the state of the generated resource, only the attributes that are kept
+type dbaasBackupStateV0 struct {
+	ID         *string `json:"id"`
+	InstanceID *string `json:"instance_id"`
+	Mode       *string `json:"mode"`
+	Name       *string `json:"name"`
+	Type       *string `json:"type"`
+	Status     *string `json:"status"`
+	Size       *int64  `json:"size"`
+	DbSize     *int64  `json:"db_size"`
+	CreatedAt  *string `json:"created_at"`
+}
+
+func (prior dbaasBackupStateV0) upgrade() dbaasBackupModel {
+	return dbaasBackupModel{
+		ID:         types.StringPointerValue(prior.ID),
+		InstanceID: types.StringPointerValue(prior.InstanceID),
+		Mode:       types.StringPointerValue(prior.Mode),
+		Name:       types.StringPointerValue(prior.Name),
+		Type:       types.StringPointerValue(prior.Type),
+		Status:     types.StringPointerValue(prior.Status),
+		Size:       types.Int64PointerValue(prior.Size),
+		DbSize:     types.Int64PointerValue(prior.DbSize),
+		CreatedAt:  types.StringPointerValue(prior.CreatedAt),
+	}
+}
@@ -0,0 +1,295 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	sdkDbaasInstances "magalu.cloud/lib/products/dbaas/instances"
+)
+
+func dbaasInstancesTestSchema(t *testing.T) resource.SchemaResponse {
+	resp := resource.SchemaResponse{}
+	(&dbaasInstances{}).Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema error: %v", resp.Diagnostics)
+	}
+	return resp
+}
+
+// dbaasInstancesTestValue builds a value with the given attributes, the others are null
+func dbaasInstancesTestValue(t *testing.T, attributes map[string]tftypes.Value) tftypes.Value {
+	objectType := dbaasInstancesTestSchema(t).Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := attributes[name]; ok {
+			values[name] = value
+		} else {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+	return tftypes.NewValue(objectType, values)
+}
+
+func dbaasParametersTestValue(parameters map[string]tftypes.Value) tftypes.Value {
+	return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, parameters)
+}
+
+func TestDbaasInstancesModifyPlan(t *testing.T) {
+	ctx := context.Background()
+	s := dbaasInstancesTestSchema(t).Schema
+
+	state := map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, "id"),
+		"password":    tftypes.NewValue(tftypes.String, "secret"),
+		"volume_size": tftypes.NewValue(tftypes.Number, 20),
+		"parameters":  dbaasParametersTestValue(map[string]tftypes.Value{"max_connections": tftypes.NewValue(tftypes.String, "200")}),
+	}
+	withChanges := func(changes map[string]tftypes.Value) map[string]tftypes.Value {
+		values := map[string]tftypes.Value{}
+		for name, value := range state {
+			values[name] = value
+		}
+		for name, value := range changes {
+			values[name] = value
+		}
+		return values
+	}
+
+	testCases := []struct {
+		name     string
+		state    map[string]tftypes.Value
+		plan     map[string]tftypes.Value
+		imported bool
+		isError  bool
+	}{
+		{name: "no changes", state: state, plan: state},
+		{name: "volume increase", state: state, plan: withChanges(map[string]tftypes.Value{
+			"volume_size": tftypes.NewValue(tftypes.Number, 30),
+		})},
+		{name: "volume decrease", state: state, isError: true, plan: withChanges(map[string]tftypes.Value{
+			"volume_size": tftypes.NewValue(tftypes.Number, 10),
+		})},
+		{name: "password change", state: state, isError: true, plan: withChanges(map[string]tftypes.Value{
+			"password": tftypes.NewValue(tftypes.String, "other"),
+		})},
+		{name: "imported without password", state: withChanges(map[string]tftypes.Value{
+			"password": tftypes.NewValue(tftypes.String, nil),
+		}), plan: state},
+		{name: "parameter change", state: state, isError: true, plan: withChanges(map[string]tftypes.Value{
+			"parameters": dbaasParametersTestValue(map[string]tftypes.Value{"max_connections": tftypes.NewValue(tftypes.String, "300")}),
+		})},
+		{name: "parameter added", state: state, isError: true, plan: withChanges(map[string]tftypes.Value{
+			"parameters": dbaasParametersTestValue(map[string]tftypes.Value{
+				"max_connections": tftypes.NewValue(tftypes.String, "200"),
+				"wait_timeout":    tftypes.NewValue(tftypes.String, "60"),
+			}),
+		})},
+		{name: "unknown parameter", state: state, plan: withChanges(map[string]tftypes.Value{
+			"parameters": dbaasParametersTestValue(map[string]tftypes.Value{"max_connections": tftypes.NewValue(tftypes.String, tftypes.UnknownValue)}),
+		})},
+		{name: "imported without parameters", imported: true, state: withChanges(map[string]tftypes.Value{
+			"parameters": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+		}), plan: state},
+		{name: "parameters added after creation", isError: true, state: withChanges(map[string]tftypes.Value{
+			"parameters": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+		}), plan: state},
+		{name: "created without parameters", state: withChanges(map[string]tftypes.Value{
+			"parameters": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+		}), plan: withChanges(map[string]tftypes.Value{
+			"parameters": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+		})},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := dbaasInstancesTestValue(t, tc.plan)
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: s, Raw: plan},
+				Plan:   tfsdk.Plan{Schema: s, Raw: plan},
+				State:  tfsdk.State{Schema: s, Raw: dbaasInstancesTestValue(t, tc.state)},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+			(&dbaasInstances{}).modifyPlan(ctx, req, resp, tc.imported)
+			assert.Equal(t, tc.isError, resp.Diagnostics.HasError(), resp.Diagnostics)
+		})
+	}
+}
+
+func TestDbaasParameterString(t *testing.T) {
+	testCases := []struct {
+		json     string
+		expected string
+	}{
+		{json: `200`, expected: "200"},
+		{json: `0.5`, expected: "0.5"},
+		{json: `1e21`, expected: "1000000000000000000000"},
+		{json: `true`, expected: "true"},
+		{json: `"utf8mb4"`, expected: "utf8mb4"},
+		{json: `null`, expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.json, func(t *testing.T) {
+			var value sdkDbaasInstances.GetResultParametersItemValue
+			if err := json.Unmarshal([]byte(tc.json), &value); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assert.Equal(t, tc.expected, dbaasParameterString(&value))
+		})
+	}
+
+	var value sdkDbaasInstances.GetResultParametersItemValue
+	if err := json.Unmarshal([]byte(`200`), &value); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.NotNil(t, value.Int, "integers must be decoded as integers")
+	assert.Nil(t, value.Float64)
+}
+
+func TestDbaasInstancesToStateParameters(t *testing.T) {
+	value := func(v sdkDbaasInstances.GetResultParametersItemValue) *sdkDbaasInstances.GetResultParametersItemValue {
+		return &v
+	}
+	result := sdkDbaasInstances.GetResult{
+		Status: dbaasStatusActive,
+		Parameters: sdkDbaasInstances.GetResultParameters{
+			{Name: "max_connections", Value: value(sdkDbaasInstances.NewGetResultParametersItemValueInt(300))},
+			{Name: "ratio", Value: value(sdkDbaasInstances.NewGetResultParametersItemValueFloat64(1.5))},
+			{Name: "wait_timeout", Value: value(sdkDbaasInstances.NewGetResultParametersItemValueInt(60))},
+		},
+	}
+	state := dbaasInstanceModel{
+		Parameters: map[string]types.String{
+			"max_connections": types.StringValue("200"),
+			"ratio":           types.StringValue("1.50"),
+		},
+	}
+
+	(&dbaasInstances{}).toState(result, &state)
+	assert.Equal(t, map[string]types.String{
+		"max_connections": types.StringValue("300"),
+		"ratio":           types.StringValue("1.50"),
+	}, state.Parameters)
+	assert.Equal(t, types.StringValue(dbaasStatusActive), state.Status)
+}
+
+func TestDbaasInstancesUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	s := dbaasInstancesTestSchema(t).Schema
+
+	// Written by the resource generated from the OpenAPI spec
+	prior := `{
+		"id": "id",
+		"name": "db",
+		"user": "admin",
+		"password": "secret",
+		"datastore_id": "engine",
+		"flavor_id": "flavor",
+		"volume": {"size": 20, "type": null},
+		"current_volume": {"size": 30, "type": "CLOUD_NVME"},
+		"backup_retention_days": 7,
+		"backup_start_at": "03:00:00",
+		"parameters": [
+			{"name": "max_connections", "value": {"integer1": 200, "number1": null, "string1": null, "boolean1": null}},
+			{"name": "ratio", "value": {"integer1": null, "number1": 0.5, "string1": null, "boolean1": null}},
+			{"name": "read_only", "value": {"integer1": null, "number1": null, "string1": null, "boolean1": true}}
+		],
+		"status": null,
+		"current_status": "ACTIVE",
+		"addresses": [{"access": "PRIVATE", "address": "10.0.0.1", "type": "IPv4"}],
+		"created_at": "2024-01-01T00:00:00",
+		"generation": "GEN1",
+		"replicas": []
+	}`
+
+	upgrader := (&dbaasInstances{}).UpgradeState(ctx)[0]
+	req := resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(prior)}}
+	resp := &resource.UpgradeStateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}}
+	upgrader.StateUpgrader(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state dbaasInstanceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	assert.Equal(t, dbaasInstanceModel{
+		ID:                  types.StringValue("id"),
+		Name:                types.StringValue("db"),
+		User:                types.StringValue("admin"),
+		Password:            types.StringValue("secret"),
+		EngineID:            types.StringValue("engine"),
+		InstanceTypeID:      types.StringValue("flavor"),
+		VolumeSize:          types.Int64Value(30),
+		VolumeType:          types.StringValue("CLOUD_NVME"),
+		BackupRetentionDays: types.Int64Value(7),
+		BackupStartAt:       types.StringValue("03:00:00"),
+		Parameters: map[string]types.String{
+			"max_connections": types.StringValue("200"),
+			"ratio":           types.StringValue("0.5"),
+			"read_only":       types.StringValue("true"),
+		},
+		Status: types.StringValue(dbaasStatusActive),
+		Addresses: []dbaasAddressModel{{
+			Access:  types.StringValue("PRIVATE"),
+			Address: types.StringValue("10.0.0.1"),
+			Type:    types.StringValue("IPv4"),
+		}},
+		CreatedAt: types.StringValue("2024-01-01T00:00:00"),
+	}, state)
+}
+
+func TestDbaasReplicasUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := resource.SchemaResponse{}
+	(&dbaasReplicas{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	prior := `{
+		"id": "id",
+		"name": "replica",
+		"source_id": "source",
+		"instance_type_id": "type",
+		"engine_id": "engine",
+		"volume": {"size": 20, "type": "CLOUD_NVME"},
+		"status": "RESIZING",
+		"addresses": null,
+		"created_at": "2024-01-01T00:00:00"
+	}`
+
+	upgrader := (&dbaasReplicas{}).UpgradeState(ctx)[0]
+	req := resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(prior)}}
+	resp := &resource.UpgradeStateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}}
+	upgrader.StateUpgrader(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state dbaasReplicaModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	assert.Equal(t, dbaasReplicaModel{
+		ID:             types.StringValue("id"),
+		Name:           types.StringValue("replica"),
+		SourceID:       types.StringValue("source"),
+		InstanceTypeID: types.StringValue("type"),
+		EngineID:       types.StringValue("engine"),
+		VolumeSize:     types.Int64Value(20),
+		VolumeType:     types.StringValue("CLOUD_NVME"),
+		Status:         types.StringNull(),
+		Addresses:      []dbaasAddressModel{},
+		CreatedAt:      types.StringValue("2024-01-01T00:00:00"),
+	}, state)
+}
@@ -0,0 +1,392 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	mgcSdk "magalu.cloud/lib"
+	sdkDbaasReplicas "magalu.cloud/lib/products/dbaas/replicas"
+	"magalu.cloud/terraform-provider-mgc/mgc/client"
+	"magalu.cloud/terraform-provider-mgc/mgc/tfutil"
+)
+
+var (
+	_ resource.Resource                 = &dbaasReplicas{}
+	_ resource.ResourceWithConfigure    = &dbaasReplicas{}
+	_ resource.ResourceWithImportState  = &dbaasReplicas{}
+	_ resource.ResourceWithModifyPlan   = &dbaasReplicas{}
+	_ resource.ResourceWithUpgradeState = &dbaasReplicas{}
+)
+
+type dbaasReplicaModel struct {
+	ID             types.String        `tfsdk:"id"`
+	Name           types.String        `tfsdk:"name"`
+	SourceID       types.String        `tfsdk:"source_id"`
+	InstanceTypeID types.String        `tfsdk:"instance_type_id"`
+	EngineID       types.String        `tfsdk:"engine_id"`
+	VolumeSize     types.Int64         `tfsdk:"volume_size"`
+	VolumeType     types.String        `tfsdk:"volume_type"`
+	Status         types.String        `tfsdk:"status"`
+	Addresses      []dbaasAddressModel `tfsdk:"addresses"`
+	CreatedAt      types.String        `tfsdk:"created_at"`
+}
+
+func NewDbaasReplicasResource() resource.Resource {
+	return &dbaasReplicas{}
+}
+
+type dbaasReplicas struct {
+	sdkClient     *mgcSdk.Client
+	dbaasReplicas sdkDbaasReplicas.Service
+	lifecycle     *dbaasStatusManager[sdkDbaasReplicas.GetResult]
+	catalog       *tfutil.Catalog
+}
+
+func (r *dbaasReplicas) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dbaas_replicas"
+}
+
+func (r *dbaasReplicas) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	var err error
+	var errDetail error
+	r.sdkClient, err, errDetail = client.NewSDKClient(req)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			err.Error(),
+			errDetail.Error(),
+		)
+		return
+	}
+
+	r.dbaasReplicas = sdkDbaasReplicas.NewService(ctx, r.sdkClient)
+	r.lifecycle = &dbaasStatusManager[sdkDbaasReplicas.GetResult]{
+		kind:   "database replica",
+		get:    r.get,
+		status: func(result *sdkDbaasReplicas.GetResult) string { return result.Status },
+		start:  r.start,
+		stop:   r.stop,
+	}
+	r.catalog = tfutil.CatalogFromProviderData(req.ProviderData)
+}
+
+func (r *dbaasReplicas) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.catalog == nil {
+		return
+	}
+
+	instanceTypePath := path.Root("instance_type_id")
+	instanceType, diags := tfutil.PlannedStringChange(ctx, req, instanceTypePath)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(r.catalog.Validate(ctx, instanceType, "DBaaS instance type", listDbaasInstanceTypeIDs(r.sdkClient), instanceTypePath)...)
+}
+
+func (r *dbaasReplicas) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	description := "Database read replicas management. The instance type is resized in place and the replica can be stopped and started through its status."
+	resp.Schema = schema.Schema{
+		Description:         description,
+		MarkdownDescription: description,
+		Version:             dbaasSchemaVersion,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the replica.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the replica.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_id": schema.StringAttribute{
+				Description: "The ID of the database instance to replicate.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"instance_type_id": schema.StringAttribute{
+				Description: "The ID of the instance type. Defaults to the one of the source instance. Changing it resizes the replica in place.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"engine_id": schema.StringAttribute{
+				Description: "The ID of the database engine.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"volume_size": schema.Int64Attribute{
+				Description: "The size of the volume in GiB, which follows the source instance.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"volume_type": schema.StringAttribute{
+				Description: "The type of the volume.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "The status of the replica. Set it to STOPPED to stop the replica or to ACTIVE to start it again.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(dbaasStatusActive, dbaasStatusStopped),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"addresses": dbaasAddressesSchema(),
+			"created_at": schema.StringAttribute{
+				Description: "The timestamp when the replica was created.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *dbaasReplicas) toState(result sdkDbaasReplicas.GetResult, state *dbaasReplicaModel) {
+	state.ID = types.StringValue(result.Id)
+	state.Name = types.StringValue(result.Name)
+	state.SourceID = types.StringValue(result.SourceId)
+	state.InstanceTypeID = types.StringValue(result.InstanceTypeId)
+	state.EngineID = types.StringValue(result.EngineId)
+	state.VolumeSize = types.Int64Value(int64(result.Volume.Size))
+	state.VolumeType = types.StringValue(result.Volume.Type)
+	state.CreatedAt = types.StringValue(result.CreatedAt)
+
+	state.Status = dbaasStatusToState(state.Status, result.Status)
+	state.Addresses = dbaasAddressesToState(result.Addresses)
+}
+
+func (r *dbaasReplicas) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data dbaasReplicaModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.dbaasReplicas.CreateContext(ctx, sdkDbaasReplicas.CreateParameters{
+		Name:           data.Name.ValueString(),
+		SourceId:       data.SourceID.ValueString(),
+		InstanceTypeId: data.InstanceTypeID.ValueStringPointer(),
+	}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkDbaasReplicas.CreateConfigs{}))
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating database replica", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(created.Id)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), data.ID)...)
+
+	result, err := r.lifecycle.waitStatus(ctx, created.Id, dbaasStatusActive)
+	if err != nil {
+		resp.Diagnostics.AddError("Error waiting for the database replica to be active", err.Error())
+		return
+	}
+
+	if data.Status.ValueString() == dbaasStatusStopped {
+		if result, err = r.lifecycle.setStatus(ctx, created.Id, result, dbaasStatusStopped); err != nil {
+			resp.Diagnostics.AddError("Error stopping database replica", err.Error())
+			return
+		}
+	}
+
+	data.Status = types.StringNull()
+	r.toState(*result, &data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *dbaasReplicas) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data dbaasReplicaModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.get(ctx, data.ID.ValueString())
+	if dbaasIsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading database replica", err.Error())
+		return
+	}
+	if result.Status == dbaasStatusDeleted {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	r.toState(*result, &data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *dbaasReplicas) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state dbaasReplicaModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+	result, err := r.get(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading database replica", err.Error())
+		return
+	}
+
+	needsResize := !plan.InstanceTypeID.IsUnknown() && plan.InstanceTypeID.ValueString() != state.InstanceTypeID.ValueString()
+	desiredStatus := plan.Status.ValueString()
+	if desiredStatus == "" {
+		desiredStatus = result.Status
+	}
+
+	// Resizing requires a running replica, it's stopped again afterwards if desired
+	if needsResize || desiredStatus == dbaasStatusActive {
+		if result, err = r.lifecycle.setStatus(ctx, id, result, dbaasStatusActive); err != nil {
+			resp.Diagnostics.AddError("Error starting database replica", err.Error())
+			return
+		}
+	}
+
+	if needsResize {
+		_, err = r.dbaasReplicas.ResizeContext(ctx, sdkDbaasReplicas.ResizeParameters{
+			ReplicaId:      id,
+			InstanceTypeId: plan.InstanceTypeID.ValueStringPointer(),
+		}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkDbaasReplicas.ResizeConfigs{}))
+		if err != nil {
+			resp.Diagnostics.AddError("Error resizing database replica", err.Error())
+			return
+		}
+		tflog.Debug(ctx, "waiting database replica resize")
+		if result, err = r.lifecycle.waitStatus(ctx, id, dbaasStatusActive); err != nil {
+			resp.Diagnostics.AddError("Error waiting for the database replica resize", err.Error())
+			return
+		}
+	}
+
+	if desiredStatus == dbaasStatusStopped {
+		if result, err = r.lifecycle.setStatus(ctx, id, result, dbaasStatusStopped); err != nil {
+			resp.Diagnostics.AddError("Error stopping database replica", err.Error())
+			return
+		}
+	}
+
+	plan.Status = types.StringNull()
+	r.toState(*result, &plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *dbaasReplicas) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data dbaasReplicaModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.dbaasReplicas.DeleteContext(ctx, sdkDbaasReplicas.DeleteParameters{
+		ReplicaId: data.ID.ValueString(),
+	}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkDbaasReplicas.DeleteConfigs{}))
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting database replica", err.Error())
+		return
+	}
+
+	if err = r.lifecycle.waitDeleted(ctx, data.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Error waiting for the database replica deletion", err.Error())
+	}
+}
+
+func (r *dbaasReplicas) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *dbaasReplicas) get(ctx context.Context, id string) (*sdkDbaasReplicas.GetResult, error) {
+	result, err := r.dbaasReplicas.GetContext(ctx, sdkDbaasReplicas.GetParameters{
+		ReplicaId: id,
+	}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkDbaasReplicas.GetConfigs{}))
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *dbaasReplicas) start(ctx context.Context, id string) error {
+	_, err := r.dbaasReplicas.StartContext(ctx, sdkDbaasReplicas.StartParameters{
+		ReplicaId: id,
+	}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkDbaasReplicas.StartConfigs{}))
+	return err
+}
+
+func (r *dbaasReplicas) stop(ctx context.Context, id string) error {
+	_, err := r.dbaasReplicas.StopContext(ctx, sdkDbaasReplicas.StopParameters{
+		ReplicaId: id,
+	}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkDbaasReplicas.StopConfigs{}))
+	return err
+}
+
+func (r *dbaasReplicas) UpgradeState(context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: dbaasStateUpgraderV0(dbaasReplicaStateV0.upgrade),
+	}
+}
+
+// dbaasReplicaStateV0 is the state of the generated resource, only the attributes that are kept
+type dbaasReplicaStateV0 struct {
+	ID             *string               `json:"id"`
+	Name           *string               `json:"name"`
+	SourceID       *string               `json:"source_id"`
+	InstanceTypeID *string               `json:"instance_type_id"`
+	FlavorID       *string               `json:"flavor_id"`
+	EngineID       *string               `json:"engine_id"`
+	DatastoreID    *string               `json:"datastore_id"`
+	Volume         *dbaasVolumeStateV0   `json:"volume"`
+	Status         *string               `json:"status"`
+	Addresses      []dbaasAddressStateV0 `json:"addresses"`
+	CreatedAt      *string               `json:"created_at"`
+}
+
+func (prior dbaasReplicaStateV0) upgrade() dbaasReplicaModel {
+	state := dbaasReplicaModel{
+		ID:             types.StringPointerValue(prior.ID),
+		Name:           types.StringPointerValue(prior.Name),
+		SourceID:       types.StringPointerValue(prior.SourceID),
+		InstanceTypeID: dbaasFirstNonNull(prior.InstanceTypeID, prior.FlavorID),
+		EngineID:       dbaasFirstNonNull(prior.EngineID, prior.DatastoreID),
+		Status:         dbaasStatusFromStateV0(prior.Status),
+		Addresses:      dbaasAddressesFromStateV0(prior.Addresses),
+		CreatedAt:      types.StringPointerValue(prior.CreatedAt),
+	}
+	state.VolumeSize, state.VolumeType = dbaasVolumeFromStateV0(prior.Volume, nil)
+	return state
+}
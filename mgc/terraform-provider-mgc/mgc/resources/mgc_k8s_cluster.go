@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -21,6 +22,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	mgcSdk "magalu.cloud/lib"
 	sdkCluster "magalu.cloud/lib/products/kubernetes/cluster"
+	sdkVersion "magalu.cloud/lib/products/kubernetes/version"
 	"magalu.cloud/terraform-provider-mgc/mgc/client"
 	tfutil "magalu.cloud/terraform-provider-mgc/mgc/tfutil"
 )
@@ -42,9 +44,13 @@ type KubernetesClusterCreateResourceModel struct {
 	Zone               types.String   `tfsdk:"zone"`
 }
 
+var _ resource.ResourceWithModifyPlan = &k8sClusterResource{}
+
 type k8sClusterResource struct {
 	sdkClient  *mgcSdk.Client
 	k8sCluster sdkCluster.Service
+	k8sVersion sdkVersion.Service
+	catalog    *tfutil.Catalog
 }
 
 func NewK8sClusterResource() resource.Resource {
@@ -72,6 +78,29 @@ func (r *k8sClusterResource) Configure(ctx context.Context, req resource.Configu
 	}
 
 	r.k8sCluster = sdkCluster.NewService(ctx, r.sdkClient)
+	r.k8sVersion = sdkVersion.NewService(ctx, r.sdkClient)
+	r.catalog = tfutil.CatalogFromProviderData(req.ProviderData)
+}
+
+func (r *k8sClusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.catalog == nil {
+		return
+	}
+
+	versionPath := path.Root("version")
+	version, diags := tfutil.PlannedStringChange(ctx, req, versionPath)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(r.catalog.Validate(ctx, version, "Kubernetes version", func(ctx context.Context) ([]string, error) {
+		result, err := r.k8sVersion.ListContext(ctx, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkVersion.ListConfigs{}))
+		if err != nil {
+			return nil, err
+		}
+		versions := []string{}
+		for _, v := range result.Results {
+			versions = append(versions, v.Version)
+		}
+		return versions, nil
+	}, versionPath)...)
 }
 
 func (r *k8sClusterResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
@@ -14,14 +15,21 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	mgcSdk "magalu.cloud/lib"
+	sdkFlavor "magalu.cloud/lib/products/kubernetes/flavor"
 	sdkNodepool "magalu.cloud/lib/products/kubernetes/nodepool"
 	"magalu.cloud/terraform-provider-mgc/mgc/client"
 	tfutil "magalu.cloud/terraform-provider-mgc/mgc/tfutil"
 )
 
+var _ resource.ResourceWithModifyPlan = &NewNodePoolResource{}
+
 type NewNodePoolResource struct {
 	sdkClient   *mgcSdk.Client
 	sdkNodepool sdkNodepool.Service
+	sdkFlavor   sdkFlavor.Service
+	catalog     *tfutil.Catalog
+	// provider default_labels, merged into the tags
+	defaultLabels []string
 }
 
 func NewNewNodePoolResource() resource.Resource {
@@ -49,6 +57,35 @@ func (r *NewNodePoolResource) Configure(ctx context.Context, req resource.Config
 	}
 
 	r.sdkNodepool = sdkNodepool.NewService(ctx, r.sdkClient)
+	r.sdkFlavor = sdkFlavor.NewService(ctx, r.sdkClient)
+	r.catalog = tfutil.CatalogFromProviderData(req.ProviderData)
+	r.defaultLabels = tfutil.DefaultLabelsFromProviderData(req.ProviderData)
+}
+
+func (r *NewNodePoolResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// the API has no way to change the tags of existing node pools, they're replaced
+	tfutil.ModifyPlanCreateOnlyTags(ctx, r.defaultLabels, req, resp)
+
+	if r.catalog == nil {
+		return
+	}
+
+	flavorPath := path.Root("flavor_name")
+	flavor, diags := tfutil.PlannedStringChange(ctx, req, flavorPath)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(r.catalog.Validate(ctx, flavor, "Kubernetes node pool flavor", func(ctx context.Context) ([]string, error) {
+		result, err := r.sdkFlavor.ListContext(ctx, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkFlavor.ListConfigs{}))
+		if err != nil {
+			return nil, err
+		}
+		flavors := []string{}
+		for _, f := range result.Results {
+			for _, nodepool := range f.Nodepool {
+				flavors = append(flavors, nodepool.Name)
+			}
+		}
+		return flavors, nil
+	}, flavorPath)...)
 }
 
 func (r *NewNodePoolResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -91,7 +128,7 @@ func (r *NewNodePoolResource) Schema(_ context.Context, req resource.SchemaReque
 				Computed:    true,
 			},
 			"tags": schema.ListAttribute{
-				Description: "List of tags applied to the node pool.",
+				Description: "List of tags applied to the node pool, merged with the provider default_labels. Tags can only be set when the node pool is created, changing them replaces the node pool.",
 				Optional:    true,
 				Computed:    true,
 				ElementType: types.StringType,
@@ -163,9 +200,18 @@ func (r *NewNodePoolResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	// the planned tags include the provider default_labels
+	var plannedTags types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, tfutil.TagsPath, &plannedTags)...)
+	planned, diags := tfutil.LabelsFromList(ctx, plannedTags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var tags sdkNodepool.CreateParametersTags
-	if data.Tags != nil {
-		tags = sdkNodepool.CreateParametersTags(*convertStringArrayTFToSliceString(data.Tags))
+	if len(planned) > 0 {
+		tags = sdkNodepool.CreateParametersTags(planned)
 	}
 	createParams := sdkNodepool.CreateParameters{
 		ClusterId: data.ClusterID.ValueString(),
@@ -305,17 +351,6 @@ func convertTaintsNP(taints *[]tfutil.Taint) *sdkNodepool.CreateParametersTaints
 	return &rt
 }
 
-func convertStringArrayTFToSliceString(tags *[]types.String) *[]string {
-	if tags == nil {
-		return nil
-	}
-	tagsSlice := make([]string, len(*tags))
-	for i, tag := range *tags {
-		tagsSlice[i] = tag.ValueString()
-	}
-	return &tagsSlice
-}
-
 func (r *NewNodePoolResource) waitNodePoolCreation(ctx context.Context, nodepoolid, clusterId string) error {
 	for startTime := time.Now(); time.Since(startTime) < ClusterPoolingTimeout; {
 		time.Sleep(30 * time.Second)
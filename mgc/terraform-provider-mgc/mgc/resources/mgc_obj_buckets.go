@@ -2,6 +2,8 @@ package resources
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -12,6 +14,12 @@ import (
 	tfutil "magalu.cloud/terraform-provider-mgc/mgc/tfutil"
 
 	sdkBuckets "magalu.cloud/lib/products/object_storage/buckets"
+	sdkBucketLabels "magalu.cloud/lib/products/object_storage/buckets/label"
+)
+
+var (
+	_ resource.ResourceWithConfigure  = &objectStorageBuckets{}
+	_ resource.ResourceWithModifyPlan = &objectStorageBuckets{}
 )
 
 type ObjectStorageBucket struct {
@@ -30,6 +38,7 @@ type ObjectStorageBucket struct {
 	PublicRead        types.Bool   `tfsdk:"public_read"`
 	PublicReadWrite   types.Bool   `tfsdk:"public_read_write"`
 	Recursive         types.Bool   `tfsdk:"recursive"`
+	Labels            types.Set    `tfsdk:"labels"`
 }
 
 type Grant struct {
@@ -41,8 +50,10 @@ func NewObjectStorageBucketsResource() resource.Resource {
 }
 
 type objectStorageBuckets struct {
-	sdkClient *mgcSdk.Client
-	buckets   sdkBuckets.Service
+	sdkClient     *mgcSdk.Client
+	buckets       sdkBuckets.Service
+	bucketLabels  sdkBucketLabels.Service
+	defaultLabels []string
 }
 
 func (r *objectStorageBuckets) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -67,6 +78,8 @@ func (r *objectStorageBuckets) Configure(ctx context.Context, req resource.Confi
 	}
 
 	r.buckets = sdkBuckets.NewService(ctx, r.sdkClient)
+	r.bucketLabels = sdkBucketLabels.NewService(ctx, r.sdkClient)
+	r.defaultLabels = tfutil.DefaultLabelsFromProviderData(req.ProviderData)
 }
 
 func (r *objectStorageBuckets) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -162,10 +175,20 @@ func (r *objectStorageBuckets) Schema(ctx context.Context, req resource.SchemaRe
 				Optional:    true,
 				Description: "Delete bucket including objects inside.",
 			},
+			"labels": schema.SetAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Labels of the bucket, merged with the provider default_labels.",
+			},
 		},
 	}
 }
 
+func (r *objectStorageBuckets) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	tfutil.ModifyPlanDefaultLabels(ctx, r.defaultLabels, req, resp)
+}
+
 func (r *objectStorageBuckets) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var model ObjectStorageBucket
 	diags := req.Plan.Get(ctx, &model)
@@ -204,6 +227,31 @@ func (r *objectStorageBuckets) Create(ctx context.Context, req resource.CreateRe
 
 	model.FinalName = types.StringValue(result.Bucket)
 
+	labels, diags := tfutil.LabelsFromSet(ctx, model.Labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(labels) > 0 {
+		_, err = r.bucketLabels.SetContext(ctx, sdkBucketLabels.SetParameters{
+			Bucket: result.Bucket,
+			Label:  strings.Join(labels, ","),
+		}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkBucketLabels.SetConfigs{}))
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to set bucket labels", err.Error())
+			// the new bucket is empty, remove it so the next apply creates it again
+			_, deleteErr := r.buckets.DeleteContext(ctx, sdkBuckets.DeleteParameters{
+				Bucket: result.Bucket,
+			}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkBuckets.DeleteConfigs{}))
+			if deleteErr == nil {
+				return
+			}
+			// keep it in the state, the error taints it so the next apply replaces it
+			resp.Diagnostics.AddError("Failed to delete bucket without labels", deleteErr.Error())
+			model.Labels = types.SetNull(types.StringType)
+		}
+	}
+
 	diags = resp.State.Set(ctx, &model)
 	resp.Diagnostics.Append(diags...)
 	if diags.HasError() {
@@ -230,11 +278,95 @@ func (r *objectStorageBuckets) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	resp.State = req.State
+	result, err := r.bucketLabels.GetContext(ctx, sdkBucketLabels.GetParameters{
+		Bucket: model.FinalName.ValueString(),
+	}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkBucketLabels.GetConfigs{}))
+	if err != nil {
+		resp.Diagnostics.AddWarning("Failed to read bucket labels", err.Error())
+		resp.State = req.State
+		return
+	}
+
+	model.Labels, diags = tfutil.LabelsToSet(ctx, tfutil.SplitLabels(result.Labels))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *objectStorageBuckets) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("Update is not supported for Buckets creation", "Update is not supported")
+	var plan, state ObjectStorageBucket
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only labels can be updated
+	if !bucketSettingsEqual(plan, state) {
+		resp.Diagnostics.AddError("Update is not supported for Buckets creation", "Update is not supported")
+		return
+	}
+
+	planLabels, diags := tfutil.LabelsFromSet(ctx, plan.Labels)
+	resp.Diagnostics.Append(diags...)
+	stateLabels, diags := tfutil.LabelsFromSet(ctx, state.Labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	added, removed := tfutil.DiffLabels(stateLabels, planLabels)
+	if len(removed) > 0 {
+		_, err := r.bucketLabels.DeleteContext(ctx, sdkBucketLabels.DeleteParameters{
+			Bucket: state.FinalName.ValueString(),
+			Label:  strings.Join(removed, ","),
+		}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkBucketLabels.DeleteConfigs{}))
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to delete bucket labels", err.Error())
+			return
+		}
+	}
+	if len(added) > 0 {
+		_, err := r.bucketLabels.SetContext(ctx, sdkBucketLabels.SetParameters{
+			Bucket: state.FinalName.ValueString(),
+			Label:  strings.Join(added, ","),
+		}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkBucketLabels.SetConfigs{}))
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to set bucket labels", err.Error())
+			return
+		}
+	}
+
+	state.Labels = plan.Labels
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func grantsEqual(a, b []Grant) bool {
+	return slices.EqualFunc(a, b, func(x, y Grant) bool {
+		return x.ID.Equal(y.ID)
+	})
+}
+
+// bucketSettingsEqual compares the attributes that can't be updated, all but the
+// labels and the computed final name
+func bucketSettingsEqual(a, b ObjectStorageBucket) bool {
+	return a.Bucket.Equal(b.Bucket) &&
+		a.BucketIsPrefix.Equal(b.BucketIsPrefix) &&
+		a.AuthenticatedRead.Equal(b.AuthenticatedRead) &&
+		a.AwsExecRead.Equal(b.AwsExecRead) &&
+		a.EnableVersioning.Equal(b.EnableVersioning) &&
+		grantsEqual(a.GrantFullControl, b.GrantFullControl) &&
+		grantsEqual(a.GrantRead, b.GrantRead) &&
+		grantsEqual(a.GrantReadACP, b.GrantReadACP) &&
+		grantsEqual(a.GrantWrite, b.GrantWrite) &&
+		grantsEqual(a.GrantWriteACP, b.GrantWriteACP) &&
+		a.Private.Equal(b.Private) &&
+		a.PublicRead.Equal(b.PublicRead) &&
+		a.PublicReadWrite.Equal(b.PublicReadWrite) &&
+		a.Recursive.Equal(b.Recursive)
 }
 
 func (r *objectStorageBuckets) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
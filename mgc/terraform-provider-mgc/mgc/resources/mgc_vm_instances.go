@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -25,9 +27,13 @@ import (
 	tfutil "magalu.cloud/terraform-provider-mgc/mgc/tfutil"
 )
 
+// Only active machine types and images are accepted for new values when planning
+const vmStatusActive = "active"
+
 var (
-	_ resource.Resource              = &vmInstances{}
-	_ resource.ResourceWithConfigure = &vmInstances{}
+	_ resource.Resource               = &vmInstances{}
+	_ resource.ResourceWithConfigure  = &vmInstances{}
+	_ resource.ResourceWithModifyPlan = &vmInstances{}
 )
 
 func NewVirtualMachineInstancesResource() resource.Resource {
@@ -39,6 +45,8 @@ type vmInstances struct {
 	vmInstances    sdkVmInstances.Service
 	vmImages       sdkVmImages.Service
 	vmMachineTypes sdkVmMachineTypes.Service
+	defaultLabels  []string
+	catalog        *tfutil.Catalog
 }
 
 func (r *vmInstances) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -64,6 +72,8 @@ func (r *vmInstances) Configure(ctx context.Context, req resource.ConfigureReque
 	r.vmInstances = sdkVmInstances.NewService(ctx, r.sdkClient)
 	r.vmImages = sdkVmImages.NewService(ctx, r.sdkClient)
 	r.vmMachineTypes = sdkVmMachineTypes.NewService(ctx, r.sdkClient)
+	r.defaultLabels = tfutil.DefaultLabelsFromProviderData(req.ProviderData)
+	r.catalog = tfutil.CatalogFromProviderData(req.ProviderData)
 }
 
 type vmInstancesResourceModel struct {
@@ -79,6 +89,7 @@ type vmInstancesResourceModel struct {
 	Network      networkVmInstancesModel     `tfsdk:"network"`
 	MachineType  vmInstancesMachineTypeModel `tfsdk:"machine_type"`
 	Image        tfutil.GenericIDNameModel   `tfsdk:"image"`
+	Labels       types.Set                   `tfsdk:"labels"`
 }
 
 type networkVmInstancesModel struct {
@@ -162,6 +173,12 @@ func (r *vmInstances) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				Description: "The status of the virtual machine instance.",
 				Computed:    true,
 			},
+			"labels": schema.SetAttribute{
+				Description: "The labels of the virtual machine instance, merged with the provider default_labels. Labels can only be set when the instance is created, changing them replaces the instance.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+			},
 			"image": schema.SingleNestedAttribute{
 				Description: "The image used to create the virtual machine instance.",
 				Required:    true,
@@ -301,6 +318,53 @@ func (r *vmInstances) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 	}
 }
 
+func (r *vmInstances) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// the API has no way to change the labels of existing instances, they're replaced
+	tfutil.ModifyPlanCreateOnlyLabels(ctx, r.defaultLabels, req, resp)
+
+	if r.catalog == nil {
+		return
+	}
+
+	machineTypePath := path.Root("machine_type").AtName("name")
+	machineType, diags := tfutil.PlannedStringChange(ctx, req, machineTypePath)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(r.catalog.Validate(ctx, machineType, "machine type", r.listMachineTypeNames, machineTypePath)...)
+
+	imagePath := path.Root("image").AtName("name")
+	image, diags := tfutil.PlannedStringChange(ctx, req, imagePath)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(r.catalog.Validate(ctx, image, "image", r.listImageNames, imagePath)...)
+}
+
+func (r *vmInstances) listMachineTypeNames(ctx context.Context) ([]string, error) {
+	result, err := r.vmMachineTypes.ListContext(ctx, sdkVmMachineTypes.ListParameters{}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkVmMachineTypes.ListConfigs{}))
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, x := range result.MachineTypes {
+		if x.Status == vmStatusActive {
+			names = append(names, x.Name)
+		}
+	}
+	return names, nil
+}
+
+func (r *vmInstances) listImageNames(ctx context.Context) ([]string, error) {
+	result, err := r.vmImages.ListContext(ctx, sdkVmImages.ListParameters{}, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkVmImages.ListConfigs{}))
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, x := range result.Images {
+		if x.Status == vmStatusActive {
+			names = append(names, x.Name)
+		}
+	}
+	return names, nil
+}
+
 func (r *vmInstances) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	data := vmInstancesResourceModel{}
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -316,6 +380,15 @@ func (r *vmInstances) Read(ctx context.Context, req resource.ReadRequest, resp *
 
 	data.ID = types.StringValue(getResult.Id)
 	data = r.setValuesFromServer(data, getResult)
+
+	var labels []string
+	if getResult.Labels != nil {
+		labels = *getResult.Labels
+	}
+	var diags diag.Diagnostics
+	data.Labels, diags = tfutil.LabelsToSet(ctx, labels)
+	resp.Diagnostics.Append(diags...)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -379,6 +452,16 @@ func (r *vmInstances) Create(ctx context.Context, req resource.CreateRequest, re
 
 	createParams.Network.AssociatePublicIp = state.Network.AssociatePublicIP.ValueBoolPointer()
 
+	labels, diags := tfutil.LabelsFromSet(ctx, state.Labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(labels) > 0 {
+		createLabels := sdkVmInstances.CreateParametersLabels(labels)
+		createParams.Labels = &createLabels
+	}
+
 	result, err := r.vmInstances.CreateContext(ctx, createParams, tfutil.GetConfigsFromTags(r.sdkClient.Sdk().Config().Get, sdkVmInstances.CreateConfigs{}))
 	if err != nil {
 		resp.Diagnostics.AddError(
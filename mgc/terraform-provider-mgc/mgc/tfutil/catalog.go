@@ -0,0 +1,189 @@
+package tfutil
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	maxCatalogSuggestions = 5
+	// fetches are shared by all resources waiting for them, so they aren't bound
+	// to the context of the one that started it, only to this timeout
+	catalogFetchTimeout = 2 * time.Minute
+)
+
+// CatalogFetch lists the values of a catalog kind
+type CatalogFetch func(ctx context.Context) ([]string, error)
+
+// Catalog caches the lists of valid values (machine types, images, versions...)
+// used to validate the configuration at plan time. A single Catalog is shared
+// by all resources of a provider instance, so each list is fetched only once.
+type Catalog struct {
+	mu      sync.Mutex
+	entries map[string][]string
+	group   singleflight.Group
+}
+
+func NewCatalog() *Catalog {
+	return &Catalog{entries: map[string][]string{}}
+}
+
+// CatalogFromProviderData returns the provider Catalog, or a new one if the
+// provider data doesn't carry any.
+func CatalogFromProviderData(providerData any) *Catalog {
+	if cfg, ok := providerData.(ProviderConfig); ok && cfg.Catalog != nil {
+		return cfg.Catalog
+	}
+	return NewCatalog()
+}
+
+// Values returns the cached values of the given kind, calling fetch on the first
+// use. Concurrent callers wait for the same fetch, each kind is fetched independently.
+// Failed fetches are not cached.
+func (c *Catalog) Values(ctx context.Context, kind string, fetch CatalogFetch) ([]string, error) {
+	c.mu.Lock()
+	values, ok := c.entries[kind]
+	c.mu.Unlock()
+	if ok {
+		return values, nil
+	}
+
+	ch := c.group.DoChan(kind, func() (any, error) {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), catalogFetchTimeout)
+		defer cancel()
+
+		values, err := fetch(fetchCtx)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.entries[kind] = values
+		c.mu.Unlock()
+		return values, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-ch:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.([]string), nil
+	}
+}
+
+// Validate checks that value is one of the catalog values of the given kind.
+// Unknown or empty values are skipped. If the catalog can't be fetched, a warning
+// is issued and the validation is left to the API.
+func (c *Catalog) Validate(ctx context.Context, value types.String, kind string, fetch CatalogFetch, attrPath path.Path) (diags diag.Diagnostics) {
+	if value.IsUnknown() || value.IsNull() || value.ValueString() == "" {
+		return
+	}
+
+	values, err := c.Values(ctx, kind, fetch)
+	if err != nil {
+		diags.AddAttributeWarning(attrPath, fmt.Sprintf("Unable to validate %s", kind), err.Error())
+		return
+	}
+
+	v := value.ValueString()
+	if slices.Contains(values, v) {
+		return
+	}
+
+	detail := fmt.Sprintf("%q is not an available %s.", v, kind)
+	if suggestions := Suggest(v, values, maxCatalogSuggestions); len(suggestions) > 0 {
+		detail += fmt.Sprintf(" Did you mean: %s?", strings.Join(suggestions, ", "))
+	}
+	diags.AddAttributeError(attrPath, fmt.Sprintf("Invalid %s", kind), detail)
+	return
+}
+
+// PlannedStringChange returns the planned value of a string attribute when the
+// resource is being created or the attribute is changing. Otherwise a null value
+// is returned, so values of existing resources aren't validated again.
+func PlannedStringChange(ctx context.Context, req resource.ModifyPlanRequest, attrPath path.Path) (types.String, diag.Diagnostics) {
+	var planned, current types.String
+	if req.Plan.Raw.IsNull() {
+		return types.StringNull(), nil
+	}
+
+	diags := req.Plan.GetAttribute(ctx, attrPath, &planned)
+	if diags.HasError() || req.State.Raw.IsNull() {
+		return planned, diags
+	}
+
+	diags.Append(req.State.GetAttribute(ctx, attrPath, &current)...)
+	if planned.Equal(current) {
+		return types.StringNull(), diags
+	}
+	return planned, diags
+}
+
+// Suggest returns up to limit candidates similar to value, the closest first.
+func Suggest(value string, candidates []string, limit int) []string {
+	type scored struct {
+		candidate string
+		distance  int
+	}
+
+	lowerValue := strings.ToLower(value)
+	threshold := len(value)/3 + 1
+
+	var matches []scored
+	for _, candidate := range candidates {
+		lowerCandidate := strings.ToLower(candidate)
+		distance := levenshtein(lowerValue, lowerCandidate)
+		if distance <= threshold || strings.Contains(lowerCandidate, lowerValue) || strings.Contains(lowerValue, lowerCandidate) {
+			matches = append(matches, scored{candidate, distance})
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b scored) int {
+		return a.distance - b.distance
+	})
+
+	result := []string{}
+	for _, m := range matches {
+		if len(result) == limit {
+			break
+		}
+		if !slices.Contains(result, m.candidate) {
+			result = append(result, m.candidate)
+		}
+	}
+	return result
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,118 @@
+package tfutil
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"BV1-1-10", "BV1-2-10", "BV2-2-20", "BV4-8-100", "cloud-ubuntu-24.04 LTS"}
+
+	tests := []struct {
+		name  string
+		value string
+		limit int
+		want  []string
+	}{
+		{"typo", "BV1-1-1O", 5, []string{"BV1-1-10", "BV1-2-10"}},
+		{"case insensitive", "bv4-8-100", 1, []string{"BV4-8-100"}},
+		{"substring", "ubuntu", 5, []string{"cloud-ubuntu-24.04 LTS"}},
+		{"limit", "BV1-1-1O", 1, []string{"BV1-1-10"}},
+		{"no match", "something-else", 5, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Suggest(tt.value, candidates, tt.limit))
+		})
+	}
+}
+
+func TestCatalogValuesCachesSuccessfulFetches(t *testing.T) {
+	catalog := NewCatalog()
+	calls := 0
+	fetch := func(context.Context) ([]string, error) {
+		calls++
+		return []string{"a", "b"}, nil
+	}
+
+	for range 3 {
+		values, err := catalog.Values(context.Background(), "letters", fetch)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, values)
+	}
+	assert.Equal(t, 1, calls)
+
+	failing := func(context.Context) ([]string, error) {
+		calls++
+		return nil, errors.New("unavailable")
+	}
+	_, err := catalog.Values(context.Background(), "numbers", failing)
+	assert.Error(t, err)
+	_, err = catalog.Values(context.Background(), "numbers", failing)
+	assert.Error(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestCatalogValuesSharesFetches(t *testing.T) {
+	catalog := NewCatalog()
+	started, release := make(chan struct{}), make(chan struct{})
+	var calls atomic.Int32
+	fetch := func(ctx context.Context) ([]string, error) {
+		calls.Add(1)
+		close(started)
+		<-release
+		return []string{"a"}, ctx.Err()
+	}
+
+	// the caller that started the fetch gives up, the others still get its values
+	firstCtx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error)
+	go func() {
+		_, err := catalog.Values(firstCtx, "letters", fetch)
+		firstErr <- err
+	}()
+	<-started
+
+	second := make(chan []string)
+	go func() {
+		values, _ := catalog.Values(context.Background(), "letters", fetch)
+		second <- values
+	}()
+
+	cancel()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+	close(release)
+	assert.Equal(t, []string{"a"}, <-second)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestCatalogValidate(t *testing.T) {
+	fetch := func(context.Context) ([]string, error) {
+		return []string{"BV1-1-10", "BV2-2-20"}, nil
+	}
+	attrPath := path.Root("machine_type")
+
+	diags := NewCatalog().Validate(context.Background(), types.StringValue("BV1-1-10"), "machine type", fetch, attrPath)
+	assert.False(t, diags.HasError())
+
+	diags = NewCatalog().Validate(context.Background(), types.StringUnknown(), "machine type", fetch, attrPath)
+	assert.Empty(t, diags)
+
+	diags = NewCatalog().Validate(context.Background(), types.StringValue("BV1-1-1O"), "machine type", fetch, attrPath)
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "Invalid machine type", diags[0].Summary())
+	assert.Equal(t, `"BV1-1-1O" is not an available machine type. Did you mean: BV1-1-10?`, diags[0].Detail())
+
+	diags = NewCatalog().Validate(context.Background(), types.StringValue("BV1-1-10"), "machine type", func(context.Context) ([]string, error) {
+		return nil, errors.New("unavailable")
+	}, attrPath)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, 1, diags.WarningsCount())
+}
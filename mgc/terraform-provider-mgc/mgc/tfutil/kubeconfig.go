@@ -0,0 +1,118 @@
+package tfutil
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KubeconfigCredentials holds the connection data of the current context of a
+// kubeconfig, in the format expected by the kubernetes and helm providers.
+type KubeconfigCredentials struct {
+	Host                 string
+	ClusterCACertificate string
+	ClientCertificate    string
+	ClientKey            string
+	Token                string
+}
+
+type kubeconfigFile struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			ClientCertificateData string `yaml:"client-certificate-data"`
+			ClientKeyData         string `yaml:"client-key-data"`
+			Token                 string `yaml:"token"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+// ParseKubeconfig extracts the credentials of the current context of a raw
+// kubeconfig. If no current context is set, the first context is used.
+// Base64 encoded certificate data is returned decoded (PEM).
+func ParseKubeconfig(raw string) (*KubeconfigCredentials, error) {
+	var file kubeconfigFile
+	if err := yaml.Unmarshal([]byte(raw), &file); err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+
+	if len(file.Contexts) == 0 {
+		return nil, fmt.Errorf("invalid kubeconfig: no contexts found")
+	}
+
+	ctx := file.Contexts[0]
+	if file.CurrentContext != "" {
+		found := false
+		for _, c := range file.Contexts {
+			if c.Name == file.CurrentContext {
+				ctx = c
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("invalid kubeconfig: current context %q not found", file.CurrentContext)
+		}
+	}
+
+	result := &KubeconfigCredentials{}
+	var err error
+
+	for _, c := range file.Clusters {
+		if c.Name != ctx.Context.Cluster {
+			continue
+		}
+		result.Host = c.Cluster.Server
+		if result.ClusterCACertificate, err = decodeKubeconfigData(c.Cluster.CertificateAuthorityData); err != nil {
+			return nil, fmt.Errorf("invalid kubeconfig: cluster %q certificate authority: %w", c.Name, err)
+		}
+		break
+	}
+
+	for _, u := range file.Users {
+		if u.Name != ctx.Context.User {
+			continue
+		}
+		if result.ClientCertificate, err = decodeKubeconfigData(u.User.ClientCertificateData); err != nil {
+			return nil, fmt.Errorf("invalid kubeconfig: user %q client certificate: %w", u.Name, err)
+		}
+		if result.ClientKey, err = decodeKubeconfigData(u.User.ClientKeyData); err != nil {
+			return nil, fmt.Errorf("invalid kubeconfig: user %q client key: %w", u.Name, err)
+		}
+		result.Token = u.User.Token
+		break
+	}
+
+	if result.Host == "" {
+		return nil, fmt.Errorf("invalid kubeconfig: cluster %q not found", ctx.Context.Cluster)
+	}
+
+	return result, nil
+}
+
+func decodeKubeconfigData(data string) (string, error) {
+	if data == "" {
+		return "", nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", err
+	}
+	return string(decoded), nil
+}
@@ -0,0 +1,80 @@
+package tfutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+current-context: prod
+clusters:
+- name: other
+  cluster:
+    server: https://other.example.com
+- name: prod-cluster
+  cluster:
+    server: https://prod.example.com:6443
+    certificate-authority-data: Q0EtREFUQQ==
+contexts:
+- name: other
+  context:
+    cluster: other
+    user: other
+- name: prod
+  context:
+    cluster: prod-cluster
+    user: admin
+users:
+- name: admin
+  user:
+    client-certificate-data: Q0VSVA==
+    client-key-data: S0VZ
+`
+
+func TestParseKubeconfig(t *testing.T) {
+	creds, err := ParseKubeconfig(testKubeconfig)
+	assert.NoError(t, err)
+	assert.Equal(t, &KubeconfigCredentials{
+		Host:                 "https://prod.example.com:6443",
+		ClusterCACertificate: "CA-DATA",
+		ClientCertificate:    "CERT",
+		ClientKey:            "KEY",
+	}, creds)
+}
+
+func TestParseKubeconfigErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		raw  string
+	}{
+		{
+			name: "Invalid YAML",
+			raw:  "clusters: [",
+		},
+		{
+			name: "No contexts",
+			raw:  "clusters: []\n",
+		},
+		{
+			name: "Missing current context",
+			raw:  "current-context: x\ncontexts:\n- name: y\n  context:\n    cluster: y\n",
+		},
+		{
+			name: "Missing cluster",
+			raw:  "contexts:\n- name: y\n  context:\n    cluster: y\n",
+		},
+		{
+			name: "Invalid certificate data",
+			raw:  "contexts:\n- name: y\n  context:\n    cluster: y\nclusters:\n- name: y\n  cluster:\n    server: https://y\n    certificate-authority-data: '!!'\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseKubeconfig(tc.raw)
+			assert.Error(t, err)
+		})
+	}
+}
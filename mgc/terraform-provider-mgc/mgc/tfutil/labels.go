@@ -0,0 +1,232 @@
+package tfutil
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	LabelsPath = path.Root("labels")
+	TagsPath   = path.Root("tags")
+)
+
+// DefaultLabelsFromProviderData returns the provider-level default_labels
+// from the data received by a resource Configure.
+func DefaultLabelsFromProviderData(providerData any) []string {
+	cfg, ok := providerData.(ProviderConfig)
+	if !ok {
+		return nil
+	}
+	labels := make([]string, 0, len(cfg.DefaultLabels))
+	for _, label := range cfg.DefaultLabels {
+		if label.IsNull() || label.IsUnknown() {
+			continue
+		}
+		labels = append(labels, label.ValueString())
+	}
+	return labels
+}
+
+// MergeLabels returns the union of the default and the resource labels,
+// defaults first, without duplicates.
+func MergeLabels(defaultLabels []string, labels []string) []string {
+	seen := make(map[string]struct{}, len(defaultLabels)+len(labels))
+	result := make([]string, 0, len(defaultLabels)+len(labels))
+	for _, list := range [][]string{defaultLabels, labels} {
+		for _, label := range list {
+			if _, ok := seen[label]; ok {
+				continue
+			}
+			seen[label] = struct{}{}
+			result = append(result, label)
+		}
+	}
+	return result
+}
+
+// ModifyPlanDefaultLabels sets the planned "labels" attribute to the union of
+// the configured labels and the provider default labels, so the labels applied
+// by the provider are part of the plan and do not show up as a diff after
+// every refresh. When neither are set, the labels are not managed and the prior
+// state is kept.
+//
+// It returns true if the planned labels differ from the prior state.
+func ModifyPlanDefaultLabels(ctx context.Context, defaultLabels []string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) (changed bool) {
+	return modifyPlanLabels(ctx, LabelsPath, LabelsToSet, defaultLabels, req, resp)
+}
+
+// ModifyPlanCreateOnlyLabels is ModifyPlanDefaultLabels for resources whose labels
+// can only be set when they are created. Changing the labels of an existing
+// resource, including the provider default labels, replaces it.
+func ModifyPlanCreateOnlyLabels(ctx context.Context, defaultLabels []string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if modifyPlanLabels(ctx, LabelsPath, LabelsToSet, defaultLabels, req, resp) && !req.State.Raw.IsNull() {
+		resp.RequiresReplace.Append(LabelsPath)
+	}
+}
+
+// ModifyPlanCreateOnlyTags is ModifyPlanCreateOnlyLabels for resources that
+// have a "tags" list instead, such as Kubernetes node pools.
+func ModifyPlanCreateOnlyTags(ctx context.Context, defaultLabels []string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if modifyPlanLabels(ctx, TagsPath, LabelsToList, defaultLabels, req, resp) && !req.State.Raw.IsNull() {
+		resp.RequiresReplace.Append(TagsPath)
+	}
+}
+
+// labelsValue is the attribute value holding the labels, a set or a list of strings
+type labelsValue interface {
+	attr.Value
+	ElementsAs(ctx context.Context, target interface{}, allowUnhandled bool) diag.Diagnostics
+}
+
+func modifyPlanLabels[T labelsValue](
+	ctx context.Context,
+	attrPath path.Path,
+	toValue func(context.Context, []string) (T, diag.Diagnostics),
+	defaultLabels []string,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) (changed bool) {
+	// Resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return false
+	}
+
+	var configured T
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, attrPath, &configured)...)
+	if resp.Diagnostics.HasError() {
+		return false
+	}
+	// the labels will only be known when applying, they may differ
+	if configured.IsUnknown() {
+		return true
+	}
+
+	stateLabels, diags := toValue(ctx, nil)
+	resp.Diagnostics.Append(diags...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, attrPath, &stateLabels)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return false
+	}
+
+	var configuredLabels []types.String
+	resp.Diagnostics.Append(configured.ElementsAs(ctx, &configuredLabels, false)...)
+	if resp.Diagnostics.HasError() {
+		return false
+	}
+
+	labels := make([]string, 0, len(configuredLabels))
+	for _, label := range configuredLabels {
+		if label.IsUnknown() {
+			return true
+		}
+		labels = append(labels, label.ValueString())
+	}
+
+	var current []string
+	if !stateLabels.IsNull() && !stateLabels.IsUnknown() {
+		resp.Diagnostics.Append(stateLabels.ElementsAs(ctx, &current, false)...)
+		if resp.Diagnostics.HasError() {
+			return false
+		}
+	}
+
+	planned := stateLabels
+	merged := MergeLabels(defaultLabels, labels)
+	switch {
+	case len(merged) > 0:
+		// keep the state if only the order differs, lists are returned in the API order
+		if added, removed := DiffLabels(current, merged); len(added) > 0 || len(removed) > 0 {
+			planned, diags = toValue(ctx, merged)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return false
+			}
+		}
+	case !configured.IsNull():
+		planned, _ = toValue(ctx, nil)
+	case req.State.Raw.IsNull():
+		// not managed, new resources get whatever the API returns
+		return false
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, attrPath, planned)...)
+	return !planned.Equal(stateLabels)
+}
+
+// SplitLabels converts comma separated labels, as returned by the API, to a list.
+func SplitLabels(labels string) []string {
+	var result []string
+	for _, label := range strings.Split(labels, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			result = append(result, label)
+		}
+	}
+	return result
+}
+
+// DiffLabels returns the labels that must be added to and removed from
+// current to reach desired.
+func DiffLabels(current, desired []string) (added, removed []string) {
+	currentSet := make(map[string]struct{}, len(current))
+	for _, label := range current {
+		currentSet[label] = struct{}{}
+	}
+	desiredSet := make(map[string]struct{}, len(desired))
+	for _, label := range desired {
+		desiredSet[label] = struct{}{}
+		if _, ok := currentSet[label]; !ok {
+			added = append(added, label)
+		}
+	}
+	for _, label := range current {
+		if _, ok := desiredSet[label]; !ok {
+			removed = append(removed, label)
+		}
+	}
+	return
+}
+
+// LabelsToSet converts labels returned by the API to the "labels" attribute
+// value. Empty labels are represented as null.
+func LabelsToSet(ctx context.Context, labels []string) (types.Set, diag.Diagnostics) {
+	if len(labels) == 0 {
+		return types.SetNull(types.StringType), nil
+	}
+	return types.SetValueFrom(ctx, types.StringType, labels)
+}
+
+// LabelsToList is LabelsToSet for "tags" lists.
+func LabelsToList(ctx context.Context, labels []string) (types.List, diag.Diagnostics) {
+	if len(labels) == 0 {
+		return types.ListNull(types.StringType), nil
+	}
+	return types.ListValueFrom(ctx, types.StringType, labels)
+}
+
+// LabelsFromList is LabelsFromSet for "tags" lists.
+func LabelsFromList(ctx context.Context, labels types.List) ([]string, diag.Diagnostics) {
+	if labels.IsNull() || labels.IsUnknown() {
+		return nil, nil
+	}
+	var result []string
+	diags := labels.ElementsAs(ctx, &result, false)
+	return result, diags
+}
+
+// LabelsFromSet converts the "labels" attribute value to a plain list.
+func LabelsFromSet(ctx context.Context, labels types.Set) ([]string, diag.Diagnostics) {
+	if labels.IsNull() || labels.IsUnknown() {
+		return nil, nil
+	}
+	var result []string
+	diags := labels.ElementsAs(ctx, &result, false)
+	return result, diags
+}
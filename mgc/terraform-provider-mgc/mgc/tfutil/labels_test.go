@@ -0,0 +1,189 @@
+package tfutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeLabels(t *testing.T) {
+	testCases := []struct {
+		name     string
+		defaults []string
+		labels   []string
+		expected []string
+	}{
+		{
+			name:     "No labels",
+			expected: []string{},
+		},
+		{
+			name:     "Only defaults",
+			defaults: []string{"cost-center:42", "owner:finops"},
+			expected: []string{"cost-center:42", "owner:finops"},
+		},
+		{
+			name:     "Only resource labels",
+			labels:   []string{"app:web"},
+			expected: []string{"app:web"},
+		},
+		{
+			name:     "Merged without duplicates",
+			defaults: []string{"cost-center:42", "owner:finops"},
+			labels:   []string{"app:web", "owner:finops"},
+			expected: []string{"cost-center:42", "owner:finops", "app:web"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MergeLabels(tc.defaults, tc.labels))
+		})
+	}
+}
+
+func TestDiffLabels(t *testing.T) {
+	added, removed := DiffLabels([]string{"a", "b", "c"}, []string{"b", "d"})
+	assert.Equal(t, []string{"d"}, added)
+	assert.Equal(t, []string{"a", "c"}, removed)
+
+	added, removed = DiffLabels(nil, nil)
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+}
+
+func TestDefaultLabelsFromProviderData(t *testing.T) {
+	cfg := ProviderConfig{
+		DefaultLabels: []types.String{types.StringValue("owner:finops"), types.StringNull()},
+	}
+	assert.Equal(t, []string{"owner:finops"}, DefaultLabelsFromProviderData(cfg))
+	assert.Nil(t, DefaultLabelsFromProviderData(nil))
+}
+
+func TestSplitLabels(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, SplitLabels("a, b,,"))
+	assert.Empty(t, SplitLabels(""))
+}
+
+var labelsTestSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"labels": schema.SetAttribute{Optional: true, Computed: true, ElementType: types.StringType},
+		"tags":   schema.ListAttribute{Optional: true, Computed: true, ElementType: types.StringType},
+	},
+}
+
+var labelsTestType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"labels": tftypes.Set{ElementType: tftypes.String},
+	"tags":   tftypes.List{ElementType: tftypes.String},
+}}
+
+func labelsTestStrings(t tftypes.Type, labels []string) tftypes.Value {
+	if labels == nil {
+		return tftypes.NewValue(t, nil)
+	}
+	elements := make([]tftypes.Value, len(labels))
+	for i, label := range labels {
+		elements[i] = tftypes.NewValue(tftypes.String, label)
+	}
+	return tftypes.NewValue(t, elements)
+}
+
+// labelsTestValue is the resource value with the given labels and tags, nil is null
+func labelsTestValue(labels []string, tags []string) tftypes.Value {
+	return tftypes.NewValue(labelsTestType, map[string]tftypes.Value{
+		"labels": labelsTestStrings(tftypes.Set{ElementType: tftypes.String}, labels),
+		"tags":   labelsTestStrings(tftypes.List{ElementType: tftypes.String}, tags),
+	})
+}
+
+func labelsTestModifyPlanRequest(state, config tftypes.Value) (resource.ModifyPlanRequest, *resource.ModifyPlanResponse) {
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: labelsTestSchema, Raw: config},
+		Plan:   tfsdk.Plan{Schema: labelsTestSchema, Raw: config},
+		State:  tfsdk.State{Schema: labelsTestSchema, Raw: state},
+	}
+	return req, &resource.ModifyPlanResponse{Plan: req.Plan}
+}
+
+func TestModifyPlanCreateOnlyLabels(t *testing.T) {
+	ctx := context.Background()
+	defaults := []string{"owner:finops"}
+
+	testCases := []struct {
+		name     string
+		state    []string
+		config   []string
+		expected []string
+		replace  bool
+	}{
+		{name: "create merges defaults", config: []string{"app:web"}, expected: []string{"owner:finops", "app:web"}},
+		{name: "unchanged", state: []string{"app:web", "owner:finops"}, config: []string{"app:web"}, expected: []string{"app:web", "owner:finops"}},
+		{name: "new defaults replace existing", state: []string{"app:web"}, config: []string{"app:web"}, expected: []string{"owner:finops", "app:web"}, replace: true},
+		{name: "adding labels replaces existing", state: []string{"app:web", "owner:finops"}, config: []string{"app:web", "env:prod"}, expected: []string{"owner:finops", "app:web", "env:prod"}, replace: true},
+		{name: "removing labels replaces existing", state: []string{"app:web", "env:prod", "owner:finops"}, config: []string{"app:web"}, expected: []string{"owner:finops", "app:web"}, replace: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state := tftypes.NewValue(labelsTestType, nil)
+			if tc.state != nil {
+				state = labelsTestValue(tc.state, nil)
+			}
+			req, resp := labelsTestModifyPlanRequest(state, labelsTestValue(tc.config, nil))
+
+			ModifyPlanCreateOnlyLabels(ctx, defaults, req, resp)
+			assert.False(t, resp.Diagnostics.HasError())
+			assert.Equal(t, tc.replace, resp.RequiresReplace.Contains(LabelsPath))
+
+			var planned types.Set
+			resp.Plan.GetAttribute(ctx, LabelsPath, &planned)
+			labels, _ := LabelsFromSet(ctx, planned)
+			assert.ElementsMatch(t, tc.expected, labels)
+		})
+	}
+}
+
+func TestModifyPlanCreateOnlyTags(t *testing.T) {
+	ctx := context.Background()
+	defaults := []string{"owner:finops"}
+
+	testCases := []struct {
+		name     string
+		defaults []string
+		state    []string
+		config   []string
+		expected []string
+		replace  bool
+	}{
+		{name: "create merges defaults", defaults: defaults, config: []string{"app:web"}, expected: []string{"owner:finops", "app:web"}},
+		{name: "create without tags is not managed", expected: nil},
+		{name: "API order is kept", defaults: defaults, state: []string{"app:web", "owner:finops"}, config: []string{"app:web"}, expected: []string{"app:web", "owner:finops"}},
+		{name: "new defaults replace existing", defaults: defaults, state: []string{"app:web"}, config: []string{"app:web"}, expected: []string{"owner:finops", "app:web"}, replace: true},
+		{name: "unmanaged tags are kept", state: []string{"app:web"}, expected: []string{"app:web"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state := tftypes.NewValue(labelsTestType, nil)
+			if tc.state != nil {
+				state = labelsTestValue(nil, tc.state)
+			}
+			req, resp := labelsTestModifyPlanRequest(state, labelsTestValue(nil, tc.config))
+
+			ModifyPlanCreateOnlyTags(ctx, tc.defaults, req, resp)
+			assert.False(t, resp.Diagnostics.HasError())
+			assert.Equal(t, tc.replace, resp.RequiresReplace.Contains(TagsPath))
+
+			var planned types.List
+			resp.Plan.GetAttribute(ctx, TagsPath, &planned)
+			tags, _ := LabelsFromList(ctx, planned)
+			assert.Equal(t, tc.expected, tags)
+		})
+	}
+}
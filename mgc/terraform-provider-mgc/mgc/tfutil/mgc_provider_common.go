@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	mgcSdk "magalu.cloud/lib"
 )
 
 type findKey func(key string, out any) error
@@ -16,6 +17,10 @@ type ProviderConfig struct {
 	Env           types.String         `tfsdk:"env"`
 	ApiKey        types.String         `tfsdk:"api_key"`
 	ObjectStorage *ObjectStorageConfig `tfsdk:"object_storage"`
+	DefaultLabels []types.String       `tfsdk:"default_labels"`
+	Workspace     types.String         `tfsdk:"workspace"`
+	Catalog       *Catalog             `tfsdk:"-"`
+	SdkClient     *mgcSdk.Client       `tfsdk:"-"`
 }
 
 type KeyPair struct {